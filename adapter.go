@@ -10,8 +10,8 @@ type Adapter interface {
 	Ping(ctx context.Context) error
 	Aggregate(ctx context.Context, query Query, mode string, field string) (int, error)
 	Query(ctx context.Context, query Query) (Cursor, error)
-	Insert(ctx context.Context, query Query, primaryField string, mutates map[string]Mutate) (interface{}, error)
-	InsertAll(ctx context.Context, query Query, primaryField string, fields []string, bulkMutates []map[string]Mutate) ([]interface{}, error)
+	Insert(ctx context.Context, query Query, primaryField string, mutates map[string]Mutate, onConflict OnConflict) (interface{}, error)
+	InsertAll(ctx context.Context, query Query, primaryField string, fields []string, bulkMutates []map[string]Mutate, onConflict OnConflict) ([]interface{}, error)
 	Update(ctx context.Context, query Query, mutates map[string]Mutate) (int, error)
 	Delete(ctx context.Context, query Query) (int, error)
 
@@ -21,3 +21,21 @@ type Adapter interface {
 
 	Apply(ctx context.Context, migration Migration) error
 }
+
+// ReturningAdapter is an optional interface implemented by adapter that is able to return affected rows of insert and update (RETURNING clause).
+// When implemented, repository uses it to reload record on insertion and update instead of issuing another query.
+// Returned fields is defined by query's select, default to all fields.
+type ReturningAdapter interface {
+	InsertReturning(ctx context.Context, query Query, mutates map[string]Mutate, onConflict OnConflict) (Cursor, error)
+	InsertAllReturning(ctx context.Context, query Query, fields []string, bulkMutates []map[string]Mutate, onConflict OnConflict) (Cursor, error)
+	UpdateReturning(ctx context.Context, query Query, mutates map[string]Mutate) (Cursor, error)
+}
+
+// InspectorAdapter is an optional interface implemented by adapter that is able to inspect the live database schema.
+// Inspected schema is described using the same types as migration, table definitions contains the columns followed by
+// primary, unique and foreign keys, while the rest of the indexes are returned separately.
+type InspectorAdapter interface {
+	InspectTables(ctx context.Context) ([]string, error)
+	InspectTable(ctx context.Context, name string) (Table, error)
+	InspectIndexes(ctx context.Context, table string) ([]Index, error)
+}
@@ -0,0 +1,164 @@
+package mysql
+
+import (
+	"context"
+	db "database/sql"
+	"strings"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/adapter/sql"
+)
+
+// InspectTables returns the name of tables in current database.
+func (a *Adapter) InspectTables(ctx context.Context) ([]string, error) {
+	rows, err := query(ctx, a.Adapter, "SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, name)
+	}
+
+	return tables, rows.Err()
+}
+
+// InspectTable returns the columns, primary key, unique keys and foreign keys of a table.
+// Single auto increment primary key column is returned as ID or BigID column.
+// mysql implements unique key as unique index, so every unique index is returned as unique key.
+func (a *Adapter) InspectTable(ctx context.Context, name string) (rel.Table, error) {
+	var (
+		table = rel.Table{Name: name}
+	)
+
+	columns, err := a.inspectColumns(ctx, name)
+	if err != nil {
+		return table, err
+	}
+
+	if len(columns) == 0 {
+		return table, rel.ErrNotFound
+	}
+
+	rows, err := query(ctx, a.Adapter, `SELECT tc.CONSTRAINT_NAME, tc.CONSTRAINT_TYPE, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.UPDATE_RULE, rc.DELETE_RULE
+		FROM information_schema.TABLE_CONSTRAINTS tc
+		JOIN information_schema.KEY_COLUMN_USAGE kcu ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.TABLE_NAME = tc.TABLE_NAME AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		LEFT JOIN information_schema.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND rc.TABLE_NAME = tc.TABLE_NAME AND rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+		ORDER BY tc.CONSTRAINT_NAME, kcu.ORDINAL_POSITION;`, name)
+	if err != nil {
+		return table, err
+	}
+
+	keys, err := sql.ScanKeys(rows)
+	if err != nil {
+		return table, err
+	}
+
+	for i := range columns {
+		table.Definitions = append(table.Definitions, columns[i])
+	}
+
+	for _, key := range keys {
+		if key.Type == rel.PrimaryKey {
+			// mysql always names primary key as PRIMARY.
+			key.Name = ""
+
+			if len(key.Columns) == 1 && primaryColumn(columns, key.Columns[0]) {
+				continue
+			}
+		}
+
+		table.Definitions = append(table.Definitions, key)
+	}
+
+	return table, nil
+}
+
+func (a *Adapter) inspectColumns(ctx context.Context, table string) ([]rel.Column, error) {
+	rows, err := query(ctx, a.Adapter, `SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, EXTRA
+		FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION;`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []rel.Column
+	for rows.Next() {
+		var (
+			column           rel.Column
+			typ, null, extra string
+			def              db.NullString
+		)
+
+		if err := rows.Scan(&column.Name, &typ, &null, &def, &extra); err != nil {
+			return nil, err
+		}
+
+		sql.ParseColumnType(&column, typ)
+		column.Required = null == "NO"
+
+		if def.Valid {
+			column.Default = def.String
+		}
+
+		if strings.Contains(strings.ToLower(extra), "auto_increment") {
+			column.Options = "AUTO_INCREMENT"
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+// primaryColumn converts auto increment column of single column primary key into ID or BigID column.
+func primaryColumn(columns []rel.Column, name string) bool {
+	for i := range columns {
+		if columns[i].Name != name || columns[i].Options != "AUTO_INCREMENT" || !columns[i].Unsigned {
+			continue
+		}
+
+		switch columns[i].Type {
+		case rel.Int:
+			columns[i] = rel.Column{Name: name, Type: rel.ID}
+			return true
+		case rel.BigInt:
+			columns[i] = rel.Column{Name: name, Type: rel.BigID}
+			return true
+		}
+	}
+
+	return false
+}
+
+// InspectIndexes returns the indexes of a table that are not used by unique or foreign key.
+func (a *Adapter) InspectIndexes(ctx context.Context, table string) ([]rel.Index, error) {
+	rows, err := query(ctx, a.Adapter, `SELECT s.INDEX_NAME, s.NON_UNIQUE = 0, s.COLUMN_NAME
+		FROM information_schema.STATISTICS s
+		WHERE s.TABLE_SCHEMA = DATABASE() AND s.TABLE_NAME = ? AND s.INDEX_NAME <> 'PRIMARY' AND NOT EXISTS (
+			SELECT 1 FROM information_schema.TABLE_CONSTRAINTS tc
+			WHERE tc.TABLE_SCHEMA = s.TABLE_SCHEMA AND tc.TABLE_NAME = s.TABLE_NAME AND tc.CONSTRAINT_NAME = s.INDEX_NAME
+		)
+		ORDER BY s.INDEX_NAME, s.SEQ_IN_INDEX;`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.ScanIndexes(table, rows)
+}
+
+func query(ctx context.Context, adapter *sql.Adapter, statement string, args ...interface{}) (*db.Rows, error) {
+	if adapter.Tx != nil {
+		return adapter.Tx.QueryContext(ctx, statement, args...)
+	}
+
+	return adapter.DB.QueryContext(ctx, statement, args...)
+}
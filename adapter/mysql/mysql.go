@@ -13,6 +13,7 @@
 package mysql
 
 import (
+	"context"
 	db "database/sql"
 	"strings"
 
@@ -26,16 +27,24 @@ type Adapter struct {
 }
 
 var (
-	_ rel.Adapter = (*Adapter)(nil)
+	_ rel.Adapter          = (*Adapter)(nil)
+	_ rel.InspectorAdapter = (*Adapter)(nil)
 
 	// Config for mysql adapter.
 	Config = sql.Config{
 		DropIndexOnTable: true,
+		OnDuplicateKey:   true,
+		ModifyColumn:     true,
+		DropKeyByType:    true,
 		Placeholder:      "?",
 		EscapeChar:       "`",
 		IncrementFunc:    incrementFunc,
 		ErrorFunc:        errorFunc,
 		MapColumnFunc:    sql.MapColumn,
+		JSONContainsFunc: sql.JSONContains,
+		JSONExtractFunc:  sql.JSONExtract,
+		ArgumentFunc:     sql.JSONArray,
+		AlterTableFunc:   alterTableFunc,
 	}
 )
 
@@ -63,6 +72,15 @@ func Open(dsn string) (*Adapter, error) {
 	return New(database), err
 }
 
+// Begin begins a new transaction.
+func (a *Adapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	newAdapter, err := a.Adapter.Begin(ctx)
+
+	return &Adapter{
+		Adapter: newAdapter.(*sql.Adapter),
+	}, err
+}
+
 func incrementFunc(adapter sql.Adapter) int {
 	var variable string
 	var increment int
@@ -78,6 +96,93 @@ func incrementFunc(adapter sql.Adapter) int {
 	return increment
 }
 
+// alterTableFunc resolves existing column definition when changing nullability and constraint type when dropping constraint.
+// mysql requires the whole column to be redefined to change its nullability, and uses different syntax to drop each type of constraint.
+func alterTableFunc(ctx context.Context, adapter *sql.Adapter, table rel.Table) error {
+	var (
+		err         error
+		definitions = make([]rel.TableDefinition, len(table.Definitions))
+	)
+
+	for i := range table.Definitions {
+		switch v := table.Definitions[i].(type) {
+		case rel.Column:
+			if v.Op == rel.SchemaAlter && (v.Alter == rel.AlterColumnNull || v.Alter == rel.AlterColumnNotNull) {
+				err = lookupColumn(ctx, adapter, table.Name, &v)
+			}
+
+			definitions[i] = v
+		case rel.Key:
+			if v.Op == rel.SchemaDrop && v.Type == "" {
+				err = lookupConstraint(ctx, adapter, table.Name, &v)
+			}
+
+			definitions[i] = v
+		default:
+			definitions[i] = v
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	table.Definitions = definitions
+
+	_, _, err = adapter.Exec(ctx, sql.NewBuilder(adapter.Config).Table(table), nil)
+	return err
+}
+
+func lookupColumn(ctx context.Context, adapter *sql.Adapter, table string, column *rel.Column) error {
+	var (
+		typ, extra string
+		def        db.NullString
+		statement  = "SELECT COLUMN_TYPE, COLUMN_DEFAULT, EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?;"
+	)
+
+	if err := queryRow(ctx, adapter, statement, table, column.Name).Scan(&typ, &def, &extra); err != nil {
+		return err
+	}
+
+	column.Type = rel.ColumnType(typ)
+	column.Required = column.Alter == rel.AlterColumnNotNull
+	column.Options = strings.TrimSpace(strings.Replace(extra, "DEFAULT_GENERATED", "", 1))
+
+	if def.Valid {
+		if strings.HasPrefix(def.String, "CURRENT_TIMESTAMP") {
+			column.Options = strings.TrimSpace("DEFAULT " + def.String + " " + column.Options)
+		} else {
+			column.Default = def.String
+		}
+	}
+
+	return nil
+}
+
+func lookupConstraint(ctx context.Context, adapter *sql.Adapter, table string, key *rel.Key) error {
+	var (
+		typ       string
+		statement = "SELECT CONSTRAINT_TYPE FROM information_schema.TABLE_CONSTRAINTS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND CONSTRAINT_NAME = ?;"
+	)
+
+	err := queryRow(ctx, adapter, statement, table, key.Name).Scan(&typ)
+	if err == db.ErrNoRows {
+		// let database reports the error.
+		return nil
+	}
+
+	key.Type = rel.KeyType(typ)
+	return err
+}
+
+func queryRow(ctx context.Context, adapter *sql.Adapter, statement string, args ...interface{}) *db.Row {
+	if adapter.Tx != nil {
+		return adapter.Tx.QueryRowContext(ctx, statement, args...)
+	}
+
+	return adapter.DB.QueryRowContext(ctx, statement, args...)
+}
+
 func errorFunc(err error) error {
 	if err == nil {
 		return nil
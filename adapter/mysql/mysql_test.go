@@ -6,6 +6,8 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/go-rel/rel"
@@ -24,6 +26,32 @@ func dsn() string {
 	return "root@tcp(localhost:3306)/rel_test?charset=utf8&parseTime=True&loc=Local"
 }
 
+// combinationFlags skips intersect and except specs on mysql older than 8.0.31, since they're not supported.
+func combinationFlags(adapter *Adapter) []specs.Flag {
+	var (
+		version string
+		numbers [3]int
+	)
+
+	if err := adapter.DB.QueryRowContext(ctx, "SELECT VERSION();").Scan(&version); err != nil {
+		return []specs.Flag{specs.SkipIntersectExcept}
+	}
+
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		version = version[:i]
+	}
+
+	for i, part := range strings.SplitN(version, ".", 3) {
+		numbers[i], _ = strconv.Atoi(part)
+	}
+
+	if numbers[0] < 8 || (numbers[0] == 8 && numbers[1] == 0 && numbers[2] < 31) {
+		return []specs.Flag{specs.SkipIntersectExcept}
+	}
+
+	return nil
+}
+
 func TestAdapter_specs(t *testing.T) {
 	adapter, err := Open(dsn())
 	assert.Nil(t, err)
@@ -37,12 +65,23 @@ func TestAdapter_specs(t *testing.T) {
 
 	// Migration Specs
 	// - Rename column is only supported by MySQL 8.0
-	specs.Migrate(t, repo, specs.SkipRenameColumn)
+	specs.Migrate(t, repo, specs.SkipRenameColumn|specs.SkipCheckConstraint)
+
+	// Inspect Specs
+	specs.Inspect(t, repo)
 
 	// Query Specs
 	specs.Query(t, repo)
 	specs.QueryJoin(t, repo)
+	specs.QueryJoinAssoc(t, repo)
+	specs.QueryJSON(t, repo)
+	specs.QueryArray(t, repo)
+	specs.QuerySubquery(t, repo)
+	specs.QueryWith(t, repo)
+	specs.QueryCombination(t, repo, combinationFlags(adapter)...)
+	specs.QuerySelectExpr(t, repo)
 	specs.QueryNotFound(t, repo)
+	specs.QueryPage(t, repo)
 
 	// Preload specs
 	specs.PreloadHasMany(t, repo)
@@ -54,9 +93,12 @@ func TestAdapter_specs(t *testing.T) {
 	specs.PreloadBelongsTo(t, repo)
 	specs.PreloadBelongsToWithQuery(t, repo)
 	specs.PreloadBelongsToSlice(t, repo)
+	specs.PreloadNested(t, repo)
+	specs.PreloadLimit(t, repo)
 
 	// Aggregate Specs
 	specs.Aggregate(t, repo)
+	specs.AggregateAll(t, repo)
 
 	// Insert Specs
 	specs.Insert(t, repo)
@@ -65,6 +107,12 @@ func TestAdapter_specs(t *testing.T) {
 	specs.InsertBelongsTo(t, repo)
 	specs.Inserts(t, repo)
 	specs.InsertAll(t, repo)
+	specs.InsertOnConflictIgnore(t, repo)
+	specs.InsertOnConflictReplace(t, repo)
+	specs.InsertOnConflictUpdate(t, repo)
+	specs.InsertAllOnConflictIgnore(t, repo)
+	specs.InsertReload(t, repo)
+	specs.InsertAllReload(t, repo)
 	specs.InsertAllPartialCustomPrimary(t, repo)
 
 	// Update Specs
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	db "database/sql"
+	"strings"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/adapter/sql"
+)
+
+// InspectTables returns the name of tables in current schema.
+func (adapter *Adapter) InspectTables(ctx context.Context) ([]string, error) {
+	rows, err := adapter.query(ctx, "SELECT tablename FROM pg_catalog.pg_tables WHERE schemaname = current_schema() ORDER BY tablename;", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, name)
+	}
+
+	return tables, rows.Err()
+}
+
+// InspectTable returns the columns, primary key, unique keys and foreign keys of a table.
+// Single serial primary key column is returned as ID or BigID column, and other integer serial column is returned as Serial column.
+func (adapter *Adapter) InspectTable(ctx context.Context, name string) (rel.Table, error) {
+	var (
+		table = rel.Table{Name: name}
+	)
+
+	columns, serials, err := adapter.inspectColumns(ctx, name)
+	if err != nil {
+		return table, err
+	}
+
+	if len(columns) == 0 {
+		return table, rel.ErrNotFound
+	}
+
+	rows, err := adapter.query(ctx, `SELECT c.conname,
+			CASE c.contype WHEN 'p' THEN 'PRIMARY KEY' WHEN 'u' THEN 'UNIQUE' ELSE 'FOREIGN KEY' END,
+			a.attname, rt.relname, ra.attname,
+			CASE c.confupdtype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END,
+			CASE c.confdeltype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END
+		FROM pg_catalog.pg_constraint c
+		JOIN pg_catalog.pg_class t ON t.oid = c.conrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+		CROSS JOIN LATERAL unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		LEFT JOIN pg_catalog.pg_class rt ON rt.oid = c.confrelid
+		LEFT JOIN pg_catalog.pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = c.confkey[k.ord::int]
+		WHERE n.nspname = current_schema() AND t.relname = $1 AND c.contype IN ('p', 'u', 'f')
+		ORDER BY c.conname, k.ord;`, []interface{}{name})
+	if err != nil {
+		return table, err
+	}
+
+	keys, err := sql.ScanKeys(rows)
+	if err != nil {
+		return table, err
+	}
+
+	for i := range keys {
+		if keys[i].Type == rel.PrimaryKey && len(keys[i].Columns) == 1 && serials[keys[i].Columns[0]] && primaryColumn(columns, keys[i].Columns[0]) {
+			keys = append(keys[:i], keys[i+1:]...)
+			break
+		}
+	}
+
+	for i := range columns {
+		table.Definitions = append(table.Definitions, columns[i])
+	}
+
+	for i := range keys {
+		table.Definitions = append(table.Definitions, keys[i])
+	}
+
+	return table, nil
+}
+
+// inspectColumns returns the columns of a table and the name of columns that uses sequence as default value.
+func (adapter *Adapter) inspectColumns(ctx context.Context, table string) ([]rel.Column, map[string]bool, error) {
+	rows, err := adapter.query(ctx, `SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull, pg_get_expr(d.adbin, d.adrelid)
+		FROM pg_catalog.pg_attribute a
+		JOIN pg_catalog.pg_class t ON t.oid = a.attrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+		LEFT JOIN pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = current_schema() AND t.relname = $1 AND t.relkind = 'r' AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum;`, []interface{}{table})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var (
+		columns []rel.Column
+		serials = map[string]bool{}
+	)
+
+	for rows.Next() {
+		var (
+			column rel.Column
+			typ    string
+			def    db.NullString
+		)
+
+		if err := rows.Scan(&column.Name, &typ, &column.Required, &def); err != nil {
+			return nil, nil, err
+		}
+
+		sql.ParseColumnType(&column, typ)
+
+		switch {
+		case !def.Valid:
+		case strings.HasPrefix(def.String, "nextval("):
+			serials[column.Name] = true
+			if column.Type == rel.Int {
+				column.Type = rel.Serial
+			}
+		default:
+			column.Default = sql.ParseDefault(def.String)
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, serials, rows.Err()
+}
+
+// primaryColumn converts serial column of single column primary key into ID or BigID column.
+func primaryColumn(columns []rel.Column, name string) bool {
+	for i := range columns {
+		if columns[i].Name != name {
+			continue
+		}
+
+		switch columns[i].Type {
+		case rel.Serial:
+			columns[i] = rel.Column{Name: name, Type: rel.ID}
+			return true
+		case rel.BigInt:
+			columns[i] = rel.Column{Name: name, Type: rel.BigID}
+			return true
+		}
+	}
+
+	return false
+}
+
+// InspectIndexes returns the indexes of a table that are not used by primary or unique key.
+func (adapter *Adapter) InspectIndexes(ctx context.Context, table string) ([]rel.Index, error) {
+	rows, err := adapter.query(ctx, `SELECT i.relname, ix.indisunique, a.attname
+		FROM pg_catalog.pg_index ix
+		JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+		JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+		CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord)
+		LEFT JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE n.nspname = current_schema() AND t.relname = $1 AND NOT EXISTS (
+			SELECT 1 FROM pg_catalog.pg_constraint c
+			WHERE c.conrelid = ix.indrelid AND c.conindid = ix.indexrelid AND c.contype IN ('p', 'u', 'x')
+		)
+		ORDER BY i.relname, k.ord;`, []interface{}{table})
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.ScanIndexes(table, rows)
+}
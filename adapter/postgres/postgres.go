@@ -15,10 +15,13 @@ package postgres
 import (
 	"context"
 	db "database/sql"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-rel/rel"
 	"github.com/go-rel/rel/adapter/sql"
+	"github.com/lib/pq"
 )
 
 // Adapter definition for postgres database.
@@ -27,7 +30,9 @@ type Adapter struct {
 }
 
 var (
-	_ rel.Adapter = (*Adapter)(nil)
+	_ rel.Adapter          = (*Adapter)(nil)
+	_ rel.ReturningAdapter = (*Adapter)(nil)
+	_ rel.InspectorAdapter = (*Adapter)(nil)
 
 	// Config for postgres adapter.
 	Config = sql.Config{
@@ -37,6 +42,10 @@ var (
 		InsertDefaultValues: true,
 		ErrorFunc:           errorFunc,
 		MapColumnFunc:       mapColumnFunc,
+		JSONContainsFunc:    jsonContainsFunc,
+		JSONExtractFunc:     jsonExtractFunc,
+		JSONPathFunc:        jsonPathFunc,
+		ArgumentFunc:        argumentFunc,
 	}
 )
 
@@ -57,39 +66,127 @@ func Open(dsn string) (*Adapter, error) {
 }
 
 // Insert inserts a record to database and returns its id.
-func (adapter *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate) (interface{}, error) {
+func (adapter *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (interface{}, error) {
 	var (
-		id              int64
-		statement, args = sql.NewBuilder(adapter.Config).Returning(primaryField).Insert(query.Table, mutates)
-		rows, err       = adapter.query(ctx, statement, args)
+		id              interface{}
+		builder         = sql.NewBuilder(adapter.Config)
+		statement, args = builder.Returning(primaryField).OnConflict(onConflict).Insert(query.Table, mutates)
 	)
 
-	if err == nil && rows.Next() {
-		defer rows.Close()
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := adapter.query(ctx, statement, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if rows.Next() {
 		rows.Scan(&id)
+	} else if !onConflict.IsZero() && primaryField != "" && len(onConflict.Keys) > 0 {
+		// ignored insertion doesn't return any row, retrieve it using conflict keys.
+		rows.Close()
+		return adapter.SelectPrimary(ctx, query.Table, primaryField, onConflict.Keys, mutates)
 	}
 
-	return id, err
+	return primaryValue(id), nil
 }
 
 // InsertAll inserts multiple records to database and returns its ids.
-func (adapter *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate) ([]interface{}, error) {
+// Ids is not returned when some of the insertion is ignored because of conflict.
+func (adapter *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]interface{}, error) {
 	var (
 		ids             []interface{}
-		statement, args = sql.NewBuilder(adapter.Config).Returning(primaryField).InsertAll(query.Table, fields, bulkMutates)
-		rows, err       = adapter.query(ctx, statement, args)
+		builder         = sql.NewBuilder(adapter.Config)
+		statement, args = builder.Returning(primaryField).OnConflict(onConflict).InsertAll(query.Table, fields, bulkMutates)
 	)
 
-	if err == nil {
-		defer rows.Close()
-		for rows.Next() {
-			var id int64
-			rows.Scan(&id)
-			ids = append(ids, id)
-		}
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := adapter.query(ctx, statement, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var id interface{}
+		rows.Scan(&id)
+		ids = append(ids, primaryValue(id))
+	}
+
+	if len(ids) != len(bulkMutates) {
+		ids = make([]interface{}, len(bulkMutates))
+	}
+
+	return ids, nil
+}
+
+// InsertReturning inserts a record to database and returns the inserted row.
+func (adapter *Adapter) InsertReturning(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (rel.Cursor, error) {
+	var (
+		builder         = sql.NewBuilder(adapter.Config)
+		statement, args = builder.Returning(returningFields(query)...).OnConflict(onConflict).Insert(query.Table, mutates)
+	)
+
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := adapter.query(ctx, statement, args)
+	return &sql.Cursor{Rows: rows}, err
+}
+
+// InsertAllReturning inserts multiple records to database and returns the inserted rows.
+func (adapter *Adapter) InsertAllReturning(ctx context.Context, query rel.Query, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) (rel.Cursor, error) {
+	var (
+		builder         = sql.NewBuilder(adapter.Config)
+		statement, args = builder.Returning(returningFields(query)...).OnConflict(onConflict).InsertAll(query.Table, fields, bulkMutates)
+	)
+
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := adapter.query(ctx, statement, args)
+	return &sql.Cursor{Rows: rows}, err
+}
+
+// UpdateReturning updates records in database and returns the updated rows.
+func (adapter *Adapter) UpdateReturning(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate) (rel.Cursor, error) {
+	var (
+		builder         = sql.NewBuilder(adapter.Config)
+		statement, args = builder.Returning(returningFields(query)...).With(query.WithQuery...).Update(query.Table, mutates, query.WhereQuery)
+	)
+
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := adapter.query(ctx, statement, args)
+	return &sql.Cursor{Rows: rows}, err
+}
+
+// primaryValue converts primary value returned as bytes, such as uuid, into string.
+func primaryValue(id interface{}) interface{} {
+	if b, ok := id.([]byte); ok {
+		return string(b)
+	}
+
+	return id
+}
+
+func returningFields(query rel.Query) []string {
+	if len(query.SelectQuery.Fields) == 0 {
+		return []string{"*"}
 	}
 
-	return ids, err
+	return query.SelectQuery.Fields
 }
 
 func (adapter *Adapter) query(ctx context.Context, statement string, args []interface{}) (*db.Rows, error) {
@@ -98,6 +195,8 @@ func (adapter *Adapter) query(ctx context.Context, statement string, args []inte
 		rows *db.Rows
 	)
 
+	args = adapter.Arguments(args)
+
 	finish := adapter.Instrumenter.Observe(ctx, "adapter-query", statement)
 	if adapter.Tx != nil {
 		rows, err = adapter.Tx.QueryContext(ctx, statement, args...)
@@ -167,12 +266,34 @@ func mapColumnFunc(column *rel.Column) (string, int, int) {
 	switch column.Type {
 	case rel.ID:
 		typ = "SERIAL NOT NULL PRIMARY KEY"
+	case rel.BigID:
+		typ = "BIGSERIAL NOT NULL PRIMARY KEY"
+	case rel.UUID:
+		typ = "UUID"
+	case rel.Binary:
+		typ = "BYTEA"
+	case rel.Inet:
+		typ = "INET"
+	case rel.Enum:
+		typ = "VARCHAR"
+		m = 255
+		column.Options = strings.TrimSpace(sql.EnumCheck("\""+column.Name+"\"", column.Values) + " " + column.Options)
+	case rel.Array:
+		element := *column
+		element.Type = column.Element
+		element.Default = nil
+
+		typ, m, n = mapColumnFunc(&element)
+		typ = formatType(typ, m, n) + "[]"
+		m, n = 0, 0
 	case rel.DateTime:
 		typ = "TIMESTAMPTZ"
 		if t, ok := column.Default.(time.Time); ok {
 			column.Default = t.Format("2006-01-02 15:04:05")
 		}
-	case rel.Int, rel.BigInt, rel.Text:
+	case rel.JSON:
+		typ = "JSONB"
+	case rel.SmallInt, rel.Int, rel.BigInt, rel.Text:
 		column.Limit = 0
 		typ, m, n = sql.MapColumn(column)
 	default:
@@ -181,3 +302,49 @@ func mapColumnFunc(column *rel.Column) (string, int, int) {
 
 	return typ, m, n
 }
+
+func jsonContainsFunc(field string, placeholder string) string {
+	return field + "@>" + placeholder + "::jsonb"
+}
+
+// jsonExtractFunc extracts json path as text, it's casted according to compared value.
+func jsonExtractFunc(field string, placeholder string, value interface{}) string {
+	expr := "(" + field + "#>>" + placeholder + "::text[])"
+
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		expr += "::numeric"
+	case bool:
+		expr += "::boolean"
+	}
+
+	return expr
+}
+
+// jsonPathFunc returns path segments as text array.
+func jsonPathFunc(path []string) interface{} {
+	return pq.Array(path)
+}
+
+// formatType writes size of type inline, it's used when the size is not the last part of the type.
+func formatType(typ string, m int, n int) string {
+	if m == 0 {
+		return typ
+	}
+
+	if n == 0 {
+		return typ + "(" + strconv.Itoa(m) + ")"
+	}
+
+	return typ + "(" + strconv.Itoa(m) + "," + strconv.Itoa(n) + ")"
+}
+
+// argumentFunc converts slice into postgres array.
+func argumentFunc(arg interface{}) interface{} {
+	switch arg.(type) {
+	case []string, []int64, []float64, []bool:
+		return pq.Array(arg)
+	}
+
+	return arg
+}
@@ -43,10 +43,21 @@ func TestAdapter_specs(t *testing.T) {
 	// Migration Specs
 	specs.Migrate(t, repo)
 
+	// Inspect Specs
+	specs.Inspect(t, repo)
+
 	// Query Specs
 	specs.Query(t, repo)
 	specs.QueryJoin(t, repo)
+	specs.QueryJoinAssoc(t, repo)
+	specs.QueryJSON(t, repo)
+	specs.QueryArray(t, repo)
+	specs.QuerySubquery(t, repo)
+	specs.QueryWith(t, repo)
+	specs.QueryCombination(t, repo)
+	specs.QuerySelectExpr(t, repo)
 	specs.QueryNotFound(t, repo)
+	specs.QueryPage(t, repo)
 
 	// Preload specs
 	specs.PreloadHasMany(t, repo)
@@ -58,9 +69,12 @@ func TestAdapter_specs(t *testing.T) {
 	specs.PreloadBelongsTo(t, repo)
 	specs.PreloadBelongsToWithQuery(t, repo)
 	specs.PreloadBelongsToSlice(t, repo)
+	specs.PreloadNested(t, repo)
+	specs.PreloadLimit(t, repo)
 
 	// Aggregate Specs
 	specs.Aggregate(t, repo)
+	specs.AggregateAll(t, repo)
 
 	// Insert Specs
 	specs.Insert(t, repo)
@@ -69,6 +83,12 @@ func TestAdapter_specs(t *testing.T) {
 	specs.InsertBelongsTo(t, repo)
 	specs.Inserts(t, repo)
 	specs.InsertAll(t, repo)
+	specs.InsertOnConflictIgnore(t, repo)
+	specs.InsertOnConflictReplace(t, repo)
+	specs.InsertOnConflictUpdate(t, repo)
+	specs.InsertAllOnConflictIgnore(t, repo)
+	specs.InsertReload(t, repo)
+	specs.InsertAllReload(t, repo)
 	specs.InsertAllPartialCustomPrimary(t, repo)
 
 	// Update Specs
@@ -0,0 +1,282 @@
+// Package replica wraps a primary and several replica adapters as a single adapter for rel.
+// Read queries are routed to replicas, while writes, migrations and transactions are always routed to primary.
+// Read queries using context returned by rel.WithPrimary are routed to primary as well, repository uses it to read
+// records it has just written.
+//
+// Usage:
+//
+//	// open primary and replica connections.
+//	primary, err := postgres.Open("postgres://postgres@primary/rel?sslmode=disable")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer primary.Close()
+//
+//	replica1, err := postgres.Open("postgres://postgres@replica1/rel?sslmode=disable")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer replica1.Close()
+//
+//	// initialize rel's repo.
+//	repo := rel.New(replica.New(replica.Config{
+//		Primary:  primary,
+//		Replicas: []rel.Adapter{replica1},
+//	}))
+package replica
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-rel/rel"
+)
+
+// Strategy used to choose a replica for read query.
+type Strategy int
+
+const (
+	// RoundRobin strategy distributes read queries to each replica in turn.
+	RoundRobin Strategy = iota
+	// LeastLatency strategy routes read queries to the replica with lowest average latency.
+	LeastLatency
+)
+
+// Config for replica adapter.
+type Config struct {
+	Primary  rel.Adapter
+	Replicas []rel.Adapter
+	Strategy Strategy
+}
+
+// Adapter definition for replica adapter.
+type Adapter struct {
+	Config
+	counter uint64
+	latency []int64
+}
+
+var (
+	_ rel.Adapter          = (*Adapter)(nil)
+	_ rel.ReturningAdapter = (*Adapter)(nil)
+	_ rel.InspectorAdapter = (*Adapter)(nil)
+)
+
+// New replica adapter using given config.
+// Returning and inspection are routed to primary, rel.ErrNotSupported is returned when primary doesn't support it.
+func New(config Config) *Adapter {
+	return &Adapter{
+		Config:  config,
+		latency: make([]int64, len(config.Replicas)),
+	}
+}
+
+// Close primary and replica connections.
+func (a *Adapter) Close() error {
+	var (
+		result error
+	)
+
+	for _, adapter := range append([]rel.Adapter{a.Primary}, a.Replicas...) {
+		if closer, ok := adapter.(interface{ Close() error }); ok {
+			if err := closer.Close(); err != nil && result == nil {
+				result = err
+			}
+		}
+	}
+
+	return result
+}
+
+// Instrumentation set instrumenter for primary and all replicas.
+func (a *Adapter) Instrumentation(instrumenter rel.Instrumenter) {
+	a.Primary.Instrumentation(instrumenter)
+
+	for _, replica := range a.Replicas {
+		replica.Instrumentation(instrumenter)
+	}
+}
+
+// Ping primary and all replicas.
+func (a *Adapter) Ping(ctx context.Context) error {
+	if err := a.Primary.Ping(ctx); err != nil {
+		return err
+	}
+
+	for _, replica := range a.Replicas {
+		if err := replica.Ping(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Aggregate using one of the replica.
+func (a *Adapter) Aggregate(ctx context.Context, query rel.Query, mode string, field string) (int, error) {
+	var (
+		index, adapter = a.reader(ctx, query)
+		start          = time.Now()
+		result, err    = adapter.Aggregate(ctx, query, mode, field)
+	)
+
+	a.observe(index, start)
+
+	return result, err
+}
+
+// Query using one of the replica.
+func (a *Adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
+	var (
+		index, adapter = a.reader(ctx, query)
+		start          = time.Now()
+		cur, err       = adapter.Query(ctx, query)
+	)
+
+	a.observe(index, start)
+
+	return cur, err
+}
+
+// Insert using primary.
+func (a *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (interface{}, error) {
+	return a.Primary.Insert(ctx, query, primaryField, mutates, onConflict)
+}
+
+// InsertAll using primary.
+func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]interface{}, error) {
+	return a.Primary.InsertAll(ctx, query, primaryField, fields, bulkMutates, onConflict)
+}
+
+// Update using primary.
+func (a *Adapter) Update(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate) (int, error) {
+	return a.Primary.Update(ctx, query, mutates)
+}
+
+// Delete using primary.
+func (a *Adapter) Delete(ctx context.Context, query rel.Query) (int, error) {
+	return a.Primary.Delete(ctx, query)
+}
+
+// Begin transaction using primary.
+// The returned adapter is primary's transaction, so every query inside transaction is routed to primary.
+func (a *Adapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	return a.Primary.Begin(ctx)
+}
+
+// Commit using primary.
+func (a *Adapter) Commit(ctx context.Context) error {
+	return a.Primary.Commit(ctx)
+}
+
+// Rollback using primary.
+func (a *Adapter) Rollback(ctx context.Context) error {
+	return a.Primary.Rollback(ctx)
+}
+
+// Apply migration using primary.
+func (a *Adapter) Apply(ctx context.Context, migration rel.Migration) error {
+	return a.Primary.Apply(ctx, migration)
+}
+
+// reader returns adapter for read query, index is -1 when primary is used.
+// locking query is always routed to primary, since replica is read only.
+func (a *Adapter) reader(ctx context.Context, query rel.Query) (int, rel.Adapter) {
+	if len(a.Replicas) == 0 || query.LockQuery != "" || rel.UsePrimary(ctx) {
+		return -1, a.Primary
+	}
+
+	var (
+		index int
+	)
+
+	switch a.Strategy {
+	case LeastLatency:
+		var (
+			min = atomic.LoadInt64(&a.latency[0])
+		)
+
+		for i := 1; i < len(a.latency); i++ {
+			if latency := atomic.LoadInt64(&a.latency[i]); latency < min {
+				index, min = i, latency
+			}
+		}
+	default:
+		index = int((atomic.AddUint64(&a.counter, 1) - 1) % uint64(len(a.Replicas)))
+	}
+
+	return index, a.Replicas[index]
+}
+
+// observe records moving average of replica latency, it's only used by least latency strategy.
+func (a *Adapter) observe(index int, start time.Time) {
+	if index < 0 || a.Strategy != LeastLatency {
+		return
+	}
+
+	var (
+		latency = int64(time.Since(start))
+		average = atomic.LoadInt64(&a.latency[index])
+	)
+
+	if average != 0 {
+		latency = (average*4 + latency) / 5
+	}
+
+	atomic.StoreInt64(&a.latency[index], latency)
+}
+
+// InsertReturning using primary.
+func (a *Adapter) InsertReturning(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (rel.Cursor, error) {
+	if adapter, ok := a.Primary.(rel.ReturningAdapter); ok {
+		return adapter.InsertReturning(ctx, query, mutates, onConflict)
+	}
+
+	return nil, rel.ErrNotSupported
+}
+
+// InsertAllReturning using primary.
+func (a *Adapter) InsertAllReturning(ctx context.Context, query rel.Query, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) (rel.Cursor, error) {
+	if adapter, ok := a.Primary.(rel.ReturningAdapter); ok {
+		return adapter.InsertAllReturning(ctx, query, fields, bulkMutates, onConflict)
+	}
+
+	return nil, rel.ErrNotSupported
+}
+
+// UpdateReturning using primary.
+func (a *Adapter) UpdateReturning(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate) (rel.Cursor, error) {
+	if adapter, ok := a.Primary.(rel.ReturningAdapter); ok {
+		return adapter.UpdateReturning(ctx, query, mutates)
+	}
+
+	return nil, rel.ErrNotSupported
+}
+
+// InspectTables using primary.
+func (a *Adapter) InspectTables(ctx context.Context) ([]string, error) {
+	if adapter, ok := a.Primary.(rel.InspectorAdapter); ok {
+		return adapter.InspectTables(ctx)
+	}
+
+	return nil, rel.ErrNotSupported
+}
+
+// InspectTable using primary.
+func (a *Adapter) InspectTable(ctx context.Context, name string) (rel.Table, error) {
+	if adapter, ok := a.Primary.(rel.InspectorAdapter); ok {
+		return adapter.InspectTable(ctx, name)
+	}
+
+	return rel.Table{}, rel.ErrNotSupported
+}
+
+// InspectIndexes using primary.
+func (a *Adapter) InspectIndexes(ctx context.Context, table string) ([]rel.Index, error) {
+	if adapter, ok := a.Primary.(rel.InspectorAdapter); ok {
+		return adapter.InspectIndexes(ctx, table)
+	}
+
+	return nil, rel.ErrNotSupported
+}
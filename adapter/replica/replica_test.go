@@ -0,0 +1,482 @@
+package replica
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/adapter/specs"
+	"github.com/go-rel/rel/adapter/sqlite3"
+	"github.com/go-rel/rel/validate"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var ctx = context.TODO()
+
+type User struct {
+	ID    int
+	Email string
+}
+
+type testAdapter struct {
+	mock.Mock
+}
+
+func (ta *testAdapter) Close() error {
+	return ta.Called().Error(0)
+}
+
+func (ta *testAdapter) Instrumentation(instrumenter rel.Instrumenter) {
+	ta.Called()
+}
+
+func (ta *testAdapter) Ping(ctx context.Context) error {
+	return ta.Called().Error(0)
+}
+
+func (ta *testAdapter) Aggregate(ctx context.Context, query rel.Query, mode string, field string) (int, error) {
+	args := ta.Called(query, mode, field)
+	return args.Int(0), args.Error(1)
+}
+
+func (ta *testAdapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
+	args := ta.Called(query)
+	return nil, args.Error(1)
+}
+
+func (ta *testAdapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (interface{}, error) {
+	args := ta.Called(query)
+	return args.Get(0), args.Error(1)
+}
+
+func (ta *testAdapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]interface{}, error) {
+	args := ta.Called(query)
+	return nil, args.Error(1)
+}
+
+func (ta *testAdapter) Update(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate) (int, error) {
+	args := ta.Called(query)
+	return args.Int(0), args.Error(1)
+}
+
+func (ta *testAdapter) Delete(ctx context.Context, query rel.Query) (int, error) {
+	args := ta.Called(query)
+	return args.Int(0), args.Error(1)
+}
+
+func (ta *testAdapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	return ta, ta.Called().Error(0)
+}
+
+func (ta *testAdapter) Commit(ctx context.Context) error {
+	return ta.Called().Error(0)
+}
+
+func (ta *testAdapter) Rollback(ctx context.Context) error {
+	return ta.Called().Error(0)
+}
+
+func (ta *testAdapter) Apply(ctx context.Context, migration rel.Migration) error {
+	return ta.Called(migration).Error(0)
+}
+
+type testReturningAdapter struct {
+	testAdapter
+}
+
+func (tra *testReturningAdapter) InsertReturning(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (rel.Cursor, error) {
+	return nil, tra.Called(query).Error(1)
+}
+
+func (tra *testReturningAdapter) InsertAllReturning(ctx context.Context, query rel.Query, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) (rel.Cursor, error) {
+	return nil, tra.Called(query).Error(1)
+}
+
+func (tra *testReturningAdapter) UpdateReturning(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate) (rel.Cursor, error) {
+	return nil, tra.Called(query).Error(1)
+}
+
+type testInspectorAdapter struct {
+	testAdapter
+}
+
+func (tia *testInspectorAdapter) InspectTables(ctx context.Context) ([]string, error) {
+	return nil, tia.Called().Error(1)
+}
+
+func (tia *testInspectorAdapter) InspectTable(ctx context.Context, name string) (rel.Table, error) {
+	return rel.Table{}, tia.Called(name).Error(1)
+}
+
+func (tia *testInspectorAdapter) InspectIndexes(ctx context.Context, table string) ([]rel.Index, error) {
+	return nil, tia.Called(table).Error(1)
+}
+
+func TestAdapter_roundRobin(t *testing.T) {
+	var (
+		primary  = &testAdapter{}
+		replica1 = &testAdapter{}
+		replica2 = &testAdapter{}
+		adapter  = New(Config{Primary: primary, Replicas: []rel.Adapter{replica1, replica2}})
+		query    = rel.From("users")
+	)
+
+	replica1.On("Query", query).Return(nil, nil).Twice()
+	replica2.On("Query", query).Return(nil, nil).Once()
+	replica2.On("Aggregate", query, "count", "*").Return(1, nil).Once()
+
+	for i := 0; i < 3; i++ {
+		_, err := adapter.Query(ctx, query)
+		assert.Nil(t, err)
+	}
+
+	count, err := adapter.Aggregate(ctx, query, "count", "*")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	primary.AssertExpectations(t)
+	replica1.AssertExpectations(t)
+	replica2.AssertExpectations(t)
+}
+
+func TestAdapter_leastLatency(t *testing.T) {
+	var (
+		primary  = &testAdapter{}
+		replica1 = &testAdapter{}
+		replica2 = &testAdapter{}
+		adapter  = New(Config{Primary: primary, Replicas: []rel.Adapter{replica1, replica2}, Strategy: LeastLatency})
+		query    = rel.From("users")
+	)
+
+	adapter.latency[0] = 100
+	adapter.latency[1] = 10
+
+	replica2.On("Query", query).Return(nil, nil).Once()
+
+	_, err := adapter.Query(ctx, query)
+	assert.Nil(t, err)
+	assert.NotEqual(t, int64(10), adapter.latency[1])
+	assert.Equal(t, int64(100), adapter.latency[0])
+
+	primary.AssertExpectations(t)
+	replica1.AssertExpectations(t)
+	replica2.AssertExpectations(t)
+}
+
+func TestAdapter_forcePrimary(t *testing.T) {
+	var (
+		primary = &testAdapter{}
+		replica = &testAdapter{}
+		adapter = New(Config{Primary: primary, Replicas: []rel.Adapter{replica}})
+		query   = rel.From("users")
+	)
+
+	primary.On("Query", query.Lock("FOR UPDATE")).Return(nil, nil).Once()
+	primary.On("Query", query).Return(nil, nil).Once()
+	primary.On("Aggregate", query, "count", "*").Return(1, nil).Once()
+
+	_, err := adapter.Query(ctx, query.Lock("FOR UPDATE"))
+	assert.Nil(t, err)
+
+	_, err = adapter.Query(rel.WithPrimary(ctx), query)
+	assert.Nil(t, err)
+
+	_, err = adapter.Aggregate(rel.WithPrimary(ctx), query, "count", "*")
+	assert.Nil(t, err)
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
+
+func TestAdapter_withoutReplica(t *testing.T) {
+	var (
+		primary = &testAdapter{}
+		adapter = New(Config{Primary: primary})
+		query   = rel.From("users")
+	)
+
+	primary.On("Query", query).Return(nil, nil).Once()
+
+	_, err := adapter.Query(ctx, query)
+	assert.Nil(t, err)
+
+	primary.AssertExpectations(t)
+}
+
+func TestAdapter_primary(t *testing.T) {
+	var (
+		primary   = &testAdapter{}
+		replica   = &testAdapter{}
+		adapter   = New(Config{Primary: primary, Replicas: []rel.Adapter{replica}})
+		query     = rel.From("users")
+		migration = rel.Raw("SELECT 1")
+	)
+
+	primary.On("Insert", query).Return(1, nil).Once()
+	primary.On("InsertAll", query).Return(nil, nil).Once()
+	primary.On("Update", query).Return(1, nil).Once()
+	primary.On("Delete", query).Return(1, nil).Once()
+	primary.On("Begin").Return(nil).Once()
+	primary.On("Commit").Return(nil).Once()
+	primary.On("Rollback").Return(nil).Once()
+	primary.On("Apply", migration).Return(nil).Once()
+
+	_, err := adapter.Insert(ctx, query, "id", nil, rel.OnConflict{})
+	assert.Nil(t, err)
+
+	_, err = adapter.InsertAll(ctx, query, "id", nil, nil, rel.OnConflict{})
+	assert.Nil(t, err)
+
+	_, err = adapter.Update(ctx, query, nil)
+	assert.Nil(t, err)
+
+	_, err = adapter.Delete(ctx, query)
+	assert.Nil(t, err)
+
+	tx, err := adapter.Begin(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, primary, tx)
+
+	assert.Nil(t, adapter.Commit(ctx))
+	assert.Nil(t, adapter.Rollback(ctx))
+	assert.Nil(t, adapter.Apply(ctx, migration))
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
+
+func TestAdapter_returning(t *testing.T) {
+	var (
+		primary = &testReturningAdapter{}
+		replica = &testAdapter{}
+		adapter = New(Config{Primary: primary, Replicas: []rel.Adapter{replica}})
+		query   = rel.From("users")
+	)
+
+	primary.On("InsertReturning", query).Return(nil, nil).Once()
+	primary.On("InsertAllReturning", query).Return(nil, nil).Once()
+	primary.On("UpdateReturning", query).Return(nil, nil).Once()
+
+	_, err := adapter.InsertReturning(ctx, query, nil, rel.OnConflict{})
+	assert.Nil(t, err)
+
+	_, err = adapter.InsertAllReturning(ctx, query, nil, nil, rel.OnConflict{})
+	assert.Nil(t, err)
+
+	_, err = adapter.UpdateReturning(ctx, query, nil)
+	assert.Nil(t, err)
+
+	_, err = adapter.InspectTables(ctx)
+	assert.Equal(t, rel.ErrNotSupported, err)
+
+	_, err = adapter.InspectTable(ctx, "users")
+	assert.Equal(t, rel.ErrNotSupported, err)
+
+	_, err = adapter.InspectIndexes(ctx, "users")
+	assert.Equal(t, rel.ErrNotSupported, err)
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
+
+func TestAdapter_inspector(t *testing.T) {
+	var (
+		primary = &testInspectorAdapter{}
+		replica = &testAdapter{}
+		adapter = New(Config{Primary: primary, Replicas: []rel.Adapter{replica}})
+		query   = rel.From("users")
+	)
+
+	primary.On("InspectTables").Return(nil, nil).Once()
+	primary.On("InspectTable", "users").Return(nil, nil).Once()
+	primary.On("InspectIndexes", "users").Return(nil, nil).Once()
+
+	_, err := adapter.InspectTables(ctx)
+	assert.Nil(t, err)
+
+	_, err = adapter.InspectTable(ctx, "users")
+	assert.Nil(t, err)
+
+	_, err = adapter.InspectIndexes(ctx, "users")
+	assert.Nil(t, err)
+
+	_, err = adapter.InsertReturning(ctx, query, nil, rel.OnConflict{})
+	assert.Equal(t, rel.ErrNotSupported, err)
+
+	_, err = adapter.InsertAllReturning(ctx, query, nil, nil, rel.OnConflict{})
+	assert.Equal(t, rel.ErrNotSupported, err)
+
+	_, err = adapter.UpdateReturning(ctx, query, nil)
+	assert.Equal(t, rel.ErrNotSupported, err)
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
+
+func TestAdapter_repositoryReadsOwnWrites(t *testing.T) {
+	var (
+		primary = &testAdapter{}
+		replica = &testAdapter{}
+		adapter = New(Config{Primary: primary, Replicas: []rel.Adapter{replica}})
+		user    = User{Email: "user@example.com"}
+		err     = errors.New("reload error")
+	)
+
+	primary.On("Instrumentation").Once()
+	replica.On("Instrumentation").Once()
+	repo := rel.New(adapter)
+
+	primary.On("Aggregate", mock.Anything, "count", "*").Return(0, nil).Once()
+	primary.On("Insert", mock.Anything).Return(1, nil).Once()
+	primary.On("Query", mock.Anything).Return(nil, err).Once()
+
+	message, verr := validate.Unique().Validate(ctx, repo, rel.NewDocument(&user), "email")
+	assert.Nil(t, verr)
+	assert.Equal(t, "", message)
+
+	assert.Equal(t, err, repo.Insert(ctx, &user, rel.Reload(true)))
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
+
+func TestAdapter_pingAndClose(t *testing.T) {
+	var (
+		primary = &testAdapter{}
+		replica = &testAdapter{}
+		adapter = New(Config{Primary: primary, Replicas: []rel.Adapter{replica}})
+		err     = errors.New("error")
+	)
+
+	primary.On("Instrumentation").Once()
+	replica.On("Instrumentation").Once()
+	primary.On("Ping").Return(nil).Twice()
+	replica.On("Ping").Return(nil).Once()
+	replica.On("Ping").Return(err).Once()
+	primary.On("Close").Return(nil).Once()
+	replica.On("Close").Return(err).Once()
+
+	adapter.Instrumentation(rel.DefaultLogger)
+	assert.Nil(t, adapter.Ping(ctx))
+	assert.Equal(t, err, adapter.Ping(ctx))
+	assert.Equal(t, err, adapter.Close())
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
+
+func dsn() string {
+	if os.Getenv("SQLITE3_DATABASE") != "" {
+		return os.Getenv("SQLITE3_DATABASE") + "?_foreign_keys=1&_loc=Local"
+	}
+
+	return "./rel_test.db?_foreign_keys=1&_loc=Local"
+}
+
+// jsonFlags skips json filter specs when sqlite is built without json1 extension, it's enabled using sqlite_json build tag.
+func jsonFlags(adapter *sqlite3.Adapter) []specs.Flag {
+	if _, _, err := adapter.Exec(ctx, "SELECT json('[]');", nil); err != nil {
+		return []specs.Flag{specs.SkipJSONFilter}
+	}
+
+	return nil
+}
+
+func TestAdapter_specs(t *testing.T) {
+	primary, err := sqlite3.Open(dsn())
+	assert.Nil(t, err)
+
+	replica1, err := sqlite3.Open(dsn())
+	assert.Nil(t, err)
+
+	replica2, err := sqlite3.Open(dsn())
+	assert.Nil(t, err)
+
+	adapter := New(Config{Primary: primary, Replicas: []rel.Adapter{replica1, replica2}})
+	defer adapter.Close()
+
+	repo := rel.New(adapter)
+
+	// Prepare tables
+	teardown := specs.Setup(t, repo)
+	defer teardown()
+
+	// Migration Specs
+	specs.Migrate(t, repo, specs.SkipDropColumn)
+
+	// Inspect Specs
+	specs.Inspect(t, repo)
+
+	// Query Specs
+	specs.Query(t, repo)
+	specs.QueryJoin(t, repo)
+	specs.QueryJoinAssoc(t, repo)
+	specs.QueryJSON(t, repo, jsonFlags(primary)...)
+	specs.QueryArray(t, repo)
+	specs.QuerySubquery(t, repo)
+	specs.QueryWith(t, repo)
+	specs.QueryCombination(t, repo)
+	specs.QuerySelectExpr(t, repo)
+	specs.QueryNotFound(t, repo)
+	specs.QueryPage(t, repo)
+
+	// Preload specs
+	specs.PreloadHasMany(t, repo)
+	specs.PreloadHasManyWithQuery(t, repo)
+	specs.PreloadHasManySlice(t, repo)
+	specs.PreloadHasOne(t, repo)
+	specs.PreloadHasOneWithQuery(t, repo)
+	specs.PreloadHasOneSlice(t, repo)
+	specs.PreloadBelongsTo(t, repo)
+	specs.PreloadBelongsToWithQuery(t, repo)
+	specs.PreloadBelongsToSlice(t, repo)
+	specs.PreloadNested(t, repo)
+	specs.PreloadLimit(t, repo)
+
+	// Aggregate Specs
+	specs.Aggregate(t, repo)
+	specs.AggregateAll(t, repo)
+
+	// Insert Specs
+	specs.Insert(t, repo)
+	specs.InsertHasMany(t, repo)
+	specs.InsertHasOne(t, repo)
+	specs.InsertBelongsTo(t, repo)
+	specs.Inserts(t, repo)
+	specs.InsertAll(t, repo)
+	specs.InsertOnConflictIgnore(t, repo)
+	specs.InsertOnConflictReplace(t, repo)
+	specs.InsertOnConflictUpdate(t, repo)
+	specs.InsertAllOnConflictIgnore(t, repo)
+	specs.InsertReload(t, repo)
+	specs.InsertAllReload(t, repo)
+
+	// Update Specs
+	specs.Update(t, repo)
+	specs.UpdateNotFound(t, repo)
+	specs.UpdateHasManyInsert(t, repo)
+	specs.UpdateHasManyUpdate(t, repo)
+	specs.UpdateHasManyReplace(t, repo)
+	specs.UpdateHasOneInsert(t, repo)
+	specs.UpdateHasOneUpdate(t, repo)
+	specs.UpdateBelongsToInsert(t, repo)
+	specs.UpdateBelongsToUpdate(t, repo)
+	specs.UpdateAtomic(t, repo)
+	specs.Updates(t, repo)
+	specs.UpdateAll(t, repo)
+
+	// Delete specs
+	specs.Delete(t, repo)
+	specs.DeleteBelongsTo(t, repo)
+	specs.DeleteHasOne(t, repo)
+	specs.DeleteHasMany(t, repo)
+	specs.DeleteAll(t, repo)
+
+	// Constraint specs
+	specs.UniqueConstraint(t, repo)
+	specs.CheckConstraint(t, repo)
+}
@@ -59,3 +59,61 @@ func Aggregate(t *testing.T, repo rel.Repository) {
 		})
 	}
 }
+
+// AggregateAll tests grouped aggregation specifications.
+func AggregateAll(t *testing.T, repo rel.Repository) {
+	repo.MustInsert(ctx, &User{Name: "aggregate", Gender: "male", Age: 10})
+	repo.MustInsert(ctx, &User{Name: "aggregate", Gender: "male", Age: 15})
+	repo.MustInsert(ctx, &User{Name: "aggregate", Gender: "female", Age: 20})
+
+	var (
+		query = rel.From("users").Select("gender").
+			SelectExpr(rel.Count("id").As("count"), rel.Sum("age").As("total"), rel.Avg("age").As("average")).
+			Where(where.Eq("name", "aggregate")).Group("gender").SortAsc("gender")
+	)
+
+	t.Run("Struct", func(t *testing.T) {
+		type report struct {
+			Gender  string
+			Count   int
+			Total   int
+			Average float64
+		}
+
+		var (
+			result []report
+		)
+
+		assert.Nil(t, repo.AggregateAll(ctx, &result, query))
+		assert.Equal(t, []report{
+			{Gender: "female", Count: 1, Total: 20, Average: 20},
+			{Gender: "male", Count: 2, Total: 25, Average: 12.5},
+		}, result)
+	})
+
+	t.Run("Having", func(t *testing.T) {
+		type report struct {
+			Gender string
+			Count  int
+		}
+
+		var (
+			result []report
+		)
+
+		assert.Nil(t, repo.AggregateAll(ctx, &result, query.Having(where.Fragment("COUNT(id) > 1"))))
+		assert.Equal(t, []report{{Gender: "male", Count: 2}}, result)
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		var (
+			result []map[string]interface{}
+		)
+
+		assert.Nil(t, repo.AggregateAll(ctx, &result, query))
+		assert.Len(t, result, 2)
+		assert.Equal(t, "female", result[0]["gender"])
+		assert.Equal(t, "male", result[1]["gender"])
+		assert.Contains(t, result[1], "average")
+	})
+}
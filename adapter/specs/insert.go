@@ -259,3 +259,99 @@ func assertRecords(t *testing.T, repo rel.Repository, records interface{}) {
 		assert.Equal(t, found, *v)
 	}
 }
+
+// InsertReload tests insert specifications with reload.
+func InsertReload(t *testing.T, repo rel.Repository) {
+	var (
+		result User
+		user   = User{Age: 99}
+	)
+
+	err := repo.Insert(ctx, &user, rel.Set("name", "insert reload"), rel.Reload(true))
+	assert.Nil(t, err)
+	assert.NotEqual(t, int64(0), user.ID)
+	assert.Equal(t, "insert reload", user.Name)
+	assert.Equal(t, 0, user.Age)
+
+	repo.MustFind(ctx, &result, where.Eq("id", user.ID))
+	assert.Equal(t, result, user)
+}
+
+// InsertAllReload tests insert multiple specifications with reload.
+func InsertAllReload(t *testing.T, repo rel.Repository) {
+	var (
+		users = []User{{Name: "insert all reload", Age: 10}, {Name: "insert all reload too"}}
+	)
+
+	assert.Nil(t, repo.InsertAll(ctx, &users, rel.Reload(true)))
+	assertRecords(t, repo, &users)
+}
+
+// InsertOnConflictIgnore tests insert specification with on conflict ignore.
+func InsertOnConflictIgnore(t *testing.T, repo rel.Repository) {
+	var (
+		result   Extra
+		existing = createExtra(repo, "on-conflict-ignore")
+		extra    = Extra{Slug: existing.Slug, Score: 50, UserID: existing.UserID}
+	)
+
+	err := repo.Insert(ctx, &extra, rel.OnConflictKeys("slug").DoNothing())
+	assert.Nil(t, err)
+	assert.Equal(t, existing.ID, extra.ID)
+
+	repo.MustFind(ctx, &result, where.Eq("id", existing.ID))
+	assert.Equal(t, existing, result)
+}
+
+// InsertOnConflictReplace tests insert specification with on conflict replace.
+func InsertOnConflictReplace(t *testing.T, repo rel.Repository) {
+	var (
+		result   Extra
+		existing = createExtra(repo, "on-conflict-replace")
+		extra    = Extra{Slug: existing.Slug, Score: 50, UserID: existing.UserID}
+	)
+
+	err := repo.Insert(ctx, &extra, rel.OnConflictReplace("slug"))
+	assert.Nil(t, err)
+	assert.Equal(t, existing.ID, extra.ID)
+
+	repo.MustFind(ctx, &result, where.Eq("id", existing.ID))
+	assert.Equal(t, extra, result)
+}
+
+// InsertOnConflictUpdate tests insert specification with on conflict update using mutates.
+func InsertOnConflictUpdate(t *testing.T, repo rel.Repository) {
+	var (
+		result   Extra
+		existing = createExtra(repo, "on-conflict-update")
+		extra    = Extra{Slug: existing.Slug, Score: 50, UserID: existing.UserID}
+	)
+
+	err := repo.Insert(ctx, &extra, rel.OnConflictKeys("slug").DoUpdate(rel.IncBy("score", 5)))
+	assert.Nil(t, err)
+	assert.Equal(t, existing.ID, extra.ID)
+
+	repo.MustFind(ctx, &result, where.Eq("id", existing.ID))
+	assert.Equal(t, existing.Score+5, result.Score)
+}
+
+// InsertAllOnConflictIgnore tests insert all specification with on conflict ignore.
+func InsertAllOnConflictIgnore(t *testing.T, repo rel.Repository) {
+	var (
+		result   []Extra
+		existing = createExtra(repo, "insert-all-on-conflict-ignore")
+		slug     = "insert-all-on-conflict-new"
+		extras   = []Extra{
+			{Slug: existing.Slug, Score: 50, UserID: existing.UserID},
+			{Slug: &slug, Score: 50, UserID: existing.UserID},
+		}
+	)
+
+	err := repo.InsertAll(ctx, &extras, rel.OnConflictIgnore())
+	assert.Nil(t, err)
+
+	repo.MustFindAll(ctx, &result, where.In("slug", *existing.Slug, slug), rel.NewSortAsc("id"))
+	assert.Len(t, result, 2)
+	assert.Equal(t, existing, result[0])
+	assert.Equal(t, 50, result[1].Score)
+}
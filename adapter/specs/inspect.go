@@ -0,0 +1,108 @@
+package specs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+// Inspect specs.
+func Inspect(t *testing.T, repo rel.Repository) {
+	adapter, ok := repo.Adapter(ctx).(rel.InspectorAdapter)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	t.Run("InspectTables", func(t *testing.T) {
+		tables, err := adapter.InspectTables(ctx)
+		assert.Nil(t, err)
+		assert.Subset(t, tables, []string{"users", "addresses", "extras", "composites", "profiles"})
+	})
+
+	t.Run("InspectTable", func(t *testing.T) {
+		table, err := adapter.InspectTable(ctx, "extras")
+		assert.Nil(t, err)
+		assert.Equal(t, "extras", table.Name)
+
+		var (
+			columns = map[string]rel.Column{}
+			keys    = map[rel.KeyType]rel.Key{}
+		)
+
+		for _, def := range table.Definitions {
+			switch v := def.(type) {
+			case rel.Column:
+				columns[v.Name] = v
+			case rel.Key:
+				keys[v.Type] = v
+			}
+		}
+
+		assert.Len(t, columns, 4)
+		assert.Equal(t, rel.ID, columns["id"].Type)
+		assert.Equal(t, rel.Int, columns["user_id"].Type)
+		assert.Equal(t, rel.String, columns["slug"].Type)
+		assert.Equal(t, 30, columns["slug"].Limit)
+		assert.Equal(t, rel.Int, columns["score"].Type)
+		assert.Equal(t, "0", columns["score"].Default)
+
+		assert.Equal(t, []string{"slug"}, keys[rel.UniqueKey].Columns)
+		assert.Equal(t, []string{"user_id"}, keys[rel.ForeignKey].Columns)
+		assert.Equal(t, "users", keys[rel.ForeignKey].Reference.Table)
+		assert.Equal(t, []string{"id"}, keys[rel.ForeignKey].Reference.Columns)
+	})
+
+	t.Run("InspectTable composite primary key", func(t *testing.T) {
+		table, err := adapter.InspectTable(ctx, "composites")
+		assert.Nil(t, err)
+
+		var primary rel.Key
+		for _, def := range table.Definitions {
+			if key, ok := def.(rel.Key); ok && key.Type == rel.PrimaryKey {
+				primary = key
+			}
+		}
+
+		assert.Equal(t, []string{"primary1", "primary2"}, primary.Columns)
+	})
+
+	t.Run("InspectTable not found", func(t *testing.T) {
+		_, err := adapter.InspectTable(ctx, "unknown")
+		assert.Equal(t, rel.ErrNotFound, err)
+	})
+
+	t.Run("InspectIndexes", func(t *testing.T) {
+		index := rel.Index{Table: "extras", Name: "extras_score_slug_idx", Columns: []string{"score", "slug"}}
+
+		assert.Nil(t, repo.Adapter(ctx).(rel.Adapter).Apply(ctx, index))
+		defer repo.Adapter(ctx).(rel.Adapter).Apply(ctx, rel.Index{Op: rel.SchemaDrop, Table: "extras", Name: index.Name})
+
+		indexes, err := adapter.InspectIndexes(ctx, "extras")
+		assert.Nil(t, err)
+		assert.Contains(t, indexes, index)
+	})
+	t.Run("Transaction", func(t *testing.T) {
+		err := repo.Transaction(ctx, func(ctx context.Context) error {
+			return repo.Transaction(ctx, func(ctx context.Context) error {
+				adapter, ok := repo.Adapter(ctx).(rel.InspectorAdapter)
+				if !assert.True(t, ok) {
+					return nil
+				}
+
+				tables, err := adapter.InspectTables(ctx)
+				assert.Nil(t, err)
+				assert.Contains(t, tables, "extras")
+
+				table, err := adapter.InspectTable(ctx, "extras")
+				assert.Nil(t, err)
+				assert.Equal(t, "extras", table.Name)
+
+				return nil
+			})
+		})
+
+		assert.Nil(t, err)
+	})
+}
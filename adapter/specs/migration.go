@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-rel/rel"
 	"github.com/go-rel/rel/migrator"
+	"github.com/stretchr/testify/assert"
 )
 
 var m migrator.Migrator
@@ -83,10 +84,24 @@ func Setup(t *testing.T, repo rel.Repository) func() {
 		},
 	)
 
+	m.Register(5,
+		func(schema *rel.Schema) {
+			schema.CreateTable("profiles", func(t *rel.Table) {
+				t.ID("id")
+				t.JSON("settings")
+				t.JSON("tags")
+				t.Array("labels", rel.String)
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("profiles")
+		},
+	)
+
 	m.Migrate(ctx)
 
 	return func() {
-		for i := 0; i < 4; i++ {
+		for i := 0; i < 5; i++ {
 			m.Rollback(ctx)
 		}
 	}
@@ -94,7 +109,7 @@ func Setup(t *testing.T, repo rel.Repository) func() {
 
 // Migrate specs.
 func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
-	m.Register(5,
+	m.Register(6,
 		func(schema *rel.Schema) {
 			schema.CreateTable("dummies", func(t *rel.Table) {
 				t.ID("id")
@@ -120,6 +135,13 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 				t.Time("time2", rel.Default(time.Now()))
 				t.Timestamp("timestamp1")
 				t.Timestamp("timestamp2", rel.Default(time.Now()))
+				t.JSON("json")
+				t.SmallInt("smallint")
+				t.UUID("uuid")
+				t.Binary("binary")
+				t.Enum("enum", []string{"draft", "published"}, rel.Default("draft"))
+				t.Array("array", rel.String)
+				t.Inet("inet")
 
 				t.Unique([]string{"int2"})
 				t.Unique([]string{"bigint1", "bigint2"})
@@ -131,7 +153,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(6,
+	m.Register(7,
 		func(schema *rel.Schema) {
 			schema.AlterTable("dummies", func(t *rel.AlterTable) {
 				t.Bool("new_column")
@@ -150,7 +172,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	defer m.Rollback(ctx)
 
 	if SkipRenameColumn.enabled(flags) {
-		m.Register(7,
+		m.Register(8,
 			func(schema *rel.Schema) {
 				schema.AlterTable("dummies", func(t *rel.AlterTable) {
 					t.RenameColumn("text", "teks")
@@ -169,7 +191,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 		defer m.Rollback(ctx)
 	}
 
-	m.Register(8,
+	m.Register(9,
 		func(schema *rel.Schema) {
 			schema.CreateIndex("dummies", "int1_idx", []string{"int1"})
 			schema.CreateIndex("dummies", "string1_string2_idx", []string{"string1", "string2"})
@@ -181,7 +203,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(9,
+	m.Register(10,
 		func(schema *rel.Schema) {
 			schema.RenameTable("dummies", "new_dummies")
 		},
@@ -191,7 +213,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(10,
+	m.Register(11,
 		func(schema *rel.Schema) {
 			schema.CreateTableIfNotExists("dummies2", func(t *rel.Table) {
 				t.ID("id")
@@ -203,7 +225,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(11,
+	m.Register(12,
 		func(schema *rel.Schema) {
 			schema.CreateTableIfNotExists("dummies2", func(t *rel.Table) {
 				t.ID("id")
@@ -217,5 +239,130 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
+	m.Change(13, func(schema *rel.Schema) {
+		schema.CreateTable("alters", func(t *rel.Table) {
+			t.ID("id")
+			t.Int("user_id", rel.Unsigned(true))
+			t.String("code", rel.Limit(20))
+			t.Int("score")
+		})
+	})
+	defer m.Rollback(ctx)
+
+	m.Register(14,
+		func(schema *rel.Schema) {
+			schema.AlterTable("alters", func(t *rel.AlterTable) {
+				t.ChangeColumn("code", rel.String, rel.Limit(50))
+				t.SetNotNull("score")
+				t.SetDefault("score", 0)
+				t.AddForeignKey("user_id", "users", "id", rel.Name("alters_user_id_fk"))
+				t.AddUnique([]string{"code"}, rel.Name("alters_code_unique"))
+
+				if !SkipCheckConstraint.skipped(flags) {
+					t.Check("alters_score_check", "score >= 0")
+				}
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.AlterTable("alters", func(t *rel.AlterTable) {
+				if !SkipCheckConstraint.skipped(flags) {
+					t.DropConstraint("alters_score_check")
+				}
+
+				t.DropConstraint("alters_code_unique")
+				t.DropForeignKey("alters_user_id_fk")
+				t.DropDefault("score")
+				t.SetNull("score")
+				t.ChangeColumn("code", rel.String, rel.Limit(20))
+			})
+		},
+	)
+	defer m.Rollback(ctx)
+
+	m.Register(15,
+		func(schema *rel.Schema) {
+			schema.CreateTable("alter_children", func(t *rel.Table) {
+				t.ID("id")
+				t.Int("alter_id", rel.Unsigned(true))
+
+				t.ForeignKey("alter_id", "alters", "id", rel.OnDelete("CASCADE"))
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("alter_children")
+		},
+	)
+	defer m.Rollback(ctx)
+
 	m.Migrate(ctx)
+
+	t.Run("Status", func(t *testing.T) {
+		statuses, err := m.Status(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, statuses, 15)
+
+		for _, status := range statuses {
+			assert.True(t, status.Applied)
+			assert.False(t, status.AppliedAt.IsZero())
+		}
+	})
+
+	t.Run("Redo", func(t *testing.T) {
+		assert.Nil(t, m.Redo(ctx))
+		assert.Nil(t, m.MigrateTo(ctx, migrator.Latest))
+	})
+
+	t.Run("AlterTable", func(t *testing.T) {
+		var (
+			alter = Alter{Code: "alter-code-longer-than-twenty-characters", Score: 10}
+		)
+
+		assert.Nil(t, repo.Insert(ctx, &alter))
+
+		err := repo.Insert(ctx, &Alter{Code: alter.Code})
+		assertConstraint(t, err, rel.UniqueConstraint, "code")
+
+		if !SkipCheckConstraint.skipped(flags) {
+			err = repo.Insert(ctx, &Alter{Code: "alter-check", Score: -1})
+			assertConstraint(t, err, rel.CheckConstraint, "score")
+		}
+
+		repo.MustDelete(ctx, &alter)
+	})
+	t.Run("AlterReferencedTable", func(t *testing.T) {
+		var (
+			alter = Alter{Code: "alter-referenced"}
+			child = AlterChild{}
+		)
+
+		repo.MustInsert(ctx, &alter)
+		child.AlterID = alter.ID
+		repo.MustInsert(ctx, &child)
+
+		// altered table is referenced using ON DELETE action, the referencing records must be kept when the table is rebuilt.
+		m.Register(16,
+			func(schema *rel.Schema) {
+				schema.AlterTable("alters", func(t *rel.AlterTable) {
+					t.AddUnique([]string{"code", "score"}, rel.Name("alters_code_score_unique"))
+				})
+			},
+			func(schema *rel.Schema) {
+				schema.AlterTable("alters", func(t *rel.AlterTable) {
+					t.DropConstraint("alters_code_score_unique")
+				})
+			},
+		)
+
+		assert.Nil(t, m.MigrateTo(ctx, migrator.Latest))
+		assert.Equal(t, 1, repo.MustCount(ctx, "alter_children"))
+
+		assert.Nil(t, m.Redo(ctx))
+		assert.Equal(t, 1, repo.MustCount(ctx, "alter_children"))
+
+		assert.Nil(t, m.RollbackSteps(ctx, 1))
+		assert.Equal(t, 1, repo.MustCount(ctx, "alter_children"))
+
+		repo.MustDelete(ctx, &alter)
+		assert.Equal(t, 0, repo.MustCount(ctx, "alter_children"))
+	})
 }
@@ -177,3 +177,59 @@ func PreloadBelongsToSlice(t *testing.T, repo rel.Repository) {
 		assert.Equal(t, user, result[i].User)
 	}
 }
+
+// PreloadNested tests specification for preloading nested association in a single call.
+func PreloadNested(t *testing.T, repo rel.Repository) {
+	var (
+		result []Address
+		user   = createPreloadUser(repo)
+	)
+
+	err := repo.FindAll(ctx, &result, where.Eq("user_id", user.ID))
+	assert.Nil(t, err)
+
+	err = repo.Preload(ctx, &result, "user.addresses", where.Ne("name", "home"))
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+
+	for i := range result {
+		assert.Equal(t, user.ID, result[i].User.ID)
+		assert.Len(t, result[i].User.Addresses, 2)
+	}
+
+	var (
+		users []User
+	)
+
+	err = repo.FindAll(ctx, &users, where.Eq("id", user.ID))
+	assert.Nil(t, err)
+
+	err = repo.Preload(ctx, &users, "addresses", where.Eq("name", "work"), rel.NewNestedPreload("user"))
+	assert.Nil(t, err)
+	assert.Len(t, users[0].Addresses, 1)
+	assert.Equal(t, user.Name, users[0].Addresses[0].User.Name)
+}
+
+// PreloadLimit tests specification for limiting preloaded records of each parent.
+func PreloadLimit(t *testing.T, repo rel.Repository) {
+	var (
+		result []User
+		users  = []User{
+			createPreloadUser(repo),
+			createPreloadUser(repo),
+		}
+	)
+
+	err := repo.FindAll(ctx, &result, where.In("id", users[0].ID, users[1].ID), rel.NewSortAsc("id"))
+	assert.Nil(t, err)
+
+	err = repo.Preload(ctx, &result, "addresses", rel.PreloadLimit(2), rel.NewSortDesc("name"))
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+
+	for i := range result {
+		assert.Len(t, result[i].Addresses, 2)
+		assert.Equal(t, "work", result[i].Addresses[0].Name)
+		assert.Equal(t, "primary", result[i].Addresses[1].Name)
+	}
+}
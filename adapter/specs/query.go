@@ -82,6 +82,344 @@ func QueryJoin(t *testing.T, repo rel.Repository) {
 	run(t, repo, tests)
 }
 
+// QueryJoinAssoc tests query specifications using association join.
+func QueryJoinAssoc(t *testing.T, repo rel.Repository) {
+	var (
+		user = User{Name: "join assoc", Gender: "male", Age: 30}
+	)
+
+	repo.MustInsert(ctx, &user)
+	repo.MustInsert(ctx, &User{Name: "join assoc", Gender: "female", Age: 40})
+	repo.MustInsert(ctx, &Address{Name: "join assoc address", UserID: &user.ID})
+
+	t.Run("BelongsTo", func(t *testing.T) {
+		var (
+			result []Address
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, rel.JoinAssoc("user").Where(where.Eq("users.name", "join assoc"))))
+		assert.Len(t, result, 1)
+		assert.Equal(t, "join assoc address", result[0].Name)
+	})
+
+	t.Run("HasMany", func(t *testing.T) {
+		var (
+			result []User
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, rel.JoinAssoc("addresses").Where(where.Eq("addresses.name", "join assoc address"))))
+		assert.Len(t, result, 1)
+		assert.Equal(t, user.ID, result[0].ID)
+	})
+
+	t.Run("LeftJoin", func(t *testing.T) {
+		var (
+			result []User
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, rel.JoinAssocWith("LEFT JOIN", "primary_address").
+			Where(where.Eq("users.name", "join assoc"), where.Nil("addresses.id"))))
+		assert.Len(t, result, 1)
+		assert.Equal(t, 40, result[0].Age)
+	})
+
+	t.Run("Populate", func(t *testing.T) {
+		var (
+			result Address
+		)
+
+		assert.Nil(t, repo.Find(ctx, &result, rel.JoinPopulate("user").Where(where.Eq("addresses.name", "join assoc address"))))
+		assert.Equal(t, user.ID, *result.UserID)
+		assert.Equal(t, user.ID, result.User.ID)
+		assert.Equal(t, "join assoc", result.User.Name)
+		assert.Equal(t, 30, result.User.Age)
+	})
+
+	t.Run("PopulateLeftJoin", func(t *testing.T) {
+		var (
+			result []User
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, rel.JoinPopulateWith("LEFT JOIN", "primary_address").
+			Where(where.Eq("users.name", "join assoc")).SortAsc("users.age")))
+		assert.Len(t, result, 2)
+		assert.NotNil(t, result[0].PrimaryAddress)
+		assert.Equal(t, "join assoc address", result[0].PrimaryAddress.Name)
+		assert.Nil(t, result[1].PrimaryAddress)
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		var (
+			result []Address
+		)
+
+		count, err := repo.FindAndCountAll(ctx, &result, rel.JoinAssoc("user").Where(where.Eq("users.name", "join assoc")))
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 1, count)
+	})
+}
+
+// QuerySubquery tests query specifications using subquery.
+func QuerySubquery(t *testing.T, repo rel.Repository) {
+	var (
+		user      = User{Name: "subquery", Gender: "male", Age: 25}
+		addresses = rel.Select("user_id").From("addresses").Where(where.Like("name", "subquery%"))
+	)
+
+	repo.MustInsert(ctx, &user)
+	repo.MustInsert(ctx, &User{Name: "subquery", Gender: "female", Age: 35})
+	repo.MustInsert(ctx, &Address{Name: "subquery address", UserID: &user.ID})
+
+	tests := []rel.Querier{
+		rel.Where(where.In("id", addresses)),
+		rel.Where(where.Nin("id", addresses), where.Eq("name", "subquery")),
+		rel.Where(where.Eq("id", addresses.Limit(1))),
+		rel.Where(where.Exists(rel.From("addresses").Where(where.Fragment("addresses.user_id = users.id")))),
+		rel.Where(where.NotExists(rel.From("addresses").Where(where.Fragment("addresses.user_id = users.id")))),
+		rel.From(rel.From("users").Where(where.Gt("age", 20))).As("t").Where(where.Eq("t.name", "subquery")),
+	}
+
+	run(t, repo, tests)
+
+	t.Run("In", func(t *testing.T) {
+		var (
+			result []User
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, where.In("id", addresses), where.Eq("name", "subquery")))
+		assert.Len(t, result, 1)
+		assert.Equal(t, user.ID, result[0].ID)
+	})
+
+	t.Run("From", func(t *testing.T) {
+		var (
+			result []User
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, rel.From(rel.From("users").Where(where.Gt("age", 30))).As("t").Where(where.Eq("t.name", "subquery"))))
+		assert.Len(t, result, 1)
+		assert.Equal(t, 35, result[0].Age)
+	})
+}
+
+// QueryWith tests query specifications using common table expression.
+func QueryWith(t *testing.T, repo rel.Repository) {
+	var (
+		user   = User{Name: "with", Gender: "male", Age: 70}
+		ages   = rel.SQL("SELECT 70 AS age UNION ALL SELECT age + 10 FROM ages WHERE age < 90")
+		adults = rel.From("users").Where(where.Gte("age", 70), where.Eq("name", "with"))
+	)
+
+	repo.MustInsert(ctx, &user)
+	repo.MustInsert(ctx, &User{Name: "with", Gender: "female", Age: 90})
+	repo.MustInsert(ctx, &User{Name: "with", Gender: "female", Age: 95})
+	repo.MustInsert(ctx, &Address{Name: "with address", UserID: &user.ID})
+
+	tests := []rel.Querier{
+		rel.With("adults", adults).From("adults"),
+		rel.With("adults", adults).From("adults").Where(where.Eq("gender", "male")).SortDesc("age").Limit(1),
+		rel.WithRecursive("ages", rel.Build("", ages)).Where(where.In("age", rel.Select("age").From("ages"))),
+	}
+
+	run(t, repo, tests)
+
+	t.Run("Recursive", func(t *testing.T) {
+		var (
+			result []User
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, rel.WithRecursive("ages", rel.Build("", ages)).Where(where.In("age", rel.Select("age").From("ages")), where.Eq("name", "with"))))
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("UpdateAll", func(t *testing.T) {
+		var (
+			result []Address
+			query  = rel.With("owners", rel.Select("id").From("users").Where(where.Eq("name", "with"))).
+				From("addresses").Where(where.In("user_id", rel.Select("id").From("owners")))
+		)
+
+		assert.Nil(t, repo.UpdateAll(ctx, query, rel.Set("name", "with updated")))
+		assert.Nil(t, repo.FindAll(ctx, &result, where.Eq("name", "with updated")))
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("DeleteAll", func(t *testing.T) {
+		var (
+			result []Address
+			query  = rel.With("owners", rel.Select("id").From("users").Where(where.Eq("name", "with"))).
+				From("addresses").Where(where.In("user_id", rel.Select("id").From("owners")))
+		)
+
+		assert.Nil(t, repo.DeleteAll(ctx, query))
+		assert.Nil(t, repo.FindAll(ctx, &result, where.Eq("name", "with updated")))
+		assert.Len(t, result, 0)
+	})
+}
+
+// QueryCombination tests query specifications using set operations.
+// INTERSECT and EXCEPT specs are skipped using SkipIntersectExcept flag.
+func QueryCombination(t *testing.T, repo rel.Repository, flags ...Flag) {
+	repo.MustInsert(ctx, &User{Name: "combination1", Gender: "male", Age: 15})
+	repo.MustInsert(ctx, &User{Name: "combination2", Gender: "female", Age: 25})
+	repo.MustInsert(ctx, &User{Name: "combination3", Gender: "female", Age: 35})
+
+	var (
+		males   = rel.Select("name", "age").From("users").Where(where.Eq("gender", "male"), where.Like("name", "combination%"))
+		females = rel.Select("name", "age").From("users").Where(where.Eq("gender", "female"), where.Like("name", "combination%"))
+		adults  = rel.Select("name", "age").From("users").Where(where.Gt("age", 20), where.Like("name", "combination%"))
+	)
+
+	tests := []struct {
+		name      string
+		query     rel.Query
+		names     []string
+		intersect bool
+	}{
+		{"Union", males.Union(females).SortAsc("name"), []string{"combination1", "combination2", "combination3"}, false},
+		{"UnionAll", females.UnionAll(adults).SortAsc("name"), []string{"combination2", "combination2", "combination3", "combination3"}, false},
+		{"UnionLimit", males.Union(females).SortDesc("name").Limit(2), []string{"combination3", "combination2"}, false},
+		{"UnionSubLimit", males.Union(females.SortDesc("age").Limit(1)).SortAsc("name"), []string{"combination1", "combination3"}, false},
+		{"Intersect", females.Intersect(adults).SortAsc("name"), []string{"combination2", "combination3"}, true},
+		{"Except", adults.Except(females.Where(where.Lt("age", 30))).SortAsc("name"), []string{"combination3"}, true},
+		{"UnionIntersect", males.Union(females).Intersect(adults).SortAsc("name"), []string{"combination2", "combination3"}, true},
+	}
+
+	for _, test := range tests {
+		if test.intersect && SkipIntersectExcept.skipped(flags) {
+			continue
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				result []User
+				names  []string
+			)
+
+			assert.Nil(t, repo.FindAll(ctx, &result, test.query))
+			for i := range result {
+				names = append(names, result[i].Name)
+			}
+
+			assert.Equal(t, test.names, names)
+		})
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := repo.Aggregate(ctx, males.Union(females), "count", "*")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, count)
+	})
+}
+
+// QuerySelectExpr tests query specifications using aggregate and window function expressions.
+func QuerySelectExpr(t *testing.T, repo rel.Repository) {
+	repo.MustInsert(ctx, &User{Name: "expr1", Gender: "male", Age: 10})
+	repo.MustInsert(ctx, &User{Name: "expr2", Gender: "male", Age: 20})
+	repo.MustInsert(ctx, &User{Name: "expr3", Gender: "female", Age: 30})
+	repo.MustInsert(ctx, &User{Name: "expr4", Gender: "female", Age: 30})
+	repo.MustInsert(ctx, &User{Name: "expr5", Gender: "female", Age: 50})
+
+	t.Run("Aggregate", func(t *testing.T) {
+		type genderReport struct {
+			Gender string
+			Count  int
+			Ages   int
+			Total  int
+			Oldest int
+		}
+
+		var (
+			result []genderReport
+			query  = rel.From("users").Select("gender").
+				SelectExpr(rel.Count("*").As("count"), rel.CountDistinct("age").As("ages"), rel.Sum("age").As("total"), rel.Max("age").As("oldest")).
+				Where(where.Like("name", "expr%")).Group("gender").SortAsc("gender")
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, query))
+		assert.Equal(t, []genderReport{
+			{Gender: "female", Count: 3, Ages: 2, Total: 110, Oldest: 50},
+			{Gender: "male", Count: 2, Ages: 2, Total: 30, Oldest: 20},
+		}, result)
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		type ageRank struct {
+			Name     string
+			Position int
+			Rank     int
+			Previous *int
+		}
+
+		var (
+			result []ageRank
+			query  = rel.From("users").Select("name").
+				SelectExpr(
+					rel.RowNumber().Over(rel.PartitionBy("gender").SortAsc("age", "name")).As("position"),
+					rel.Rank().Over(rel.Window{}.SortDesc("age")).As("rank"),
+					rel.Lag("age", 1).Over(rel.PartitionBy("gender").SortAsc("age", "name")).As("previous"),
+				).
+				Where(where.Like("name", "expr%")).SortAsc("name")
+			ten    = 10
+			thirty = 30
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, query))
+		assert.Equal(t, []ageRank{
+			{Name: "expr1", Position: 1, Rank: 5},
+			{Name: "expr2", Position: 2, Rank: 4, Previous: &ten},
+			{Name: "expr3", Position: 1, Rank: 2},
+			{Name: "expr4", Position: 2, Rank: 2, Previous: &thirty},
+			{Name: "expr5", Position: 3, Rank: 1, Previous: &thirty},
+		}, result)
+	})
+}
+
+// QueryPage tests keyset pagination specifications.
+func QueryPage(t *testing.T, repo rel.Repository) {
+	var (
+		x, y  = "x", "y"
+		users = []User{
+			{Name: "a", Gender: "page", Note: &x},
+			{Name: "b", Gender: "page"},
+			{Name: "c", Gender: "page", Note: &y},
+			{Name: "d", Gender: "page", Note: &x},
+			{Name: "e", Gender: "page"},
+		}
+		query  = rel.Where(where.Eq("gender", "page")).SortDesc("note").SortAsc("name")
+		names  []string
+		cursor string
+		pages  int
+	)
+
+	repo.MustInsertAll(ctx, &users)
+
+	for {
+		var (
+			result []User
+		)
+
+		next, err := repo.FindPage(ctx, &result, cursor, 2, query)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(result), 2)
+
+		for i := range result {
+			names = append(names, result[i].Name)
+		}
+
+		pages++
+		if next == "" {
+			break
+		}
+
+		cursor = next
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []string{"c", "a", "d", "b", "e"}, names)
+}
+
 // QueryNotFound tests query specifications when no result found.
 func QueryNotFound(t *testing.T, repo rel.Repository) {
 	t.Run("NotFound", func(t *testing.T) {
@@ -119,3 +457,108 @@ func run(t *testing.T, repo rel.Repository, queriers []rel.Querier) {
 		})
 	}
 }
+
+// QueryJSON tests json column and json filter specifications.
+func QueryJSON(t *testing.T, repo rel.Repository, flags ...Flag) {
+	var (
+		profile = Profile{Tags: []string{"go", "rel"}}
+	)
+
+	profile.Settings.Theme = "dark"
+	profile.Settings.Size = 12
+	profile.Settings.Address.City = "Jakarta"
+
+	repo.MustInsert(ctx, &profile)
+
+	other := Profile{Tags: []string{"rust"}}
+	other.Settings.Theme = "dark"
+	other.Settings.Size = 20
+	other.Settings.Address.City = "Bandung"
+	repo.MustInsert(ctx, &other)
+
+	t.Run("Find", func(t *testing.T) {
+		var (
+			result Profile
+		)
+
+		assert.Nil(t, repo.Find(ctx, &result, where.Eq("id", profile.ID)))
+		assert.Equal(t, profile, result)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		var (
+			result    Profile
+			changeset = rel.NewChangeset(&profile)
+		)
+
+		profile.Settings.Theme = "light"
+		profile.Tags = append(profile.Tags, "json")
+
+		assert.Nil(t, repo.Update(ctx, &profile, changeset))
+		assert.Nil(t, repo.Find(ctx, &result, where.Eq("id", profile.ID)))
+		assert.Equal(t, profile, result)
+	})
+
+	if SkipJSONFilter.skipped(flags) {
+		return
+	}
+
+	tests := []rel.Querier{
+		where.JSONExtract("settings", "address.city").Eq("Jakarta"),
+		where.JSONExtract("settings", "theme").Ne("dark"),
+		where.JSONExtract("settings", "size").Lt(15),
+		where.JSONExtract("settings", "size").Lte(12),
+		where.JSONExtract("settings", "size").Gt(10).And(where.JSONExtract("settings", "size").Lt(15)),
+		where.JSONExtract("settings", "size").Gte(20).Or(where.JSONExtract("settings", "address.city").Eq("Jakarta")).And(where.JSONExtract("settings", "size").Ne(20)),
+		where.JSONExtract("tags", "0").Eq("go"),
+		where.JSONContains("tags", "json"),
+	}
+
+	for _, query := range tests {
+		t.Run("Filter", func(t *testing.T) {
+			var (
+				result []Profile
+			)
+
+			assert.Nil(t, repo.FindAll(ctx, &result, query))
+			assert.Len(t, result, 1)
+			assert.Equal(t, profile.ID, result[0].ID)
+		})
+	}
+}
+
+// QueryArray tests array column specifications.
+func QueryArray(t *testing.T, repo rel.Repository) {
+	var (
+		profile = Profile{Labels: []string{"go", "rel orm", `"quoted"`}}
+		empty   = Profile{Labels: []string{}}
+	)
+
+	repo.MustInsert(ctx, &profile)
+	repo.MustInsert(ctx, &empty)
+
+	t.Run("Find", func(t *testing.T) {
+		var (
+			result Profile
+		)
+
+		assert.Nil(t, repo.Find(ctx, &result, where.Eq("id", profile.ID)))
+		assert.Equal(t, profile, result)
+
+		assert.Nil(t, repo.Find(ctx, &result, where.Eq("id", empty.ID)))
+		assert.Equal(t, empty, result)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		var (
+			result    Profile
+			changeset = rel.NewChangeset(&profile)
+		)
+
+		profile.Labels = append(profile.Labels, "array")
+
+		assert.Nil(t, repo.Update(ctx, &profile, changeset))
+		assert.Nil(t, repo.Find(ctx, &result, where.Eq("id", profile.ID)))
+		assert.Equal(t, profile, result)
+	})
+}
@@ -21,11 +21,21 @@ func (f Flag) enabled(flags []Flag) bool {
 	return len(flags) > 0 && f&flags[0] == 0
 }
 
+func (f Flag) skipped(flags []Flag) bool {
+	return len(flags) > 0 && f&flags[0] != 0
+}
+
 const (
 	// SkipDropColumn spec.
 	SkipDropColumn Flag = 1 << iota
 	// SkipRenameColumn spec.
 	SkipRenameColumn
+	// SkipJSONFilter spec.
+	SkipJSONFilter
+	// SkipCheckConstraint spec.
+	SkipCheckConstraint
+	// SkipIntersectExcept spec, INTERSECT and EXCEPT are only supported since MySQL 8.0.31.
+	SkipIntersectExcept
 )
 
 // User defines users schema.
@@ -59,6 +69,37 @@ type Extra struct {
 	UserID int64
 }
 
+// Alter defines alters schema, the schema is modified using alter table.
+type Alter struct {
+	ID     int64
+	UserID *int64
+	Code   string
+	Score  int
+}
+
+// AlterChild defines alter_children schema, it references alters using ON DELETE CASCADE.
+type AlterChild struct {
+	ID      int64
+	AlterID int64
+}
+
+// ProfileSettings defines settings stored as json.
+type ProfileSettings struct {
+	Theme   string `json:"theme"`
+	Size    int    `json:"size"`
+	Address struct {
+		City string `json:"city"`
+	} `json:"address"`
+}
+
+// Profile defines profiles schema with json and array columns.
+type Profile struct {
+	ID       int64
+	Settings ProfileSettings `db:",json"`
+	Tags     []string        `db:",json"`
+	Labels   []string
+}
+
 // Composite primaries example.
 type Composite struct {
 	Primary1 int `db:",primary"`
@@ -41,9 +41,16 @@ func (a *Adapter) Aggregate(ctx context.Context, query rel.Query, mode string, f
 	var (
 		err             error
 		out             sql.NullInt64
-		statement, args = NewBuilder(a.Config).Aggregate(query, mode, field)
+		builder         = NewBuilder(a.Config)
+		statement, args = builder.Aggregate(query, mode, field)
 	)
 
+	if err = builder.Err(); err != nil {
+		return 0, err
+	}
+
+	args = a.Arguments(args)
+
 	finish := a.Instrumenter.Observe(ctx, "adapter-aggregate", statement)
 	if a.Tx != nil {
 		err = a.Tx.QueryRowContext(ctx, statement, args...).Scan(&out)
@@ -58,9 +65,14 @@ func (a *Adapter) Aggregate(ctx context.Context, query rel.Query, mode string, f
 // Query performs query operation.
 func (a *Adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
 	var (
-		statement, args = NewBuilder(a.Config).Find(query)
+		builder         = NewBuilder(a.Config)
+		statement, args = builder.Find(query)
 	)
 
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
 	finish := a.Instrumenter.Observe(ctx, "adapter-query", statement)
 	rows, err := a.query(ctx, statement, args)
 	finish(err)
@@ -69,6 +81,8 @@ func (a *Adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error
 }
 
 func (a *Adapter) query(ctx context.Context, statement string, args []interface{}) (*sql.Rows, error) {
+	args = a.Arguments(args)
+
 	if a.Tx != nil {
 		return a.Tx.QueryContext(ctx, statement, args...)
 	}
@@ -76,6 +90,23 @@ func (a *Adapter) query(ctx context.Context, statement string, args []interface{
 	return a.DB.QueryContext(ctx, statement, args...)
 }
 
+// Arguments converts query arguments into value supported by the driver using ArgumentFunc of the config.
+func (a *Adapter) Arguments(args []interface{}) []interface{} {
+	if a.Config.ArgumentFunc == nil {
+		return args
+	}
+
+	var (
+		result = make([]interface{}, len(args))
+	)
+
+	for i := range args {
+		result[i] = a.Config.ArgumentFunc(args[i])
+	}
+
+	return result
+}
+
 // Exec performs exec operation.
 func (a *Adapter) Exec(ctx context.Context, statement string, args []interface{}) (int64, int64, error) {
 	finish := a.Instrumenter.Observe(ctx, "adapter-exec", statement)
@@ -93,6 +124,8 @@ func (a *Adapter) Exec(ctx context.Context, statement string, args []interface{}
 }
 
 func (a *Adapter) exec(ctx context.Context, statement string, args []interface{}) (sql.Result, error) {
+	args = a.Arguments(args)
+
 	if a.Tx != nil {
 		return a.Tx.ExecContext(ctx, statement, args...)
 	}
@@ -101,18 +134,91 @@ func (a *Adapter) exec(ctx context.Context, statement string, args []interface{}
 }
 
 // Insert inserts a record to database and returns its id.
-func (a *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate) (interface{}, error) {
+func (a *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (interface{}, error) {
 	var (
-		statement, args = NewBuilder(a.Config).Insert(query.Table, mutates)
-		id, _, err      = a.Exec(ctx, statement, args)
+		builder         = NewBuilder(a.Config)
+		statement, args = builder.OnConflict(onConflict).Insert(query.Table, mutates)
 	)
 
-	return id, err
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
+	id, insertedCount, err := a.Exec(ctx, statement, args)
+	if err != nil || onConflict.IsZero() || primaryField == "" {
+		return id, err
+	}
+
+	// last insert id is unreliable when conflict is resolved, retrieve it using conflict keys instead.
+	if len(onConflict.Keys) > 0 {
+		return a.SelectPrimary(ctx, query.Table, primaryField, onConflict.Keys, mutates)
+	}
+
+	// last insert id is only meaningful when a single row is inserted,
+	// mysql reports two affected rows when existing row is updated and zero when it's unchanged.
+	if insertedCount != 1 {
+		return nil, nil
+	}
+
+	return id, nil
+}
+
+// SelectPrimary returns primary value of a record using unique keys value in mutates.
+// It returns nil if keys is empty or any of the key is not set by mutates.
+func (a *Adapter) SelectPrimary(ctx context.Context, table string, primaryField string, keys []string, mutates map[string]rel.Mutate) (interface{}, error) {
+	var (
+		id     interface{}
+		filter rel.FilterQuery
+	)
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	for _, key := range keys {
+		mut, ok := mutates[key]
+		if !ok || mut.Type != rel.ChangeSetOp {
+			return nil, nil
+		}
+
+		filter = filter.AndEq(key, mut.Value)
+	}
+
+	cur, err := a.Query(ctx, rel.Select(primaryField).From(table).Where(filter).Limit(1))
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close()
+
+	if !cur.Next() {
+		return nil, nil
+	}
+
+	if err := cur.Scan(&id); err != nil {
+		return nil, err
+	}
+
+	// string primary value might be returned as bytes by the driver.
+	if b, ok := id.([]byte); ok {
+		id = string(b)
+	}
+
+	return id, nil
 }
 
 // InsertAll inserts all record to database and returns its ids.
-func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate) ([]interface{}, error) {
-	statement, args := NewBuilder(a.Config).InsertAll(query.Table, fields, bulkMutates)
+// Ids is not returned when conflict resolution is used, because it can't be inferred using last insert id.
+func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]interface{}, error) {
+	var (
+		builder         = NewBuilder(a.Config)
+		statement, args = builder.OnConflict(onConflict).InsertAll(query.Table, fields, bulkMutates)
+	)
+
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+
 	id, _, err := a.Exec(ctx, statement, args)
 	if err != nil {
 		return nil, err
@@ -123,6 +229,10 @@ func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField s
 		inc = 1
 	)
 
+	if !onConflict.IsZero() {
+		return ids, nil
+	}
+
 	if a.Config.IncrementFunc != nil {
 		inc = a.Config.IncrementFunc(*a)
 	}
@@ -152,20 +262,30 @@ func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField s
 // Update updates a record in database.
 func (a *Adapter) Update(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate) (int, error) {
 	var (
-		statement, args      = NewBuilder(a.Config).Update(query.Table, mutates, query.WhereQuery)
-		_, updatedCount, err = a.Exec(ctx, statement, args)
+		builder         = NewBuilder(a.Config)
+		statement, args = builder.With(query.WithQuery...).Update(query.Table, mutates, query.WhereQuery)
 	)
 
+	if err := builder.Err(); err != nil {
+		return 0, err
+	}
+
+	_, updatedCount, err := a.Exec(ctx, statement, args)
 	return int(updatedCount), err
 }
 
 // Delete deletes all results that match the query.
 func (a *Adapter) Delete(ctx context.Context, query rel.Query) (int, error) {
 	var (
-		statement, args      = NewBuilder(a.Config).Delete(query.Table, query.WhereQuery)
-		_, deletedCount, err = a.Exec(ctx, statement, args)
+		builder         = NewBuilder(a.Config)
+		statement, args = builder.With(query.WithQuery...).Delete(query.Table, query.WhereQuery)
 	)
 
+	if err := builder.Err(); err != nil {
+		return 0, err
+	}
+
+	_, deletedCount, err := a.Exec(ctx, statement, args)
 	return int(deletedCount), err
 }
 
@@ -244,6 +364,10 @@ func (a *Adapter) Apply(ctx context.Context, migration rel.Migration) error {
 
 	switch v := migration.(type) {
 	case rel.Table:
+		if v.Op == rel.SchemaAlter && a.Config.AlterTableFunc != nil {
+			return a.Config.AlterTableFunc(ctx, a, v)
+		}
+
 		statement = builder.Table(v)
 	case rel.Index:
 		statement = builder.Index(v)
@@ -251,6 +375,10 @@ func (a *Adapter) Apply(ctx context.Context, migration rel.Migration) error {
 		statement = string(v)
 	}
 
+	if err := builder.Err(); err != nil {
+		return err
+	}
+
 	_, _, err := a.Exec(ctx, statement, nil)
 	return err
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	db "database/sql"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 )
@@ -124,6 +126,27 @@ func TestAdapter_Query_error(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestAdapter_jsonFilterNotSupported(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		query   = rel.From("names").Where(where.JSONContains("name", "Luffy"))
+	)
+	defer adapter.Close()
+
+	_, err := adapter.Aggregate(ctx, query, "count", "*")
+	assert.Equal(t, ErrUnsupportedJSONFilter, err)
+
+	_, err = adapter.Query(ctx, query)
+	assert.Equal(t, ErrUnsupportedJSONFilter, err)
+
+	_, err = adapter.Update(ctx, query, map[string]rel.Mutate{"name": rel.Set("name", "Zoro")})
+	assert.Equal(t, ErrUnsupportedJSONFilter, err)
+
+	_, err = adapter.Delete(ctx, query)
+	assert.Equal(t, ErrUnsupportedJSONFilter, err)
+}
+
 func TestAdapter_Insert(t *testing.T) {
 	var (
 		adapter = open(t)
@@ -176,6 +199,98 @@ func TestAdapter_InsertAll_customPrimary(t *testing.T) {
 	assert.Equal(t, "Zoro", names[1].Name)
 }
 
+func TestAdapter_Insert_onConflict(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		repo    = rel.New(adapter)
+		name    = Name{
+			Name: "Luffy",
+		}
+	)
+	defer adapter.Close()
+
+	repo.MustInsert(ctx, &name)
+
+	var (
+		ignored  = Name{ID: name.ID, Name: "Zoro"}
+		replaced = Name{ID: name.ID, Name: "Sanji"}
+	)
+
+	assert.Nil(t, repo.Insert(ctx, &ignored, rel.OnConflictKeys("id").DoNothing()))
+	assert.Equal(t, name.ID, ignored.ID)
+	assert.Nil(t, repo.Find(ctx, &ignored, rel.Eq("id", name.ID)))
+	assert.Equal(t, "Luffy", ignored.Name)
+
+	assert.Nil(t, repo.Insert(ctx, &replaced, rel.OnConflictReplace("id")))
+	assert.Equal(t, name.ID, replaced.ID)
+	assert.Nil(t, repo.Find(ctx, &replaced, rel.Eq("id", name.ID)))
+	assert.Equal(t, "Sanji", replaced.Name)
+}
+
+func TestAdapter_SelectPrimary_emptyKeys(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		repo    = rel.New(adapter)
+		name    = Name{Name: "Luffy"}
+	)
+	defer adapter.Close()
+
+	repo.MustInsert(ctx, &name)
+
+	id, err := adapter.SelectPrimary(ctx, "names", "id", nil, map[string]rel.Mutate{"name": rel.Set("name", "Luffy")})
+	assert.Nil(t, err)
+	assert.Nil(t, id)
+}
+
+func TestAdapter_SelectPrimary_string(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		repo    = rel.New(adapter)
+		name    = Name{Name: "Chopper"}
+	)
+	defer adapter.Close()
+
+	repo.MustInsert(ctx, &name)
+
+	id, err := adapter.SelectPrimary(ctx, "names", "name", []string{"id"}, map[string]rel.Mutate{"id": rel.Set("id", name.ID)})
+	assert.Nil(t, err)
+	assert.Equal(t, "Chopper", id)
+}
+
+func TestAdapter_Insert_onConflictWithoutTarget(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		repo    = rel.New(adapter)
+		name    = Name{Name: "Luffy"}
+	)
+	defer adapter.Close()
+
+	assert.Equal(t, ErrConflictTargetRequired, repo.Insert(ctx, &name, rel.OnConflict{Replace: true, Fields: []string{"name"}}))
+	assert.Equal(t, ErrConflictTargetRequired, repo.InsertAll(ctx, &[]Name{name}, rel.OnConflict{Replace: true, Fields: []string{"name"}}))
+}
+
+func TestAdapter_InsertAll_onConflict(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		repo    = rel.New(adapter)
+		names   = []Name{
+			{ID: 30, Name: "Luffy"},
+			{ID: 31, Name: "Zoro"},
+		}
+	)
+	defer adapter.Close()
+
+	assert.Nil(t, repo.InsertAll(ctx, &names))
+	assert.Nil(t, repo.InsertAll(ctx, &names, rel.OnConflictIgnore()))
+	assert.Equal(t, 30, names[0].ID)
+	assert.Equal(t, 31, names[1].ID)
+}
+
 func TestAdapter_Update(t *testing.T) {
 	var (
 		adapter = open(t)
@@ -295,7 +410,7 @@ func TestAdapter_InsertAll_error(t *testing.T) {
 		{"notexist": rel.Set("notexist", "12")},
 	}
 
-	ids, err := adapter.InsertAll(context.TODO(), rel.Query{}, "id", fields, mutations)
+	ids, err := adapter.InsertAll(context.TODO(), rel.Query{}, "id", fields, mutations, rel.OnConflict{})
 	assert.NotNil(t, err)
 	assert.Nil(t, ids)
 }
@@ -362,4 +477,47 @@ func TestAdapter_Apply(t *testing.T) {
 	t.Run("Raw", func(t *testing.T) {
 		adapter.Apply(ctx, rel.Raw("SELECT 1;"))
 	})
+
+	t.Run("AlterTableFunc", func(t *testing.T) {
+		var (
+			altered rel.Table
+			table   = rel.Table{Op: rel.SchemaAlter, Name: "tests"}
+			err     = errors.New("error")
+		)
+
+		adapter.Config.AlterTableFunc = func(ctx context.Context, adapter *Adapter, table rel.Table) error {
+			altered = table
+			return err
+		}
+		defer func() { adapter.Config.AlterTableFunc = nil }()
+
+		assert.Equal(t, err, adapter.Apply(ctx, table))
+		assert.Equal(t, table, altered)
+	})
+}
+
+func TestAdapter_Arguments(t *testing.T) {
+	var (
+		adapter = New(Config{
+			ArgumentFunc: func(arg interface{}) interface{} {
+				if s, ok := arg.([]string); ok {
+					return strings.Join(s, ",")
+				}
+				return arg
+			},
+		})
+		args = []interface{}{1, []string{"a", "b"}}
+	)
+
+	assert.Equal(t, []interface{}{1, "a,b"}, adapter.Arguments(args))
+	assert.Equal(t, args, New(Config{}).Arguments(args))
+}
+
+func TestJSONArray(t *testing.T) {
+	var (
+		adapter = New(Config{ArgumentFunc: JSONArray})
+		args    = []interface{}{1, []string{"a", `b"c`}, []int64{1, 2}, []string(nil), "[]"}
+	)
+
+	assert.Equal(t, []interface{}{1, `["a","b\"c"]`, "[1,2]", nil, "[]"}, adapter.Arguments(args))
 }
@@ -2,9 +2,11 @@ package sql
 
 import (
 	"encoding/json"
+	"errors"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-rel/rel"
 )
@@ -16,9 +18,23 @@ var fieldCache sync.Map
 
 // Builder defines information of query b.
 type Builder struct {
-	config      Config
-	returnField string
-	count       int
+	config       Config
+	returnFields []string
+	onConflict   rel.OnConflict
+	withQueries  []rel.WithQuery
+	count        int
+	err          error
+}
+
+// ErrUnsupportedJSONFilter returned when json filter is used with dialect that doesn't define json functions.
+var ErrUnsupportedJSONFilter = errors.New("rel: json filter is not supported by this adapter")
+
+// ErrConflictTargetRequired returned when conflicting insertion is updated without specifying conflict keys or constraint.
+var ErrConflictTargetRequired = errors.New("rel: conflict keys or constraint is required to update on conflict")
+
+// Err returns error occurred while building query, such as using filter that's not supported by the dialect.
+func (b *Builder) Err() error {
+	return b.err
 }
 
 // Table generates query for table creation and modification.
@@ -91,8 +107,9 @@ func (b *Builder) alterTable(buffer *Buffer, table rel.Table) {
 			case rel.SchemaCreate:
 				buffer.WriteString("ADD COLUMN ")
 				b.column(buffer, v)
+			case rel.SchemaAlter:
+				b.alterColumn(buffer, v)
 			case rel.SchemaRename:
-				// Add Change
 				buffer.WriteString("RENAME COLUMN ")
 				buffer.WriteString(Escape(b.config, v.Name))
 				buffer.WriteString(" TO ")
@@ -102,11 +119,12 @@ func (b *Builder) alterTable(buffer *Buffer, table rel.Table) {
 				buffer.WriteString(Escape(b.config, v.Name))
 			}
 		case rel.Key:
-			// TODO: Rename and Drop, PR welcomed.
 			switch v.Op {
 			case rel.SchemaCreate:
 				buffer.WriteString("ADD ")
 				b.key(buffer, v)
+			case rel.SchemaDrop:
+				b.dropKey(buffer, v)
 			}
 		}
 
@@ -115,26 +133,55 @@ func (b *Builder) alterTable(buffer *Buffer, table rel.Table) {
 	}
 }
 
-func (b *Builder) column(buffer *Buffer, column rel.Column) {
+func (b *Builder) alterColumn(buffer *Buffer, column rel.Column) {
+	// column is redefined, nullability change requires the column type to be resolved by AlterTableFunc.
+	if b.config.ModifyColumn && column.Alter <= rel.AlterColumnNotNull {
+		buffer.WriteString("MODIFY COLUMN ")
+		b.column(buffer, column)
+		return
+	}
+
 	var (
-		typ, m, n = b.config.MapColumnFunc(&column)
+		name = Escape(b.config, column.Name)
 	)
 
-	buffer.WriteString(Escape(b.config, column.Name))
-	buffer.WriteByte(' ')
-	buffer.WriteString(typ)
+	buffer.WriteString("ALTER COLUMN ")
+	buffer.WriteString(name)
 
-	if m != 0 {
-		buffer.WriteByte('(')
-		buffer.WriteString(strconv.Itoa(m))
+	switch column.Alter {
+	case rel.AlterColumnType:
+		buffer.WriteString(" TYPE ")
+		b.columnType(buffer, &column)
+		b.options(buffer, column.Options)
 
-		if n != 0 {
-			buffer.WriteByte(',')
-			buffer.WriteString(strconv.Itoa(n))
+		if column.Required {
+			buffer.WriteString(", ALTER COLUMN ")
+			buffer.WriteString(name)
+			buffer.WriteString(" SET NOT NULL")
 		}
 
-		buffer.WriteByte(')')
+		if column.Default != nil {
+			buffer.WriteString(", ALTER COLUMN ")
+			buffer.WriteString(name)
+			buffer.WriteString(" SET DEFAULT ")
+			b.defaultValue(buffer, column.Default)
+		}
+	case rel.AlterColumnNull:
+		buffer.WriteString(" DROP NOT NULL")
+	case rel.AlterColumnNotNull:
+		buffer.WriteString(" SET NOT NULL")
+	case rel.AlterColumnDefault:
+		buffer.WriteString(" SET DEFAULT ")
+		b.defaultValue(buffer, column.Default)
+	case rel.AlterColumnDropDefault:
+		buffer.WriteString(" DROP DEFAULT")
 	}
+}
+
+func (b *Builder) column(buffer *Buffer, column rel.Column) {
+	buffer.WriteString(Escape(b.config, column.Name))
+	buffer.WriteByte(' ')
+	b.columnType(buffer, &column)
 
 	if column.Unsigned {
 		buffer.WriteString(" UNSIGNED")
@@ -150,32 +197,65 @@ func (b *Builder) column(buffer *Buffer, column rel.Column) {
 
 	if column.Default != nil {
 		buffer.WriteString(" DEFAULT ")
-		switch v := column.Default.(type) {
-		case string:
-			// TODO: single quote only required by postgres.
-			buffer.WriteByte('\'')
-			buffer.WriteString(v)
-			buffer.WriteByte('\'')
-		default:
-			// TODO: improve
-			bytes, _ := json.Marshal(column.Default)
-			buffer.Write(bytes)
-		}
+		b.defaultValue(buffer, column.Default)
 	}
 
 	b.options(buffer, column.Options)
 }
 
-func (b *Builder) key(buffer *Buffer, key rel.Key) {
+func (b *Builder) columnType(buffer *Buffer, column *rel.Column) {
 	var (
-		typ = string(key.Type)
+		typ, m, n = b.config.MapColumnFunc(column)
 	)
 
 	buffer.WriteString(typ)
 
+	if m != 0 {
+		buffer.WriteByte('(')
+		buffer.WriteString(strconv.Itoa(m))
+
+		if n != 0 {
+			buffer.WriteByte(',')
+			buffer.WriteString(strconv.Itoa(n))
+		}
+
+		buffer.WriteByte(')')
+	}
+}
+
+func (b *Builder) defaultValue(buffer *Buffer, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		// TODO: single quote only required by postgres.
+		buffer.WriteByte('\'')
+		buffer.WriteString(v)
+		buffer.WriteByte('\'')
+	case time.Time:
+		buffer.WriteByte('\'')
+		buffer.WriteString(v.Format("2006-01-02 15:04:05"))
+		buffer.WriteByte('\'')
+	default:
+		// TODO: improve
+		bytes, _ := json.Marshal(value)
+		buffer.Write(bytes)
+	}
+}
+
+func (b *Builder) key(buffer *Buffer, key rel.Key) {
 	if key.Name != "" {
-		buffer.WriteByte(' ')
+		buffer.WriteString("CONSTRAINT ")
 		buffer.WriteString(Escape(b.config, key.Name))
+		buffer.WriteByte(' ')
+	}
+
+	buffer.WriteString(string(key.Type))
+
+	if key.Type == rel.CheckKey {
+		buffer.WriteString(" (")
+		buffer.WriteString(key.Expr)
+		buffer.WriteString(")")
+		b.options(buffer, key.Options)
+		return
 	}
 
 	buffer.WriteString(" (")
@@ -214,6 +294,30 @@ func (b *Builder) key(buffer *Buffer, key rel.Key) {
 	b.options(buffer, key.Options)
 }
 
+func (b *Builder) dropKey(buffer *Buffer, key rel.Key) {
+	buffer.WriteString("DROP ")
+
+	if b.config.DropKeyByType {
+		switch key.Type {
+		case rel.PrimaryKey:
+			buffer.WriteString("PRIMARY KEY")
+			return
+		case rel.ForeignKey:
+			buffer.WriteString("FOREIGN KEY ")
+		case rel.UniqueKey:
+			buffer.WriteString("INDEX ")
+		case rel.CheckKey:
+			buffer.WriteString("CHECK ")
+		default:
+			buffer.WriteString("CONSTRAINT ")
+		}
+	} else {
+		buffer.WriteString("CONSTRAINT ")
+	}
+
+	buffer.WriteString(Escape(b.config, key.Name))
+}
+
 // Index generates query for index.
 func (b *Builder) Index(index rel.Index) string {
 	var buffer Buffer
@@ -284,8 +388,12 @@ func (b *Builder) Find(query rel.Query) (string, []interface{}) {
 
 	// TODO: calculate arguments size and if possible buffer size
 
-	b.fields(&buffer, query.SelectQuery.OnlyDistinct, query.SelectQuery.Fields)
+	query = nestCombination(query)
+
+	b.with(&buffer, query.WithQuery)
+	b.fields(&buffer, query.SelectQuery)
 	b.query(&buffer, query)
+	buffer.WriteString(";")
 
 	return buffer.String(), buffer.Arguments
 }
@@ -296,6 +404,7 @@ func (b *Builder) Aggregate(query rel.Query, mode string, field string) (string,
 		buffer Buffer
 	)
 
+	b.with(&buffer, query.WithQuery)
 	buffer.WriteString("SELECT ")
 	buffer.WriteString(mode)
 	buffer.WriteByte('(')
@@ -303,19 +412,42 @@ func (b *Builder) Aggregate(query rel.Query, mode string, field string) (string,
 	buffer.WriteString(") AS ")
 	buffer.WriteString(mode)
 
+	if len(query.CombinationQuery) > 0 {
+		// aggregate the combined result as derived table.
+		query.WithQuery = nil
+		buffer.WriteString(" FROM ")
+		b.subQuery(&buffer, query)
+		buffer.WriteString(" AS ")
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString("t")
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString(";")
+
+		return buffer.String(), buffer.Arguments
+	}
+
 	for _, f := range query.GroupQuery.Fields {
 		buffer.WriteByte(',')
 		buffer.WriteString(Escape(b.config, f))
 	}
 
 	b.query(&buffer, query)
+	buffer.WriteString(";")
 
 	return buffer.String(), buffer.Arguments
 }
 
 func (b *Builder) query(buffer *Buffer, query rel.Query) {
-	b.from(buffer, query.Table)
-	b.join(buffer, query.Table, query.JoinQuery)
+	var (
+		table = query.Table
+	)
+
+	if query.Alias != "" {
+		table = query.Alias
+	}
+
+	b.from(buffer, query)
+	b.join(buffer, table, query.JoinQuery)
 	b.where(buffer, query.WhereQuery)
 
 	if len(query.GroupQuery.Fields) > 0 {
@@ -323,6 +455,7 @@ func (b *Builder) query(buffer *Buffer, query rel.Query) {
 		b.having(buffer, query.GroupQuery.Filter)
 	}
 
+	b.combination(buffer, query.CombinationQuery)
 	b.orderBy(buffer, query.SortQuery)
 	b.limitOffset(buffer, query.LimitQuery, query.OffsetQuery)
 
@@ -330,18 +463,185 @@ func (b *Builder) query(buffer *Buffer, query rel.Query) {
 		buffer.WriteByte(' ')
 		buffer.WriteString(string(query.LockQuery))
 	}
+}
 
-	buffer.WriteString(";")
+// subQuery writes query enclosed in parentheses.
+// It shares the same buffer and placeholder counter, so ordinal placeholders are numbered continuously.
+func (b *Builder) subQuery(buffer *Buffer, query rel.Query) {
+	buffer.WriteByte('(')
+
+	if query.SQLQuery.Statement != "" {
+		buffer.WriteString(b.renumber(strings.TrimSuffix(query.SQLQuery.Statement, ";")))
+		buffer.Append(query.SQLQuery.Values...)
+	} else {
+		query = nestCombination(query)
+		b.with(buffer, query.WithQuery)
+		b.fields(buffer, query.SelectQuery)
+		b.query(buffer, query)
+	}
+
+	buffer.WriteByte(')')
+}
+
+// renumber shifts ordinal placeholders of raw sql statement by the number of placeholders written before it,
+// placeholders inside quoted string or identifier are left as is.
+func (b *Builder) renumber(statement string) string {
+	if !b.config.Ordinal {
+		return statement
+	}
+
+	var (
+		result strings.Builder
+		quote  byte
+		max    int
+		ph     = b.config.Placeholder
+	)
+
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.HasPrefix(statement[i:], ph):
+			j := i + len(ph)
+			for j < len(statement) && statement[j] >= '0' && statement[j] <= '9' {
+				j++
+			}
+
+			if n, err := strconv.Atoi(statement[i+len(ph) : j]); err == nil {
+				if n > max {
+					max = n
+				}
+
+				result.WriteString(ph + strconv.Itoa(b.count+n))
+				i = j - 1
+				continue
+			}
+		}
+
+		result.WriteByte(c)
+	}
+
+	b.count += max
+	return result.String()
+}
+
+// nestCombination wraps the left side of set operations as derived table whenever the operator changes,
+// so mixed operations are evaluated from left to right regardless of the precedence of the operator used by the database.
+// Derived table is used instead of parenthesized operand, since it's not supported by some databases.
+func nestCombination(query rel.Query) rel.Query {
+	var (
+		combinations = query.CombinationQuery
+		last         = 0
+	)
+
+	for i := 1; i < len(combinations); i++ {
+		if combinations[i].Operator != combinations[i-1].Operator {
+			last = i
+		}
+	}
+
+	if last == 0 {
+		return query
+	}
+
+	left := query
+	left.WithQuery = nil
+	left.CombinationQuery = combinations[:last]
+	left.SortQuery = nil
+	left.OffsetQuery = 0
+	left.LimitQuery = 0
+	left.LockQuery = ""
+
+	outer := rel.From(left).As("t0")
+	outer.WithQuery = query.WithQuery
+	outer.CombinationQuery = combinations[last:]
+	outer.SortQuery = query.SortQuery
+	outer.OffsetQuery = query.OffsetQuery
+	outer.LimitQuery = query.LimitQuery
+	outer.LockQuery = query.LockQuery
+
+	return outer
+}
+
+// combination writes set operations of the query.
+// Combined query that has its own with, sort, offset, limit or set operations is written as derived table,
+// since those clauses are not allowed to be used directly as operand by some databases.
+func (b *Builder) combination(buffer *Buffer, combinations []rel.CombinationQuery) {
+	for i, combination := range combinations {
+		var (
+			query = combination.Query
+		)
+
+		buffer.WriteByte(' ')
+		buffer.WriteString(combination.Operator)
+		buffer.WriteByte(' ')
+
+		if len(query.WithQuery) == 0 && len(query.SortQuery) == 0 && query.OffsetQuery == 0 &&
+			query.LimitQuery == 0 && len(query.CombinationQuery) == 0 {
+			b.fields(buffer, query.SelectQuery)
+			b.query(buffer, query)
+			continue
+		}
+
+		buffer.WriteString("SELECT * FROM ")
+		b.subQuery(buffer, query)
+		buffer.WriteString(" AS ")
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString("t" + strconv.Itoa(i+1))
+		buffer.WriteString(b.config.EscapeChar)
+	}
+}
+
+func (b *Builder) with(buffer *Buffer, withs []rel.WithQuery) {
+	if len(withs) == 0 {
+		return
+	}
+
+	buffer.WriteString("WITH ")
+
+	for _, with := range withs {
+		if with.Recursive {
+			buffer.WriteString("RECURSIVE ")
+			break
+		}
+	}
+
+	for i, with := range withs {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString(with.Name)
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString(" AS ")
+		b.subQuery(buffer, with.Query)
+	}
+
+	buffer.WriteByte(' ')
 }
 
 // Insert generates query for insert.
 func (b *Builder) Insert(table string, mutates map[string]rel.Mutate) (string, []interface{}) {
 	var (
 		buffer Buffer
+		fields []string
 		count  = len(mutates)
 	)
 
-	buffer.WriteString("INSERT INTO ")
+	for field, mut := range mutates {
+		if mut.Type == rel.ChangeSetOp {
+			fields = append(fields, field)
+		}
+	}
+
+	b.insertInto(&buffer, fields)
 	buffer.WriteString(Escape(b.config, table))
 
 	if count == 0 && b.config.InsertDefaultValues {
@@ -378,12 +678,9 @@ func (b *Builder) Insert(table string, mutates map[string]rel.Mutate) (string, [
 		buffer.WriteByte(')')
 	}
 
-	if b.returnField != "" {
-		buffer.WriteString(" RETURNING ")
-		buffer.WriteString(b.config.EscapeChar)
-		buffer.WriteString(b.returnField)
-		buffer.WriteString(b.config.EscapeChar)
-	}
+	b.conflict(&buffer, table, fields)
+
+	b.returning(&buffer)
 
 	buffer.WriteString(";")
 
@@ -400,7 +697,7 @@ func (b *Builder) InsertAll(table string, fields []string, bulkMutates []map[str
 
 	buffer.Arguments = make([]interface{}, 0, fieldsCount*mutatesCount)
 
-	buffer.WriteString("INSERT INTO ")
+	b.insertInto(&buffer, fields)
 
 	buffer.WriteString(b.config.EscapeChar)
 	buffer.WriteString(table)
@@ -442,18 +739,184 @@ func (b *Builder) InsertAll(table string, fields []string, bulkMutates []map[str
 		}
 	}
 
-	if b.returnField != "" {
-		buffer.WriteString(" RETURNING ")
-		buffer.WriteString(b.config.EscapeChar)
-		buffer.WriteString(b.returnField)
-		buffer.WriteString(b.config.EscapeChar)
-	}
+	b.conflict(&buffer, table, fields)
+
+	b.returning(&buffer)
 
 	buffer.WriteString(";")
 
 	return buffer.String(), buffer.Arguments
 }
 
+func (b *Builder) returning(buffer *Buffer) {
+	if len(b.returnFields) == 0 {
+		return
+	}
+
+	buffer.WriteString(" RETURNING ")
+
+	for i, field := range b.returnFields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		buffer.WriteString(Escape(b.config, field))
+	}
+}
+
+func (b *Builder) insertInto(buffer *Buffer, fields []string) {
+	if b.config.OnDuplicateKey && !b.onConflict.IsZero() && b.conflictIgnored(fields) {
+		buffer.WriteString("INSERT IGNORE INTO ")
+	} else {
+		buffer.WriteString("INSERT INTO ")
+	}
+}
+
+func (b *Builder) conflict(buffer *Buffer, table string, fields []string) {
+	var (
+		onConflict = b.onConflict
+	)
+
+	if onConflict.IsZero() {
+		return
+	}
+
+	if b.config.OnDuplicateKey {
+		// ignore is handled using INSERT IGNORE.
+		if b.conflictIgnored(fields) {
+			return
+		}
+
+		buffer.WriteString(" ON DUPLICATE KEY UPDATE ")
+		b.conflictUpdate(buffer, table, fields)
+		return
+	}
+
+	buffer.WriteString(" ON CONFLICT")
+
+	if onConflict.Constraint != "" {
+		buffer.WriteString(" ON CONSTRAINT ")
+		buffer.WriteString(Escape(b.config, onConflict.Constraint))
+	} else if len(onConflict.Keys) > 0 {
+		buffer.WriteString(" (")
+		for i, key := range onConflict.Keys {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.WriteString(Escape(b.config, key))
+		}
+		buffer.WriteByte(')')
+	}
+
+	if b.conflictIgnored(fields) {
+		buffer.WriteString(" DO NOTHING")
+		return
+	}
+
+	if onConflict.Constraint == "" && len(onConflict.Keys) == 0 {
+		b.err = ErrConflictTargetRequired
+		return
+	}
+
+	buffer.WriteString(" DO UPDATE SET ")
+	b.conflictUpdate(buffer, table, fields)
+}
+
+// conflictIgnored returns true when conflicting insertion is ignored, either explicitly or because there's nothing to update.
+func (b *Builder) conflictIgnored(fields []string) bool {
+	return b.onConflict.Ignore || (len(b.conflictReplaces(fields)) == 0 && len(b.onConflict.Mutates) == 0)
+}
+
+// conflictReplaces returns fields that's replaced by inserted value when conflict happens.
+func (b *Builder) conflictReplaces(fields []string) []string {
+	if !b.onConflict.Replace {
+		return nil
+	}
+
+	if len(b.onConflict.Fields) > 0 {
+		return b.onConflict.Fields
+	}
+
+	return conflictReplaceFields(fields, b.onConflict.Keys)
+}
+
+func (b *Builder) conflictUpdate(buffer *Buffer, table string, fields []string) {
+	var (
+		onConflict = b.onConflict
+		replaces   = b.conflictReplaces(fields)
+	)
+
+	for i, field := range replaces {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		buffer.WriteString(Escape(b.config, field))
+		buffer.WriteByte('=')
+
+		if b.config.OnDuplicateKey {
+			buffer.WriteString("VALUES(")
+			buffer.WriteString(Escape(b.config, field))
+			buffer.WriteByte(')')
+		} else {
+			buffer.WriteString("EXCLUDED.")
+			buffer.WriteString(Escape(b.config, field))
+		}
+	}
+
+	for i, mut := range onConflict.Mutates {
+		if i > 0 || len(replaces) > 0 {
+			buffer.WriteByte(',')
+		}
+
+		switch mut.Type {
+		case rel.ChangeSetOp:
+			buffer.WriteString(Escape(b.config, mut.Field))
+			buffer.WriteByte('=')
+			buffer.WriteString(b.ph())
+			buffer.Append(mut.Value)
+		case rel.ChangeIncOp:
+			buffer.WriteString(Escape(b.config, mut.Field))
+			buffer.WriteByte('=')
+			buffer.WriteString(Escape(b.config, table+"."+mut.Field))
+			buffer.WriteByte('+')
+			buffer.WriteString(b.ph())
+			buffer.Append(mut.Value)
+		case rel.ChangeFragmentOp:
+			buffer.WriteString(mut.Field)
+			buffer.Append(mut.Value.([]interface{})...)
+		}
+	}
+}
+
+// conflictReplaceFields returns inserted fields excluding conflict keys.
+// if all inserted fields are conflict keys, inserted fields is returned as is.
+func conflictReplaceFields(fields []string, keys []string) []string {
+	var (
+		result = make([]string, 0, len(fields))
+	)
+
+	for _, field := range fields {
+		excluded := false
+		for _, key := range keys {
+			if field == key {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			result = append(result, field)
+		}
+	}
+
+	if len(result) == 0 {
+		return fields
+	}
+
+	return result
+}
+
 // Update generates query for update.
 func (b *Builder) Update(table string, mutates map[string]rel.Mutate, filter rel.FilterQuery) (string, []interface{}) {
 	var (
@@ -461,6 +924,7 @@ func (b *Builder) Update(table string, mutates map[string]rel.Mutate, filter rel
 		count  = len(mutates)
 	)
 
+	b.with(&buffer, b.withQueries)
 	buffer.WriteString("UPDATE ")
 	buffer.WriteString(b.config.EscapeChar)
 	buffer.WriteString(table)
@@ -494,6 +958,7 @@ func (b *Builder) Update(table string, mutates map[string]rel.Mutate, filter rel
 	}
 
 	b.where(&buffer, filter)
+	b.returning(&buffer)
 
 	buffer.WriteString(";")
 
@@ -506,6 +971,7 @@ func (b *Builder) Delete(table string, filter rel.FilterQuery) (string, []interf
 		buffer Buffer
 	)
 
+	b.with(&buffer, b.withQueries)
 	buffer.WriteString("DELETE FROM ")
 	buffer.WriteString(b.config.EscapeChar)
 	buffer.WriteString(table)
@@ -518,9 +984,9 @@ func (b *Builder) Delete(table string, filter rel.FilterQuery) (string, []interf
 	return buffer.String(), buffer.Arguments
 }
 
-func (b *Builder) fields(buffer *Buffer, distinct bool, fields []string) {
-	if len(fields) == 0 {
-		if distinct {
+func (b *Builder) fields(buffer *Buffer, selectQuery rel.SelectQuery) {
+	if len(selectQuery.Fields) == 0 && len(selectQuery.Exprs) == 0 {
+		if selectQuery.OnlyDistinct {
 			buffer.WriteString("SELECT DISTINCT *")
 			return
 		}
@@ -530,25 +996,132 @@ func (b *Builder) fields(buffer *Buffer, distinct bool, fields []string) {
 
 	buffer.WriteString("SELECT ")
 
-	if distinct {
+	if selectQuery.OnlyDistinct {
 		buffer.WriteString("DISTINCT ")
 	}
 
-	l := len(fields) - 1
-	for i, f := range fields {
-		buffer.WriteString(Escape(b.config, f))
+	for i, f := range selectQuery.Fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
 
-		if i < l {
+		buffer.WriteString(b.selectField(f))
+	}
+
+	for i, expr := range selectQuery.Exprs {
+		if i > 0 || len(selectQuery.Fields) > 0 {
 			buffer.WriteByte(',')
 		}
+
+		b.expr(buffer, expr)
+	}
+}
+
+// selectField escapes selected field, dot separated alias used to populate joined association is escaped as a single
+// identifier, so the association path is preserved as the column name.
+func (b *Builder) selectField(field string) string {
+	if i := strings.Index(field, " AS "); i > -1 && field[0] != UnescapeCharacter && strings.Contains(field[i+4:], ".") {
+		return Escape(b.config, field[:i]) + " AS " + b.config.EscapeChar + field[i+4:] + b.config.EscapeChar
 	}
+
+	return Escape(b.config, field)
 }
 
-func (b *Builder) from(buffer *Buffer, table string) {
+func (b *Builder) expr(buffer *Buffer, expr rel.Expr) {
+	buffer.WriteString(expr.Func)
+	buffer.WriteByte('(')
+
+	if expr.Distinct {
+		buffer.WriteString("DISTINCT ")
+	}
+
+	for i, arg := range expr.Args {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		switch v := arg.(type) {
+		case string:
+			buffer.WriteString(Escape(b.config, v))
+		case int:
+			buffer.WriteString(strconv.Itoa(v))
+		default:
+			buffer.WriteString(b.ph())
+			buffer.Append(v)
+		}
+	}
+
+	buffer.WriteByte(')')
+
+	if expr.Window != nil {
+		b.window(buffer, *expr.Window)
+	}
+
+	if expr.Alias != "" {
+		buffer.WriteString(" AS ")
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString(expr.Alias)
+		buffer.WriteString(b.config.EscapeChar)
+	}
+}
+
+func (b *Builder) window(buffer *Buffer, window rel.Window) {
+	buffer.WriteString(" OVER (")
+
+	if len(window.Partition) > 0 {
+		buffer.WriteString("PARTITION BY ")
+
+		for i, f := range window.Partition {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+
+			buffer.WriteString(Escape(b.config, f))
+		}
+	}
+
+	if len(window.Sort) > 0 {
+		if len(window.Partition) > 0 {
+			buffer.WriteByte(' ')
+		}
+
+		buffer.WriteString("ORDER BY")
+		b.sorts(buffer, window.Sort)
+	}
+
+	buffer.WriteByte(')')
+}
+
+func (b *Builder) from(buffer *Buffer, query rel.Query) {
 	buffer.WriteString(" FROM ")
-	buffer.WriteString(b.config.EscapeChar)
-	buffer.WriteString(table)
-	buffer.WriteString(b.config.EscapeChar)
+
+	var (
+		alias = query.Alias
+	)
+
+	if query.TableQuery != nil {
+		b.subQuery(buffer, *query.TableQuery)
+
+		// derived table requires an alias, defaults to the table of the query or subquery.
+		if alias == "" {
+			alias = query.Table
+		}
+
+		if alias == "" {
+			alias = query.TableQuery.Table
+		}
+	} else {
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString(query.Table)
+		buffer.WriteString(b.config.EscapeChar)
+	}
+
+	if alias != "" {
+		buffer.WriteString(" AS ")
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString(alias)
+		buffer.WriteString(b.config.EscapeChar)
+	}
 }
 
 func (b *Builder) join(buffer *Buffer, table string, joins []rel.JoinQuery) {
@@ -575,13 +1148,16 @@ func (b *Builder) join(buffer *Buffer, table string, joins []rel.JoinQuery) {
 		buffer.WriteByte(' ')
 
 		if join.Table != "" {
-			buffer.WriteString(b.config.EscapeChar)
-			buffer.WriteString(join.Table)
-			buffer.WriteString(b.config.EscapeChar)
+			buffer.WriteString(Escape(b.config, join.Table))
 			buffer.WriteString(" ON ")
 			buffer.WriteString(from)
 			buffer.WriteString("=")
 			buffer.WriteString(to)
+
+			if !join.Filter.None() {
+				buffer.WriteString(" AND ")
+				b.filter(buffer, join.Filter)
+			}
 		}
 
 		buffer.Append(join.Arguments...)
@@ -620,15 +1196,19 @@ func (b *Builder) having(buffer *Buffer, filter rel.FilterQuery) {
 }
 
 func (b *Builder) orderBy(buffer *Buffer, orders []rel.SortQuery) {
-	var (
-		length = len(orders)
-	)
-
-	if length == 0 {
+	if len(orders) == 0 {
 		return
 	}
 
 	buffer.WriteString(" ORDER BY")
+	b.sorts(buffer, orders)
+}
+
+func (b *Builder) sorts(buffer *Buffer, orders []rel.SortQuery) {
+	var (
+		length = len(orders)
+	)
+
 	for i, order := range orders {
 		buffer.WriteByte(' ')
 		buffer.WriteString(Escape(b.config, order.Field))
@@ -695,6 +1275,45 @@ func (b *Builder) filter(buffer *Buffer, filter rel.FilterQuery) {
 	case rel.FilterFragmentOp:
 		buffer.WriteString(filter.Field)
 		buffer.Append(filter.Value.([]interface{})...)
+	case rel.FilterExistsOp:
+		buffer.WriteString("EXISTS ")
+		b.subQuery(buffer, filter.Value.(rel.Query))
+	case rel.FilterNotExistsOp:
+		buffer.WriteString("NOT EXISTS ")
+		b.subQuery(buffer, filter.Value.(rel.Query))
+	case rel.FilterJSONContainsOp:
+		if b.config.JSONContainsFunc == nil {
+			b.err = ErrUnsupportedJSONFilter
+			return
+		}
+
+		buffer.WriteString(b.config.JSONContainsFunc(Escape(b.config, filter.Field), b.ph()))
+		buffer.Append(rel.JSONValue(filter.Value))
+	case rel.FilterJSONExtractOp:
+		var (
+			cmp = filter.Inner[0]
+		)
+
+		if b.config.JSONExtractFunc == nil {
+			b.err = ErrUnsupportedJSONFilter
+			return
+		}
+
+		var (
+			segments = strings.Split(cmp.Field, ".")
+			path     interface{}
+		)
+
+		if b.config.JSONPathFunc != nil {
+			path = b.config.JSONPathFunc(segments)
+		} else {
+			path = JSONPath(segments)
+		}
+
+		// path is passed as argument, so it never ends up inside sql literal.
+		buffer.WriteString(b.config.JSONExtractFunc(Escape(b.config, filter.Field), b.ph(), cmp.Value))
+		buffer.Append(path)
+		b.comparison(buffer, cmp)
 	}
 }
 
@@ -724,7 +1343,11 @@ func (b *Builder) build(buffer *Buffer, op string, inner []rel.FilterQuery) {
 
 func (b *Builder) buildComparison(buffer *Buffer, filter rel.FilterQuery) {
 	buffer.WriteString(Escape(b.config, filter.Field))
+	b.comparison(buffer, filter)
+}
 
+// comparison writes comparison operator and the compared value.
+func (b *Builder) comparison(buffer *Buffer, filter rel.FilterQuery) {
 	switch filter.Type {
 	case rel.FilterEqOp:
 		buffer.WriteByte('=')
@@ -740,6 +1363,11 @@ func (b *Builder) buildComparison(buffer *Buffer, filter rel.FilterQuery) {
 		buffer.WriteString(">=")
 	}
 
+	if query, ok := filter.Value.(rel.Query); ok {
+		b.subQuery(buffer, query)
+		return
+	}
+
 	buffer.WriteString(b.ph())
 	buffer.Append(filter.Value)
 }
@@ -752,11 +1380,19 @@ func (b *Builder) buildInclusion(buffer *Buffer, filter rel.FilterQuery) {
 	buffer.WriteString(Escape(b.config, filter.Field))
 
 	if filter.Type == rel.FilterInOp {
-		buffer.WriteString(" IN (")
+		buffer.WriteString(" IN ")
 	} else {
-		buffer.WriteString(" NOT IN (")
+		buffer.WriteString(" NOT IN ")
 	}
 
+	if len(values) == 1 {
+		if query, ok := values[0].(rel.Query); ok {
+			b.subQuery(buffer, query)
+			return
+		}
+	}
+
+	buffer.WriteByte('(')
 	buffer.WriteString(b.ph())
 	for i := 1; i <= len(values)-1; i++ {
 		buffer.WriteByte(',')
@@ -775,9 +1411,28 @@ func (b *Builder) ph() string {
 	return b.config.Placeholder
 }
 
-// Returning append returning to insert rel.
-func (b *Builder) Returning(field string) *Builder {
-	b.returnField = field
+// Returning append returning to insert and update query.
+// Empty field is ignored, use "*" to return all fields.
+func (b *Builder) Returning(fields ...string) *Builder {
+	b.returnFields = nil
+	for _, field := range fields {
+		if field != "" {
+			b.returnFields = append(b.returnFields, field)
+		}
+	}
+
+	return b
+}
+
+// OnConflict append on conflict resolution to insert query.
+func (b *Builder) OnConflict(onConflict rel.OnConflict) *Builder {
+	b.onConflict = onConflict
+	return b
+}
+
+// With prepends common table expressions to update and delete query.
+func (b *Builder) With(withs ...rel.WithQuery) *Builder {
+	b.withQueries = withs
 	return b
 }
 
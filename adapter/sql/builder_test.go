@@ -59,7 +59,7 @@ func TestBuilder_Table(t *testing.T) {
 			},
 		},
 		{
-			result: "CREATE TABLE `columns` (`bool` BOOL NOT NULL DEFAULT false, `int` INT(11) UNSIGNED, `bigint` BIGINT(20) UNSIGNED, `float` FLOAT(24) UNSIGNED, `decimal` DECIMAL(6,2) UNSIGNED, `string` VARCHAR(144) UNIQUE, `text` TEXT(1000), `date` DATE, `datetime` DATETIME, `time` TIME, `timestamp` TIMESTAMP DEFAULT '2020-01-01 01:00:00', `blob` blob, PRIMARY KEY (`int`), FOREIGN KEY (`int`, `string`) REFERENCES `products` (`id`, `name`) ON DELETE CASCADE ON UPDATE CASCADE, UNIQUE `date_unique` (`date`)) Engine=InnoDB;",
+			result: "CREATE TABLE `columns` (`bool` BOOL NOT NULL DEFAULT false, `int` INT(11) UNSIGNED, `bigint` BIGINT(20) UNSIGNED, `float` FLOAT(24) UNSIGNED, `decimal` DECIMAL(6,2) UNSIGNED, `string` VARCHAR(144) UNIQUE, `text` TEXT(1000), `date` DATE, `datetime` DATETIME, `time` TIME, `timestamp` TIMESTAMP DEFAULT '2020-01-01 01:00:00', `json` JSON, `blob` blob, PRIMARY KEY (`int`), FOREIGN KEY (`int`, `string`) REFERENCES `products` (`id`, `name`) ON DELETE CASCADE ON UPDATE CASCADE, CONSTRAINT `date_unique` UNIQUE (`date`), CONSTRAINT `int_check` CHECK (`int` > 0)) Engine=InnoDB;",
 			table: rel.Table{
 				Op:   rel.SchemaCreate,
 				Name: "columns",
@@ -75,14 +75,34 @@ func TestBuilder_Table(t *testing.T) {
 					rel.Column{Name: "datetime", Type: rel.DateTime},
 					rel.Column{Name: "time", Type: rel.Time},
 					rel.Column{Name: "timestamp", Type: rel.Timestamp, Default: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)},
+					rel.Column{Name: "json", Type: rel.JSON},
 					rel.Column{Name: "blob", Type: "blob"},
 					rel.Key{Columns: []string{"int"}, Type: rel.PrimaryKey},
 					rel.Key{Columns: []string{"int", "string"}, Type: rel.ForeignKey, Reference: rel.ForeignKeyReference{Table: "products", Columns: []string{"id", "name"}, OnDelete: "CASCADE", OnUpdate: "CASCADE"}},
 					rel.Key{Columns: []string{"date"}, Name: "date_unique", Type: rel.UniqueKey},
+					rel.Key{Name: "int_check", Type: rel.CheckKey, Expr: "`int` > 0"},
 				},
 				Options: "Engine=InnoDB",
 			},
 		},
+		{
+			result: "CREATE TABLE `types` (`id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY, `serial` SERIAL, `smallint` SMALLINT, `uuid` CHAR(36), `blob` BLOB, `varbinary` VARBINARY(16), `status` ENUM('draft', 'it''s published'), `tags` JSON, `ip` VARCHAR(45));",
+			table: rel.Table{
+				Op:   rel.SchemaCreate,
+				Name: "types",
+				Definitions: []rel.TableDefinition{
+					rel.Column{Name: "id", Type: rel.BigID},
+					rel.Column{Name: "serial", Type: rel.Serial},
+					rel.Column{Name: "smallint", Type: rel.SmallInt},
+					rel.Column{Name: "uuid", Type: rel.UUID},
+					rel.Column{Name: "blob", Type: rel.Binary},
+					rel.Column{Name: "varbinary", Type: rel.Binary, Limit: 16},
+					rel.Column{Name: "status", Type: rel.Enum, Values: []string{"draft", "it's published"}},
+					rel.Column{Name: "tags", Type: rel.Array, Element: rel.String},
+					rel.Column{Name: "ip", Type: rel.Inet},
+				},
+			},
+		},
 		{
 			result: "CREATE TABLE IF NOT EXISTS `products` (`id` INT UNSIGNED AUTO_INCREMENT PRIMARY KEY, `raw` BOOL);",
 			table: rel.Table{
@@ -96,7 +116,7 @@ func TestBuilder_Table(t *testing.T) {
 			},
 		},
 		{
-			result: "ALTER TABLE `columns` ADD COLUMN `verified` BOOL;ALTER TABLE `columns` RENAME COLUMN `string` TO `name`;ALTER TABLE `columns` ;ALTER TABLE `columns` DROP COLUMN `blob`;",
+			result: "ALTER TABLE `columns` ADD COLUMN `verified` BOOL;ALTER TABLE `columns` RENAME COLUMN `string` TO `name`;ALTER TABLE `columns` ALTER COLUMN `bool` TYPE INT;ALTER TABLE `columns` DROP COLUMN `blob`;",
 			table: rel.Table{
 				Op:   rel.SchemaAlter,
 				Name: "columns",
@@ -118,6 +138,34 @@ func TestBuilder_Table(t *testing.T) {
 				},
 			},
 		},
+		{
+			result: "ALTER TABLE `columns` ALTER COLUMN `code` TYPE VARCHAR(50) USING `code`::varchar, ALTER COLUMN `code` SET NOT NULL, ALTER COLUMN `code` SET DEFAULT '';ALTER TABLE `columns` ALTER COLUMN `score` DROP NOT NULL;ALTER TABLE `columns` ALTER COLUMN `score` SET NOT NULL;ALTER TABLE `columns` ALTER COLUMN `score` SET DEFAULT 0;ALTER TABLE `columns` ALTER COLUMN `date` SET DEFAULT '2020-01-01 01:00:00';ALTER TABLE `columns` ALTER COLUMN `score` DROP DEFAULT;",
+			table: rel.Table{
+				Op:   rel.SchemaAlter,
+				Name: "columns",
+				Definitions: []rel.TableDefinition{
+					rel.Column{Name: "code", Type: rel.String, Limit: 50, Required: true, Default: "", Options: "USING `code`::varchar", Op: rel.SchemaAlter, Alter: rel.AlterColumnType},
+					rel.Column{Name: "score", Op: rel.SchemaAlter, Alter: rel.AlterColumnNull},
+					rel.Column{Name: "score", Op: rel.SchemaAlter, Alter: rel.AlterColumnNotNull},
+					rel.Column{Name: "score", Default: 0, Op: rel.SchemaAlter, Alter: rel.AlterColumnDefault},
+					rel.Column{Name: "date", Default: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC), Op: rel.SchemaAlter, Alter: rel.AlterColumnDefault},
+					rel.Column{Name: "score", Op: rel.SchemaAlter, Alter: rel.AlterColumnDropDefault},
+				},
+			},
+		},
+		{
+			result: "ALTER TABLE `columns` ADD CONSTRAINT `code_unique` UNIQUE (`code`);ALTER TABLE `columns` ADD CONSTRAINT `score_check` CHECK (`score` >= 0);ALTER TABLE `columns` DROP CONSTRAINT `user_id_fk`;ALTER TABLE `columns` DROP CONSTRAINT `score_check`;",
+			table: rel.Table{
+				Op:   rel.SchemaAlter,
+				Name: "columns",
+				Definitions: []rel.TableDefinition{
+					rel.Key{Columns: []string{"code"}, Name: "code_unique", Type: rel.UniqueKey},
+					rel.Key{Name: "score_check", Type: rel.CheckKey, Expr: "`score` >= 0"},
+					rel.Key{Name: "user_id_fk", Type: rel.ForeignKey, Op: rel.SchemaDrop},
+					rel.Key{Name: "score_check", Op: rel.SchemaDrop},
+				},
+			},
+		},
 		{
 			result: "ALTER TABLE `table` RENAME TO `table1`;",
 			table: rel.Table{
@@ -155,6 +203,46 @@ func TestBuilder_Table(t *testing.T) {
 	}
 }
 
+func TestBuilder_Table_modifyColumn(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder:   "?",
+			EscapeChar:    "`",
+			MapColumnFunc: MapColumn,
+			ModifyColumn:  true,
+			DropKeyByType: true,
+		}
+		builder = NewBuilder(config)
+		table   = rel.Table{
+			Op:   rel.SchemaAlter,
+			Name: "columns",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Name: "code", Type: rel.String, Limit: 50, Required: true, Op: rel.SchemaAlter, Alter: rel.AlterColumnType},
+				rel.Column{Name: "score", Type: "int(11)", Op: rel.SchemaAlter, Alter: rel.AlterColumnNull},
+				rel.Column{Name: "score", Type: "int(11)", Required: true, Default: "0", Op: rel.SchemaAlter, Alter: rel.AlterColumnNotNull},
+				rel.Column{Name: "score", Default: 0, Op: rel.SchemaAlter, Alter: rel.AlterColumnDefault},
+				rel.Column{Name: "score", Op: rel.SchemaAlter, Alter: rel.AlterColumnDropDefault},
+				rel.Key{Name: "pk", Type: rel.PrimaryKey, Op: rel.SchemaDrop},
+				rel.Key{Name: "user_id_fk", Type: rel.ForeignKey, Op: rel.SchemaDrop},
+				rel.Key{Name: "code_unique", Type: rel.UniqueKey, Op: rel.SchemaDrop},
+				rel.Key{Name: "score_check", Type: rel.CheckKey, Op: rel.SchemaDrop},
+				rel.Key{Name: "unknown", Op: rel.SchemaDrop},
+			},
+		}
+	)
+
+	assert.Equal(t, "ALTER TABLE `columns` MODIFY COLUMN `code` VARCHAR(50) NOT NULL;"+
+		"ALTER TABLE `columns` MODIFY COLUMN `score` int(11);"+
+		"ALTER TABLE `columns` MODIFY COLUMN `score` int(11) NOT NULL DEFAULT '0';"+
+		"ALTER TABLE `columns` ALTER COLUMN `score` SET DEFAULT 0;"+
+		"ALTER TABLE `columns` ALTER COLUMN `score` DROP DEFAULT;"+
+		"ALTER TABLE `columns` DROP PRIMARY KEY;"+
+		"ALTER TABLE `columns` DROP FOREIGN KEY `user_id_fk`;"+
+		"ALTER TABLE `columns` DROP INDEX `code_unique`;"+
+		"ALTER TABLE `columns` DROP CHECK `score_check`;"+
+		"ALTER TABLE `columns` DROP CONSTRAINT `unknown`;", builder.Table(table))
+}
+
 func TestBuilder_Index(t *testing.T) {
 	var (
 		config = Config{
@@ -267,6 +355,11 @@ func TestBuilder_Find(t *testing.T) {
 			nil,
 			query.Select("id", "name"),
 		},
+		{
+			"SELECT `users`.*,`buyer`.`id` AS `buyer.id`,`name` AS `user`.`name` FROM `users`;",
+			nil,
+			query.Select("users.*", "buyer.id AS buyer.id", "name as user.name"),
+		},
 		{
 			"SELECT `id`,FIELD(`gender`, \"male\") AS `order` FROM `users` ORDER BY `order` ASC;",
 			nil,
@@ -398,6 +491,77 @@ func TestBuilder_Find_ordinal(t *testing.T) {
 			nil,
 			query.Offset(10).Limit(10),
 		},
+		{
+			"SELECT * FROM \"users\" WHERE (\"name\"=$1 AND \"id\" IN (SELECT \"user_id\" FROM \"transactions\" WHERE \"status\"=$2) AND \"age\">$3);",
+			[]interface{}{"name", "paid", 10},
+			query.Where(where.Eq("name", "name"), where.In("id", rel.Select("user_id").From("transactions").Where(where.Eq("status", "paid"))), where.Gt("age", 10)),
+		},
+		{
+			"SELECT * FROM \"users\" WHERE (\"id\"=$1 OR EXISTS (SELECT * FROM \"transactions\" WHERE \"transactions\".\"user_id\"=$2));",
+			[]interface{}{1, 2},
+			query.Where(where.Eq("id", 1).Or(where.Exists(rel.From("transactions").Where(where.Eq("transactions.user_id", 2))))),
+		},
+		{
+			"SELECT \"t\".\"name\" FROM (SELECT * FROM \"users\" WHERE \"age\">$1) AS \"t\" WHERE \"t\".\"name\"=$2;",
+			[]interface{}{10, "name"},
+			rel.Select("t.name").From(rel.From("users").Where(where.Gt("age", 10))).As("t").Where(where.Eq("t.name", "name")),
+		},
+		{
+			"SELECT * FROM (SELECT * FROM \"users\" WHERE \"age\">$1) AS \"users\";",
+			[]interface{}{10},
+			rel.From(rel.From("users").Where(where.Gt("age", 10))),
+		},
+		{
+			"SELECT * FROM (SELECT * FROM \"users\") AS \"people\";",
+			nil,
+			rel.Build("people", rel.From(rel.From("users"))),
+		},
+		{
+			"SELECT * FROM \"users\" WHERE (\"name\"=$1 AND \"id\" IN (SELECT user_id FROM transactions WHERE status=$2 AND note='$1' AND total>$3));",
+			[]interface{}{"name", "paid", 10},
+			query.Where(where.Eq("name", "name"), where.In("id", rel.Build("", rel.SQL("SELECT user_id FROM transactions WHERE status=$1 AND note='$1' AND total>$2", "paid", 10)))),
+		},
+		{
+			"WITH \"adults\" AS (SELECT * FROM \"users\" WHERE \"age\">$1),\"paid\" AS (SELECT \"user_id\" FROM \"transactions\" WHERE \"status\"=$2) SELECT * FROM \"adults\" WHERE (\"id\" IN (SELECT \"user_id\" FROM \"paid\") AND \"name\"=$3) ORDER BY \"name\" ASC LIMIT 10;",
+			[]interface{}{17, "paid", "name"},
+			rel.With("adults", rel.From("users").Where(where.Gt("age", 17))).
+				With("paid", rel.Select("user_id").From("transactions").Where(where.Eq("status", "paid"))).
+				From("adults").Where(where.In("id", rel.Select("user_id").From("paid")), where.Eq("name", "name")).SortAsc("name").Limit(10),
+		},
+		{
+			"WITH RECURSIVE \"tree\" AS (SELECT id, parent_id FROM categories WHERE id=$1 UNION ALL SELECT c.id, c.parent_id FROM categories c JOIN tree t ON c.parent_id = t.id) SELECT * FROM \"tree\" JOIN \"categories\" ON \"categories\".\"id\"=\"tree\".\"id\";",
+			[]interface{}{1},
+			rel.WithRecursive("tree", rel.Build("", rel.SQL("SELECT id, parent_id FROM categories WHERE id=$1 UNION ALL SELECT c.id, c.parent_id FROM categories c JOIN tree t ON c.parent_id = t.id", 1))).
+				From("tree").JoinOn("categories", "categories.id", "tree.id"),
+		},
+		{
+			"SELECT \"id\",\"name\" FROM \"users\" WHERE \"active\"=$1 UNION SELECT \"id\",\"name\" FROM \"archived_users\" WHERE \"age\">$2 ORDER BY \"name\" ASC LIMIT 10 OFFSET 5;",
+			[]interface{}{true, 10},
+			query.Select("id", "name").Where(where.Eq("active", true)).
+				Union(rel.Select("id", "name").From("archived_users").Where(where.Gt("age", 10))).
+				SortAsc("name").Offset(5).Limit(10),
+		},
+		{
+			"SELECT * FROM (SELECT * FROM (SELECT \"id\" FROM \"users\" UNION ALL SELECT \"id\" FROM \"admins\") AS \"t0\" INTERSECT SELECT \"id\" FROM \"members\") AS \"t0\" EXCEPT SELECT \"id\" FROM \"banned\" WHERE \"reason\"=$1;",
+			[]interface{}{"spam"},
+			query.Select("id").UnionAll(rel.Select("id").From("admins")).Intersect(rel.Select("id").From("members")).Except(rel.Select("id").From("banned").Where(where.Eq("reason", "spam"))),
+		},
+		{
+			"SELECT * FROM (SELECT \"id\" FROM \"users\" WHERE \"age\">$1 UNION SELECT \"id\" FROM \"admins\") AS \"t0\" INTERSECT SELECT \"id\" FROM \"members\" WHERE \"age\"<$2 ORDER BY \"id\" ASC LIMIT 5;",
+			[]interface{}{10, 20},
+			query.Select("id").Where(where.Gt("age", 10)).
+				Union(rel.Select("id").From("admins")).
+				Intersect(rel.Select("id").From("members").Where(where.Lt("age", 20))).
+				SortAsc("id").Limit(5),
+		},
+		{
+			"SELECT \"id\" FROM \"users\" WHERE \"age\">$1 UNION SELECT * FROM (SELECT \"id\" FROM \"admins\" WHERE \"age\">$2 ORDER BY \"id\" DESC LIMIT 1) AS \"t1\" UNION SELECT * FROM (SELECT \"id\" FROM \"members\" INTERSECT SELECT \"id\" FROM \"banned\") AS \"t2\" ORDER BY \"id\" ASC;",
+			[]interface{}{10, 20},
+			query.Select("id").Where(where.Gt("age", 10)).
+				Union(rel.Select("id").From("admins").Where(where.Gt("age", 20)).SortDesc("id").Limit(1)).
+				Union(rel.Select("id").From("members").Intersect(rel.Select("id").From("banned"))).
+				SortAsc("id"),
+		},
 	}
 
 	for _, test := range tests {
@@ -460,6 +624,10 @@ func TestBuilder_Aggregate(t *testing.T) {
 	qs, args = builder.Aggregate(query.Group("gender"), "sum", "transactions.total")
 	assert.Nil(t, args)
 	assert.Equal(t, "SELECT sum(`transactions`.`total`) AS sum,`gender` FROM `users` GROUP BY `gender`;", qs)
+
+	qs, args = builder.Aggregate(query.Select("id").Where(where.Eq("active", true)).Union(rel.Select("id").From("archived_users")), "count", "*")
+	assert.Equal(t, []interface{}{true}, args)
+	assert.Equal(t, "SELECT count(*) AS count FROM (SELECT `id` FROM `users` WHERE `active`=? UNION SELECT `id` FROM `archived_users`) AS `t`;", qs)
 }
 
 func BenchmarkBuilder_Insert(b *testing.B) {
@@ -650,6 +818,173 @@ func TestBuilder_InsertAll_ordinal(t *testing.T) {
 	assert.Equal(t, []interface{}{"foo", 10, "boo", 20}, args)
 }
 
+func TestBuilder_Insert_onConflict(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder:         "$",
+			EscapeChar:          "\"",
+			Ordinal:             true,
+			InsertDefaultValues: true,
+		}
+		mutates = map[string]rel.Mutate{
+			"name": rel.Set("name", "foo"),
+		}
+	)
+
+	tests := []struct {
+		result     string
+		args       []interface{}
+		onConflict rel.OnConflict
+	}{
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT DO NOTHING RETURNING \"id\";",
+			args:       []interface{}{"foo"},
+			onConflict: rel.OnConflictIgnore(),
+		},
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT ON CONSTRAINT \"users_name_key\" DO NOTHING RETURNING \"id\";",
+			args:       []interface{}{"foo"},
+			onConflict: rel.OnConflictConstraint("users_name_key").DoNothing(),
+		},
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT (\"name\") DO UPDATE SET \"name\"=EXCLUDED.\"name\" RETURNING \"id\";",
+			args:       []interface{}{"foo"},
+			onConflict: rel.OnConflictReplace("name"),
+		},
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT (\"name\") DO UPDATE SET \"count\"=\"users\".\"count\"+$2,\"note\"=$3,updated_at=now() RETURNING \"id\";",
+			args:       []interface{}{"foo", 1, "dup"},
+			onConflict: rel.OnConflictKeys("name").DoUpdate(rel.Inc("count"), rel.Set("note", "dup"), rel.Setf("updated_at=now()")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				builder  = NewBuilder(config)
+				qs, args = builder.Returning("id").OnConflict(test.onConflict).Insert("users", mutates)
+			)
+
+			assert.Equal(t, test.result, qs)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestBuilder_Insert_onConflictNothingToUpdate(t *testing.T) {
+	var (
+		mutates = map[string]rel.Mutate{}
+	)
+
+	tests := []struct {
+		result string
+		config Config
+	}{
+		{
+			result: "INSERT INTO `users` DEFAULT VALUES ON CONFLICT (`id`) DO NOTHING;",
+			config: Config{Placeholder: "?", EscapeChar: "`", InsertDefaultValues: true},
+		},
+		{
+			result: "INSERT IGNORE INTO `users` () VALUES ();",
+			config: Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				builder = NewBuilder(test.config)
+				qs, _   = builder.OnConflict(rel.OnConflictReplace("id")).Insert("users", mutates)
+			)
+
+			assert.Equal(t, test.result, qs)
+		})
+	}
+}
+
+func TestBuilder_Insert_onConflictWithoutTarget(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder:         "$",
+			EscapeChar:          "\"",
+			Ordinal:             true,
+			InsertDefaultValues: true,
+		}
+		builder = NewBuilder(config)
+		mutates = map[string]rel.Mutate{
+			"name": rel.Set("name", "foo"),
+		}
+	)
+
+	builder.OnConflict(rel.OnConflict{Replace: true, Fields: []string{"name"}}).Insert("users", mutates)
+	assert.Equal(t, ErrConflictTargetRequired, builder.Err())
+}
+
+func TestBuilder_InsertAll_onConflict(t *testing.T) {
+	var (
+		bulkMutates = []map[string]rel.Mutate{
+			{
+				"name": rel.Set("name", "foo"),
+				"age":  rel.Set("age", 10),
+			},
+			{
+				"name": rel.Set("name", "boo"),
+				"age":  rel.Set("age", 20),
+			},
+		}
+	)
+
+	tests := []struct {
+		result     string
+		args       []interface{}
+		onConflict rel.OnConflict
+		config     Config
+	}{
+		{
+			result:     "INSERT INTO `users` (`name`,`age`) VALUES (?,?),(?,?) ON CONFLICT (`name`) DO UPDATE SET `age`=EXCLUDED.`age`;",
+			args:       []interface{}{"foo", 10, "boo", 20},
+			onConflict: rel.OnConflictReplace("name"),
+			config:     Config{Placeholder: "?", EscapeChar: "`"},
+		},
+		{
+			result:     "INSERT INTO `users` (`name`,`age`) VALUES (?,?),(?,?) ON CONFLICT (`name`) DO UPDATE SET `name`=EXCLUDED.`name`,`age`=`users`.`age`+?;",
+			args:       []interface{}{"foo", 10, "boo", 20, 1},
+			onConflict: rel.OnConflictKeys("name").DoReplace("name").DoUpdate(rel.Inc("age")),
+			config:     Config{Placeholder: "?", EscapeChar: "`"},
+		},
+		{
+			result:     "INSERT IGNORE INTO `users` (`name`,`age`) VALUES (?,?),(?,?);",
+			args:       []interface{}{"foo", 10, "boo", 20},
+			onConflict: rel.OnConflictIgnore(),
+			config:     Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true},
+		},
+		{
+			result:     "INSERT INTO `users` (`name`,`age`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `age`=VALUES(`age`);",
+			args:       []interface{}{"foo", 10, "boo", 20},
+			onConflict: rel.OnConflictReplace("name"),
+			config:     Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true},
+		},
+		{
+			result:     "INSERT INTO `users` (`name`,`age`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `age`=`users`.`age`+?;",
+			args:       []interface{}{"foo", 10, "boo", 20, 1},
+			onConflict: rel.OnConflictKeys("name").DoUpdate(rel.Inc("age")),
+			config:     Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				builder         = NewBuilder(test.config)
+				statement, args = builder.OnConflict(test.onConflict).InsertAll("users", []string{"name", "age"}, bulkMutates)
+			)
+
+			assert.Equal(t, test.result, statement)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
 func TestBuilder_Update(t *testing.T) {
 	var (
 		config = Config{
@@ -699,6 +1034,29 @@ func TestBuilder_Update_ordinal(t *testing.T) {
 	assert.ElementsMatch(t, []interface{}{"foo", 10, true, 1}, args)
 }
 
+func TestBuilder_Update_returning(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder: "$",
+			EscapeChar:  "\"",
+			Ordinal:     true,
+		}
+		builder = NewBuilder(config)
+		mutates = map[string]rel.Mutate{
+			"name": rel.Set("name", "foo"),
+		}
+	)
+
+	qs, args := builder.Returning("*").Update("users", mutates, where.Eq("id", 1))
+	assert.Equal(t, `UPDATE "users" SET "name"=$1 WHERE "id"=$2 RETURNING *;`, qs)
+	assert.Equal(t, []interface{}{"foo", 1}, args)
+
+	builder.count = 0
+	qs, args = builder.Returning("id", "name").Update("users", mutates, where.Eq("id", 1))
+	assert.Equal(t, `UPDATE "users" SET "name"=$1 WHERE "id"=$2 RETURNING "id","name";`, qs)
+	assert.Equal(t, []interface{}{"foo", 1}, args)
+}
+
 func TestBuilder_Update_incDecAndFragment(t *testing.T) {
 	var (
 		config = Config{
@@ -717,6 +1075,22 @@ func TestBuilder_Update_incDecAndFragment(t *testing.T) {
 	assert.Equal(t, []interface{}{10}, qargs)
 }
 
+func TestBuilder_Update_with(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder: "$",
+			EscapeChar:  "\"",
+			Ordinal:     true,
+		}
+		builder = NewBuilder(config)
+		with    = rel.NewWith("inactive", rel.Select("id").From("users").Where(where.Lt("last_login", 2020)))
+	)
+
+	qs, args := builder.With(with).Update("users", map[string]rel.Mutate{"active": rel.Set("active", false)}, where.In("id", rel.Select("id").From("inactive")))
+	assert.Equal(t, "WITH \"inactive\" AS (SELECT \"id\" FROM \"users\" WHERE \"last_login\"<$1) UPDATE \"users\" SET \"active\"=$2 WHERE \"id\" IN (SELECT \"id\" FROM \"inactive\");", qs)
+	assert.Equal(t, []interface{}{2020, false}, args)
+}
+
 func TestBuilder_Delete(t *testing.T) {
 	var (
 		config = Config{
@@ -735,6 +1109,21 @@ func TestBuilder_Delete(t *testing.T) {
 	assert.Equal(t, []interface{}{1}, args)
 }
 
+func TestBuilder_Delete_with(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder: "?",
+			EscapeChar:  "`",
+		}
+		builder = NewBuilder(config)
+		with    = rel.NewWith("inactive", rel.Select("id").From("users").Where(where.Lt("last_login", 2020)))
+	)
+
+	qs, args := builder.With(with).Delete("users", where.In("id", rel.Select("id").From("inactive")))
+	assert.Equal(t, "WITH `inactive` AS (SELECT `id` FROM `users` WHERE `last_login`<?) DELETE FROM `users` WHERE `id` IN (SELECT `id` FROM `inactive`);", qs)
+	assert.Equal(t, []interface{}{2020}, args)
+}
+
 func TestBuilder_Delete_ordinal(t *testing.T) {
 	var (
 		config = Config{
@@ -768,6 +1157,8 @@ func TestBuilder_Select(t *testing.T) {
 		result   string
 		distinct bool
 		fields   []string
+		exprs    []rel.Expr
+		args     []interface{}
 	}{
 		{
 			result: "SELECT *",
@@ -802,6 +1193,34 @@ func TestBuilder_Select(t *testing.T) {
 			result: "SELECT SUM(`transactions`.`total`) AS `total`",
 			fields: []string{"SUM(transactions.total) AS total"},
 		},
+		{
+			result: "SELECT `user_id`,COUNT(*) AS `count`,SUM(`amount`) AS `total`",
+			fields: []string{"user_id"},
+			exprs:  []rel.Expr{rel.Count("*").As("count"), rel.Sum("amount").As("total")},
+		},
+		{
+			result: "SELECT COUNT(DISTINCT `user_id`) AS `users`,AVG(`amount`),MAX(`amount`),MIN(`amount`)",
+			exprs:  []rel.Expr{rel.CountDistinct("user_id").As("users"), rel.Avg("amount"), rel.Max("amount"), rel.Min("amount")},
+		},
+		{
+			result: "SELECT `id`,ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `created_at` DESC) AS `row`",
+			fields: []string{"id"},
+			exprs:  []rel.Expr{rel.RowNumber().Over(rel.PartitionBy("user_id").SortDesc("created_at")).As("row")},
+		},
+		{
+			result: "SELECT RANK() OVER (ORDER BY `score` DESC, `id` ASC),DENSE_RANK() OVER (PARTITION BY `group`,`type`),ROW_NUMBER() OVER ()",
+			exprs:  []rel.Expr{rel.Rank().Over(rel.Window{}.SortDesc("score").SortAsc("id")), rel.DenseRank().Over(rel.PartitionBy("group", "type")), rel.RowNumber().Over(rel.Window{})},
+		},
+		{
+			result: "SELECT LAG(`amount`,1) OVER (ORDER BY `id` ASC) AS `previous`,LEAD(`amount`,2) OVER (ORDER BY `id` ASC) AS `next`",
+			exprs:  []rel.Expr{rel.Lag("amount", 1).Over(rel.OrderBy("id")).As("previous"), rel.Lead("amount", 2).Over(rel.OrderBy("id")).As("next")},
+		},
+		{
+			result:   "SELECT DISTINCT COALESCE(`amount`,?) AS `amount`",
+			distinct: true,
+			exprs:    []rel.Expr{rel.NewExpr("COALESCE", "amount", 0.0).As("amount")},
+			args:     []interface{}{0.0},
+		},
 	}
 
 	for _, test := range tests {
@@ -810,8 +1229,9 @@ func TestBuilder_Select(t *testing.T) {
 				buffer Buffer
 			)
 
-			builder.fields(&buffer, test.distinct, test.fields)
+			builder.fields(&buffer, rel.SelectQuery{OnlyDistinct: test.distinct, Fields: test.fields, Exprs: test.exprs})
 			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments)
 		})
 	}
 }
@@ -826,10 +1246,24 @@ func TestBuilder_From(t *testing.T) {
 		builder = NewBuilder(config)
 	)
 
-	builder.from(&buffer, "users")
+	builder.from(&buffer, rel.From("users"))
 	assert.Equal(t, " FROM `users`", buffer.String())
 }
 
+func TestBuilder_From_alias(t *testing.T) {
+	var (
+		buffer Buffer
+		config = Config{
+			Placeholder: "?",
+			EscapeChar:  "`",
+		}
+		builder = NewBuilder(config)
+	)
+
+	builder.from(&buffer, rel.From("users").As("u"))
+	assert.Equal(t, " FROM `users` AS `u`", buffer.String())
+}
+
 func TestBuilder_Join(t *testing.T) {
 	var (
 		config = Config{
@@ -880,6 +1314,38 @@ func TestBuilder_Join(t *testing.T) {
 	}
 }
 
+func TestBuilder_Join_filter(t *testing.T) {
+	var (
+		buffer  Buffer
+		builder = NewBuilder(Config{
+			Placeholder: "?",
+			EscapeChar:  "`",
+		})
+		joins = []rel.JoinQuery{
+			{
+				Mode:   "LEFT JOIN",
+				Table:  "users AS buyer",
+				From:   "buyer.id",
+				To:     "transactions.user_id",
+				Filter: where.Nil("buyer.deleted_at"),
+			},
+			{
+				Mode:   "JOIN",
+				Table:  "comments",
+				From:   "comments.owner_id",
+				To:     "transactions.id",
+				Filter: where.Eq("comments.owner_type", "transactions"),
+			},
+		}
+	)
+
+	builder.join(&buffer, "transactions", joins)
+
+	assert.Equal(t, " LEFT JOIN `users` AS `buyer` ON `buyer`.`id`=`transactions`.`user_id` AND `buyer`.`deleted_at` IS NULL"+
+		" JOIN `comments` ON `comments`.`owner_id`=`transactions`.`id` AND `comments`.`owner_type`=?", buffer.String())
+	assert.Equal(t, []interface{}{"transactions"}, buffer.Arguments)
+}
+
 func TestBuilder_Where(t *testing.T) {
 	var (
 		config = Config{
@@ -1261,6 +1727,41 @@ func TestBuilder_Filter(t *testing.T) {
 			[]interface{}{"%value1%", "%value2%"},
 			where.And(where.Like("field1", "%value1%"), where.NotLike("field2", "%value2%")),
 		},
+		{
+			"`user_id`=(SELECT `id` FROM `users` WHERE `name`=?)",
+			[]interface{}{"name"},
+			where.Eq("user_id", rel.Select("id").From("users").Where(where.Eq("name", "name"))),
+		},
+		{
+			"`user_id` IN (SELECT `id` FROM `users` WHERE `name`=?)",
+			[]interface{}{"name"},
+			where.In("user_id", rel.Select("id").From("users").Where(where.Eq("name", "name"))),
+		},
+		{
+			"`user_id` NOT IN (SELECT `id` FROM `users`)",
+			nil,
+			where.Nin("user_id", rel.Select("id").From("users")),
+		},
+		{
+			"`user_id` IN (SELECT id FROM users WHERE name=?)",
+			[]interface{}{"name"},
+			where.In("user_id", rel.Build("", rel.SQL("SELECT id FROM users WHERE name=?;", "name"))),
+		},
+		{
+			"EXISTS (SELECT * FROM `transactions` WHERE `transactions`.`user_id`=`users`.`id`)",
+			nil,
+			where.Exists(rel.From("transactions").Where(where.Fragment("`transactions`.`user_id`=`users`.`id`"))),
+		},
+		{
+			"NOT EXISTS (SELECT * FROM `transactions`)",
+			nil,
+			where.NotExists(rel.From("transactions")),
+		},
+		{
+			"NOT EXISTS (SELECT * FROM `transactions`)",
+			nil,
+			where.Not(where.Exists(rel.From("transactions"))),
+		},
 		{
 			"",
 			nil,
@@ -1283,6 +1784,117 @@ func TestBuilder_Filter(t *testing.T) {
 	}
 }
 
+func TestBuilder_Filter_json(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder:      "?",
+			EscapeChar:       "`",
+			JSONContainsFunc: JSONContains,
+			JSONExtractFunc:  JSONExtract,
+		}
+	)
+
+	tests := []struct {
+		QueryString string
+		Args        []interface{}
+		Filter      rel.FilterQuery
+	}{
+		{
+			"JSON_CONTAINS(`tags`,?)",
+			[]interface{}{rel.JSONValue("go")},
+			where.JSONContains("tags", "go"),
+		},
+		{
+			"JSON_UNQUOTE(JSON_EXTRACT(`data`,?))=?",
+			[]interface{}{"$.address.city", "Jakarta"},
+			where.JSONExtract("data", "address.city").Eq("Jakarta"),
+		},
+		{
+			"JSON_EXTRACT(`data`,?)>=?",
+			[]interface{}{"$.tags[0].score", 10},
+			where.JSONExtract("data", "tags.0.score").Gte(10),
+		},
+		{
+			"JSON_EXTRACT(`data`,?)<>?",
+			[]interface{}{`$."it's"`, true},
+			where.JSONExtract("data", "it's").Ne(true),
+		},
+		{
+			"JSON_EXTRACT(`data`,?)=?",
+			[]interface{}{`$."a\"b')--"`, 1},
+			where.JSONExtract("data", `a"b')--`).Eq(1),
+		},
+		{
+			"(JSON_CONTAINS(`tags`,?) AND JSON_EXTRACT(`data`,?)<?)",
+			[]interface{}{rel.JSONValue([]int{1}), "$.score", 10},
+			where.JSONContains("tags", []int{1}).And(where.JSONExtract("data", "score").Lt(10)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.QueryString, func(t *testing.T) {
+			var (
+				buffer  Buffer
+				builder = NewBuilder(config)
+			)
+
+			builder.filter(&buffer, test.Filter)
+
+			assert.Equal(t, test.QueryString, buffer.String())
+			assert.Equal(t, test.Args, buffer.Arguments)
+		})
+	}
+}
+
+func TestBuilder_Filter_jsonPathOrdinal(t *testing.T) {
+	var (
+		buffer  Buffer
+		builder = NewBuilder(Config{
+			Placeholder: "$",
+			EscapeChar:  "\"",
+			Ordinal:     true,
+			JSONExtractFunc: func(field string, placeholder string, value interface{}) string {
+				return "(" + field + "#>>" + placeholder + "::text[])"
+			},
+			JSONPathFunc: func(path []string) interface{} {
+				return path
+			},
+		})
+	)
+
+	builder.filter(&buffer, where.JSONExtract("data", "address.city").Eq("Jakarta"))
+
+	assert.Equal(t, "(\"data\"#>>$1::text[])=$2", buffer.String())
+	assert.Equal(t, []interface{}{[]string{"address", "city"}, "Jakarta"}, buffer.Arguments)
+}
+
+func TestBuilder_Filter_jsonNotSupported(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder: "?",
+			EscapeChar:  "`",
+		}
+	)
+
+	tests := []rel.FilterQuery{
+		where.JSONContains("tags", "go"),
+		where.JSONExtract("data", "score").Lt(10),
+		where.Eq("id", 1).And(where.JSONContains("tags", "go")),
+	}
+
+	for _, filter := range tests {
+		t.Run(filter.Field, func(t *testing.T) {
+			var (
+				buffer  Buffer
+				builder = NewBuilder(config)
+			)
+
+			assert.NotPanics(t, func() { builder.filter(&buffer, filter) })
+			assert.Equal(t, ErrUnsupportedJSONFilter, builder.Err())
+		})
+	}
+}
+
 func TestBuilder_Filter_ordinal(t *testing.T) {
 	var (
 		config = Config{
@@ -1,6 +1,11 @@
 package sql
 
 import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-rel/rel"
@@ -12,11 +17,19 @@ type Config struct {
 	Ordinal             bool
 	InsertDefaultValues bool
 	DropIndexOnTable    bool
+	OnDuplicateKey      bool
+	ModifyColumn        bool
+	DropKeyByType       bool
 	EscapeChar          string
 	ErrorFunc           func(error) error
 	IncrementFunc       func(Adapter) int
 	IndexToSQL          func(config Config, buffer *Buffer, index rel.Index) bool
 	MapColumnFunc       func(column *rel.Column) (string, int, int)
+	JSONContainsFunc    func(field string, placeholder string) string
+	JSONExtractFunc     func(field string, placeholder string, value interface{}) string
+	JSONPathFunc        func(path []string) interface{}
+	ArgumentFunc        func(arg interface{}) interface{}
+	AlterTableFunc      func(ctx context.Context, adapter *Adapter, table rel.Table) error
 }
 
 // MapColumn func.
@@ -30,8 +43,15 @@ func MapColumn(column *rel.Column) (string, int, int) {
 	switch column.Type {
 	case rel.ID:
 		typ = "INT UNSIGNED AUTO_INCREMENT PRIMARY KEY"
+	case rel.BigID:
+		typ = "BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY"
+	case rel.Serial:
+		typ = "SERIAL"
 	case rel.Bool:
 		typ = "BOOL"
+	case rel.SmallInt:
+		typ = "SMALLINT"
+		m = column.Limit
 	case rel.Int:
 		typ = "INT"
 		m = column.Limit
@@ -64,6 +84,22 @@ func MapColumn(column *rel.Column) (string, int, int) {
 		timeLayout = "15:04:05"
 	case rel.Timestamp:
 		typ = "TIMESTAMP"
+	case rel.JSON, rel.Array:
+		typ = "JSON"
+	case rel.UUID:
+		typ = "CHAR"
+		m = 36
+	case rel.Binary:
+		typ = "BLOB"
+		if column.Limit != 0 {
+			typ = "VARBINARY"
+			m = column.Limit
+		}
+	case rel.Enum:
+		typ = "ENUM(" + EnumValues(column.Values) + ")"
+	case rel.Inet:
+		typ = "VARCHAR"
+		m = 45
 	default:
 		typ = string(column.Type)
 	}
@@ -74,3 +110,85 @@ func MapColumn(column *rel.Column) (string, int, int) {
 
 	return typ, m, n
 }
+
+// EnumValues returns comma separated quoted values of enum column.
+func EnumValues(values []string) string {
+	var (
+		quoted = make([]string, len(values))
+	)
+
+	for i := range values {
+		quoted[i] = "'" + strings.ReplaceAll(values[i], "'", "''") + "'"
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// EnumCheck returns check constraint of enum column, it's used by database without enum type.
+func EnumCheck(field string, values []string) string {
+	return "CHECK (" + field + " IN (" + EnumValues(values) + "))"
+}
+
+// JSONContains func.
+func JSONContains(field string, placeholder string) string {
+	return "JSON_CONTAINS(" + field + "," + placeholder + ")"
+}
+
+// JSONExtract func.
+// Path is bound to the placeholder, and string value is compared with unquoted json value.
+func JSONExtract(field string, placeholder string, value interface{}) string {
+	expr := "JSON_EXTRACT(" + field + "," + placeholder + ")"
+
+	if _, ok := value.(string); ok {
+		expr = "JSON_UNQUOTE(" + expr + ")"
+	}
+
+	return expr
+}
+
+// JSONPath returns json path expression of path segments, for example $.tags[0].name.
+// Key that isn't a plain identifier is quoted.
+func JSONPath(path []string) string {
+	var (
+		buffer strings.Builder
+	)
+
+	buffer.WriteByte('$')
+
+	for _, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil {
+			buffer.WriteString("[" + segment + "]")
+		} else if isIdentifier(segment) {
+			buffer.WriteString("." + segment)
+		} else {
+			buffer.WriteString(".\"" + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(segment) + "\"")
+		}
+	}
+
+	return buffer.String()
+}
+
+func isIdentifier(s string) bool {
+	for i, c := range s {
+		if c != '_' && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// JSONArray converts slice argument into json array, it's used as ArgumentFunc of database without native array column.
+func JSONArray(arg interface{}) interface{} {
+	switch arg.(type) {
+	case []string, []int64, []float64, []bool:
+		if reflect.ValueOf(arg).IsNil() {
+			return nil
+		}
+
+		data, _ := json.Marshal(arg)
+		return string(data)
+	}
+
+	return arg
+}
@@ -2,6 +2,8 @@ package sql
 
 import (
 	"database/sql"
+
+	"github.com/go-rel/rel"
 )
 
 // Cursor used for retrieving result.
@@ -14,6 +16,21 @@ func (c *Cursor) Fields() ([]string, error) {
 	return c.Columns()
 }
 
+// FieldTypes returns the type of fields in the result.
+func (c *Cursor) FieldTypes() ([]rel.FieldType, error) {
+	columnTypes, err := c.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]rel.FieldType, len(columnTypes))
+	for i := range columnTypes {
+		types[i] = columnTypes[i]
+	}
+
+	return types, nil
+}
+
 // NopScanner for this adapter.
 func (c *Cursor) NopScanner() interface{} {
 	return &sql.RawBytes{}
@@ -10,3 +10,25 @@ import (
 func TestCursor_NopScanner(t *testing.T) {
 	assert.Equal(t, &sql.RawBytes{}, (&Cursor{}).NopScanner())
 }
+
+func TestCursor_FieldTypes(t *testing.T) {
+	var (
+		adapter = open(t)
+	)
+
+	defer adapter.Close()
+
+	rows, err := adapter.DB.Query("SELECT CAST(1 AS INTEGER) AS a, CAST('b' AS TEXT) AS b;")
+	assert.Nil(t, err)
+
+	cur := &Cursor{Rows: rows}
+	defer cur.Close()
+
+	types, err := cur.FieldTypes()
+	assert.Nil(t, err)
+	assert.Len(t, types, 2)
+
+	assert.Nil(t, cur.Close())
+	_, err = cur.FieldTypes()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,247 @@
+package sql
+
+import (
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-rel/rel"
+)
+
+// ParseColumnType maps sql type reported by database, such as VARCHAR(255), int(10) unsigned or numeric(6,2), into column type and its size.
+// Unknown type is kept as is, so it's mapped back to the same sql type.
+func ParseColumnType(column *rel.Column, typ string) {
+	var (
+		base  = strings.ToLower(strings.TrimSpace(typ))
+		sizes []int
+	)
+
+	if strings.HasSuffix(base, "[]") {
+		var element rel.Column
+		ParseColumnType(&element, typ[:len(typ)-2])
+
+		column.Type = rel.Array
+		column.Element = element.Type
+		return
+	}
+
+	if strings.HasPrefix(base, "unsigned ") {
+		column.Unsigned = true
+		base = strings.TrimPrefix(base, "unsigned ")
+	}
+
+	if strings.HasSuffix(base, " unsigned") {
+		column.Unsigned = true
+		base = strings.TrimSuffix(base, " unsigned")
+	}
+
+	if start, end := strings.IndexByte(base, '('), strings.LastIndexByte(base, ')'); start >= 0 && end > start {
+		if strings.HasPrefix(base, "enum(") {
+			column.Type = rel.Enum
+			column.Values = parseEnumValues(strings.TrimSpace(typ)[start+1 : end])
+			return
+		}
+
+		for _, size := range strings.Split(base[start+1:end], ",") {
+			n, _ := strconv.Atoi(strings.TrimSpace(size))
+			sizes = append(sizes, n)
+		}
+
+		base = strings.TrimSpace(base[:start] + base[end+1:])
+	}
+
+	sizes = append(sizes, 0, 0)
+
+	switch base {
+	case "bool", "boolean":
+		column.Type = rel.Bool
+	case "tinyint":
+		if sizes[0] == 1 {
+			column.Type = rel.Bool
+		} else {
+			column.Type = rel.Int
+		}
+	case "smallint", "int2":
+		column.Type = rel.SmallInt
+	case "int", "integer", "int4", "mediumint":
+		column.Type = rel.Int
+		column.Limit = sizes[0]
+	case "bigint", "int8":
+		column.Type = rel.BigInt
+		column.Limit = sizes[0]
+	case "serial", "bigserial":
+		column.Type = rel.Serial
+	case "float", "double", "real", "float4", "float8", "double precision":
+		column.Type = rel.Float
+		column.Precision = sizes[0]
+	case "decimal", "numeric":
+		column.Type = rel.Decimal
+		column.Precision = sizes[0]
+		column.Scale = sizes[1]
+	case "varchar", "character varying", "char", "character", "nvarchar":
+		column.Type = rel.String
+		column.Limit = sizes[0]
+	case "text", "tinytext", "mediumtext", "longtext", "clob":
+		column.Type = rel.Text
+		column.Limit = sizes[0]
+	case "date":
+		column.Type = rel.Date
+	case "datetime", "timestamptz", "timestamp with time zone":
+		column.Type = rel.DateTime
+	case "time", "time without time zone":
+		column.Type = rel.Time
+	case "timestamp", "timestamp without time zone":
+		column.Type = rel.Timestamp
+	case "json", "jsonb":
+		column.Type = rel.JSON
+	case "uuid":
+		column.Type = rel.UUID
+	case "blob", "tinyblob", "mediumblob", "longblob", "bytea", "binary":
+		column.Type = rel.Binary
+	case "varbinary":
+		column.Type = rel.Binary
+		column.Limit = sizes[0]
+	case "inet":
+		column.Type = rel.Inet
+	default:
+		column.Type = rel.ColumnType(strings.ToUpper(strings.TrimSpace(typ)))
+	}
+}
+
+func parseEnumValues(s string) []string {
+	var (
+		values []string
+		value  strings.Builder
+		quoted bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'' && quoted && i+1 < len(s) && s[i+1] == '\'':
+			value.WriteByte(c)
+			i++
+		case c == '\'':
+			if quoted {
+				values = append(values, value.String())
+				value.Reset()
+			}
+			quoted = !quoted
+		case quoted:
+			value.WriteByte(c)
+		}
+	}
+
+	return values
+}
+
+// ParseDefault returns string literal of default value reported by database without its quote and type cast,
+// other default expression is returned as is, and NULL default is returned as nil.
+func ParseDefault(value string) interface{} {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "'") {
+		if end := strings.LastIndex(value, "'"); end > 0 {
+			return strings.ReplaceAll(value[1:end], "''", "'")
+		}
+	}
+
+	if i := strings.Index(value, "::"); i > 0 {
+		value = value[:i]
+	}
+
+	if value == "" || strings.EqualFold(value, "NULL") {
+		return nil
+	}
+
+	return value
+}
+
+// ReferentialAction returns action of foreign key reported by database, NO ACTION is the default and returned as empty string.
+func ReferentialAction(action string) string {
+	if strings.EqualFold(action, "NO ACTION") {
+		return ""
+	}
+
+	return strings.ToUpper(action)
+}
+
+// ScanKeys scans constraint columns grouped by constraint and ordered by column position into keys.
+// Each row contains constraint name, constraint type, column, referenced table, referenced column, update rule and delete rule,
+// returned keys are ordered by primary, unique then foreign keys.
+func ScanKeys(rows *sql.Rows) ([]rel.Key, error) {
+	defer rows.Close()
+
+	var keys []rel.Key
+	for rows.Next() {
+		var (
+			name, typ, column   string
+			refTable, refColumn sql.NullString
+			onUpdate, onDelete  sql.NullString
+		)
+
+		if err := rows.Scan(&name, &typ, &column, &refTable, &refColumn, &onUpdate, &onDelete); err != nil {
+			return nil, err
+		}
+
+		if len(keys) == 0 || keys[len(keys)-1].Name != name {
+			key := rel.Key{Name: name, Type: rel.KeyType(typ)}
+			if key.Type == rel.ForeignKey {
+				key.Reference = rel.ForeignKeyReference{
+					Table:    refTable.String,
+					OnUpdate: ReferentialAction(onUpdate.String),
+					OnDelete: ReferentialAction(onDelete.String),
+				}
+			}
+
+			keys = append(keys, key)
+		}
+
+		key := &keys[len(keys)-1]
+		key.Columns = append(key.Columns, column)
+		if key.Type == rel.ForeignKey {
+			key.Reference.Columns = append(key.Reference.Columns, refColumn.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rank := map[rel.KeyType]int{rel.PrimaryKey: 0, rel.UniqueKey: 1, rel.ForeignKey: 2}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return rank[keys[i].Type] < rank[keys[j].Type]
+	})
+
+	return keys, nil
+}
+
+// ScanIndexes scans index columns grouped by index and ordered by column position into indexes of a table.
+// Each row contains index name, whether it's unique and column, column of expression index is NULL and skipped.
+func ScanIndexes(table string, rows *sql.Rows) ([]rel.Index, error) {
+	defer rows.Close()
+
+	var indexes []rel.Index
+	for rows.Next() {
+		var (
+			name   string
+			unique bool
+			column sql.NullString
+		)
+
+		if err := rows.Scan(&name, &unique, &column); err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, rel.Index{Table: table, Name: name, Unique: unique})
+		}
+
+		if column.Valid {
+			index := &indexes[len(indexes)-1]
+			index.Columns = append(index.Columns, column.String)
+		}
+	}
+
+	return indexes, rows.Err()
+}
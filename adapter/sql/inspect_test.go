@@ -0,0 +1,134 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColumnType(t *testing.T) {
+	tests := []struct {
+		typ    string
+		column rel.Column
+	}{
+		{typ: "BOOL", column: rel.Column{Type: rel.Bool}},
+		{typ: "tinyint(1)", column: rel.Column{Type: rel.Bool}},
+		{typ: "tinyint(4)", column: rel.Column{Type: rel.Int}},
+		{typ: "smallint", column: rel.Column{Type: rel.SmallInt}},
+		{typ: "int(10) unsigned", column: rel.Column{Type: rel.Int, Unsigned: true, Limit: 10}},
+		{typ: "UNSIGNED INTEGER", column: rel.Column{Type: rel.Int, Unsigned: true}},
+		{typ: "bigint", column: rel.Column{Type: rel.BigInt}},
+		{typ: "double precision", column: rel.Column{Type: rel.Float}},
+		{typ: "numeric(6,2)", column: rel.Column{Type: rel.Decimal, Precision: 6, Scale: 2}},
+		{typ: "character varying(30)", column: rel.Column{Type: rel.String, Limit: 30}},
+		{typ: "longtext", column: rel.Column{Type: rel.Text}},
+		{typ: "date", column: rel.Column{Type: rel.Date}},
+		{typ: "DATETIME", column: rel.Column{Type: rel.DateTime}},
+		{typ: "timestamp with time zone", column: rel.Column{Type: rel.DateTime}},
+		{typ: "time without time zone", column: rel.Column{Type: rel.Time}},
+		{typ: "timestamp", column: rel.Column{Type: rel.Timestamp}},
+		{typ: "jsonb", column: rel.Column{Type: rel.JSON}},
+		{typ: "uuid", column: rel.Column{Type: rel.UUID}},
+		{typ: "bytea", column: rel.Column{Type: rel.Binary}},
+		{typ: "varbinary(16)", column: rel.Column{Type: rel.Binary, Limit: 16}},
+		{typ: "inet", column: rel.Column{Type: rel.Inet}},
+		{typ: "enum('a','it''s, b')", column: rel.Column{Type: rel.Enum, Values: []string{"a", "it's, b"}}},
+		{typ: "character varying(255)[]", column: rel.Column{Type: rel.Array, Element: rel.String}},
+		{typ: "point", column: rel.Column{Type: "POINT"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typ, func(t *testing.T) {
+			var column rel.Column
+			ParseColumnType(&column, test.typ)
+			assert.Equal(t, test.column, column)
+		})
+	}
+}
+
+func TestParseDefault(t *testing.T) {
+	assert.Equal(t, "it's", ParseDefault("'it''s'"))
+	assert.Equal(t, "a::b", ParseDefault("'a::b'::character varying"))
+	assert.Equal(t, "0", ParseDefault("0"))
+	assert.Equal(t, "CURRENT_TIMESTAMP", ParseDefault("CURRENT_TIMESTAMP"))
+	assert.Nil(t, ParseDefault("NULL::character varying"))
+	assert.Nil(t, ParseDefault(""))
+}
+
+func TestReferentialAction(t *testing.T) {
+	assert.Equal(t, "", ReferentialAction("NO ACTION"))
+	assert.Equal(t, "CASCADE", ReferentialAction("cascade"))
+}
+
+func TestScanKeys(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+	)
+
+	defer adapter.Close()
+
+	rows, err := adapter.DB.QueryContext(ctx, `SELECT * FROM (
+		SELECT 'posts_user_fk' AS name, 'FOREIGN KEY' AS type, 'user_id' AS col, 'users' AS ref, 'id' AS ref_col, 'NO ACTION' AS upd, 'CASCADE' AS del
+		UNION ALL SELECT 'posts_pkey', 'PRIMARY KEY', 'a', NULL, NULL, NULL, NULL
+		UNION ALL SELECT 'posts_pkey', 'PRIMARY KEY', 'b', NULL, NULL, NULL, NULL
+		UNION ALL SELECT 'posts_code_unique', 'UNIQUE', 'code', NULL, NULL, NULL, NULL
+	);`)
+	assert.Nil(t, err)
+
+	keys, err := ScanKeys(rows)
+	assert.Nil(t, err)
+	assert.Equal(t, []rel.Key{
+		{Name: "posts_pkey", Type: rel.PrimaryKey, Columns: []string{"a", "b"}},
+		{Name: "posts_code_unique", Type: rel.UniqueKey, Columns: []string{"code"}},
+		{
+			Name:    "posts_user_fk",
+			Type:    rel.ForeignKey,
+			Columns: []string{"user_id"},
+			Reference: rel.ForeignKeyReference{
+				Table:    "users",
+				Columns:  []string{"id"},
+				OnDelete: "CASCADE",
+			},
+		},
+	}, keys)
+
+	rows, err = adapter.DB.QueryContext(ctx, "SELECT 'name';")
+	assert.Nil(t, err)
+
+	_, err = ScanKeys(rows)
+	assert.NotNil(t, err)
+}
+
+func TestScanIndexes(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+	)
+
+	defer adapter.Close()
+
+	rows, err := adapter.DB.QueryContext(ctx, `SELECT * FROM (
+		SELECT 'posts_title' AS name, 0 AS uniq, 'title' AS col
+		UNION ALL SELECT 'posts_code', 1, 'code'
+		UNION ALL SELECT 'posts_code', 1, 'locale'
+		UNION ALL SELECT 'posts_lower', 0, NULL
+	);`)
+	assert.Nil(t, err)
+
+	indexes, err := ScanIndexes("posts", rows)
+	assert.Nil(t, err)
+	assert.Equal(t, []rel.Index{
+		{Table: "posts", Name: "posts_title", Columns: []string{"title"}},
+		{Table: "posts", Name: "posts_code", Unique: true, Columns: []string{"code", "locale"}},
+		{Table: "posts", Name: "posts_lower"},
+	}, indexes)
+
+	rows, err = adapter.DB.QueryContext(ctx, "SELECT 'name';")
+	assert.Nil(t, err)
+
+	_, err = ScanIndexes("posts", rows)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,633 @@
+package sqlite3
+
+import (
+	"context"
+	db "database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/adapter/sql"
+)
+
+// tableItem is a column or constraint definition parsed from create table statement.
+// Definition that's changed by alter table is stored as def, otherwise it's kept as raw sql.
+type tableItem struct {
+	name   string
+	column bool
+	raw    string
+	def    rel.TableDefinition
+}
+
+// alterTableFunc applies alter table using native statement when possible.
+// sqlite only supports adding and renaming column, other operations are applied by rebuilding the table:
+// a new table is created using modified definition, the records are copied, then the old table is replaced.
+func alterTableFunc(ctx context.Context, adapter *sql.Adapter, table rel.Table) error {
+	var (
+		builder = sql.NewBuilder(adapter.Config)
+		pending []rel.TableDefinition
+	)
+
+	for _, def := range table.Definitions {
+		if !nativeAlter(def) {
+			pending = append(pending, def)
+			continue
+		}
+
+		if len(pending) > 0 {
+			if err := rebuildTable(ctx, adapter, table.Name, pending); err != nil {
+				return err
+			}
+
+			pending = nil
+		}
+
+		statement := builder.Table(rel.Table{
+			Op:          rel.SchemaAlter,
+			Name:        table.Name,
+			Definitions: []rel.TableDefinition{def},
+			Options:     table.Options,
+		})
+
+		if _, _, err := adapter.Exec(ctx, statement, nil); err != nil {
+			return err
+		}
+	}
+
+	if len(pending) > 0 {
+		return rebuildTable(ctx, adapter, table.Name, pending)
+	}
+
+	return nil
+}
+
+func nativeAlter(def rel.TableDefinition) bool {
+	column, ok := def.(rel.Column)
+	return ok && (column.Op == rel.SchemaCreate || column.Op == rel.SchemaRename)
+}
+
+// rebuildTable rebuilds the table following sqlite's documented procedure:
+// foreign keys are disabled before the transaction begins, so dropping the old table doesn't trigger ON DELETE actions,
+// then foreign keys are verified before commit and the setting is restored afterwards.
+// foreign keys can't be disabled inside a transaction, in that case the table can't be rebuilt when it's referenced using ON DELETE action,
+// unless the transaction is a migration transaction which is started with foreign keys disabled.
+func rebuildTable(ctx context.Context, adapter *sql.Adapter, table string, definitions []rel.TableDefinition) (err error) {
+	if adapter.Tx != nil {
+		if err := checkReferences(ctx, adapter, table); err != nil {
+			return err
+		}
+
+		txAdapter, err := adapter.Begin(ctx)
+		if err != nil {
+			return err
+		}
+
+		return rebuildTx(ctx, txAdapter.(*sql.Adapter), table, definitions)
+	}
+
+	conn, err := adapter.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	var foreignKeys bool
+	if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys;").Scan(&foreignKeys); err != nil {
+		return err
+	}
+
+	if foreignKeys {
+		if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF;"); err != nil {
+			return err
+		}
+
+		// restored using background context, so the connection isn't returned to the pool with foreign keys disabled when ctx is canceled.
+		defer func() {
+			if _, restoreErr := conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON;"); err == nil {
+				err = restoreErr
+			}
+		}()
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return rebuildTx(ctx, &sql.Adapter{Instrumenter: adapter.Instrumenter, Config: adapter.Config, Tx: tx}, table, definitions)
+}
+
+func rebuildTx(ctx context.Context, tx *sql.Adapter, table string, definitions []rel.TableDefinition) error {
+	err := rebuild(ctx, tx, table, definitions)
+	if err == nil {
+		err = checkForeignKeys(ctx, tx)
+	}
+
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// checkReferences returns error when foreign keys is enabled and the table is referenced using ON DELETE action,
+// dropping the table would otherwise apply the action to referencing records.
+func checkReferences(ctx context.Context, adapter *sql.Adapter, table string) error {
+	var foreignKeys bool
+	if err := queryRow(ctx, adapter, "PRAGMA foreign_keys;").Scan(&foreignKeys); err != nil || !foreignKeys {
+		return err
+	}
+
+	var child string
+	err := queryRow(ctx, adapter, "SELECT m.name FROM sqlite_master m JOIN pragma_foreign_key_list(m.name) f WHERE m.type = 'table' AND f.\"table\" = ? COLLATE NOCASE AND f.on_delete IN ('CASCADE', 'SET NULL', 'SET DEFAULT') LIMIT 1;", table).Scan(&child)
+	if err == db.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return errors.New("rel: unable to rebuild table " + table + " inside transaction, it's referenced by " + child + " using ON DELETE action")
+}
+
+// checkForeignKeys returns error when the rebuilt table breaks any foreign key constraint.
+func checkForeignKeys(ctx context.Context, adapter *sql.Adapter) error {
+	rows, err := query(ctx, adapter, "PRAGMA foreign_key_check;")
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	if rows.Next() {
+		var (
+			table  string
+			rowid  db.NullInt64
+			parent string
+			fkid   int
+		)
+
+		if err := rows.Scan(&table, &rowid, &parent, &fkid); err != nil {
+			return err
+		}
+
+		return errors.New("rel: foreign key constraint failed on table " + table + " referencing " + parent)
+	}
+
+	return rows.Err()
+}
+
+func rebuild(ctx context.Context, adapter *sql.Adapter, table string, definitions []rel.TableDefinition) error {
+	var (
+		statement string
+		dropped   []string
+		newTable  = "new_" + table
+		config    = adapter.Config
+	)
+
+	if err := queryRow(ctx, adapter, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?;", table).Scan(&statement); err != nil {
+		return err
+	}
+
+	items, err := parseTable(statement)
+	if err != nil {
+		return err
+	}
+
+	for _, def := range definitions {
+		switch v := def.(type) {
+		case rel.Column:
+			if v.Op == rel.SchemaDrop {
+				dropped = append(dropped, v.Name)
+			}
+
+			items, err = alterColumn(items, v)
+		case rel.Key:
+			items, err = alterKey(items, v)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		columns    []string
+		newColumns = rel.Table{Op: rel.SchemaCreate, Name: newTable}
+	)
+
+	for _, item := range items {
+		if item.column {
+			columns = append(columns, sql.Escape(config, item.name))
+		}
+
+		if item.def != nil {
+			newColumns.Definitions = append(newColumns.Definitions, item.def)
+		} else {
+			newColumns.Definitions = append(newColumns.Definitions, rel.Raw(item.raw))
+		}
+	}
+
+	schemas, err := dependentSchemas(ctx, adapter, table, dropped)
+	if err != nil {
+		return err
+	}
+
+	var (
+		fields     = strings.Join(columns, ", ")
+		statements = append([]string{
+			"PRAGMA defer_foreign_keys = ON;",
+			sql.NewBuilder(config).Table(newColumns),
+			"INSERT INTO " + sql.Escape(config, newTable) + " (" + fields + ") SELECT " + fields + " FROM " + sql.Escape(config, table) + ";",
+			"DROP TABLE " + sql.Escape(config, table) + ";",
+			"ALTER TABLE " + sql.Escape(config, newTable) + " RENAME TO " + sql.Escape(config, table) + ";",
+		}, schemas...)
+	)
+
+	for _, statement := range statements {
+		if _, _, err := adapter.Exec(ctx, statement, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func alterColumn(items []tableItem, column rel.Column) ([]tableItem, error) {
+	i := findItem(items, column.Name, true)
+	if i < 0 {
+		return items, errors.New("rel: column " + column.Name + " not found")
+	}
+
+	if column.Op == rel.SchemaDrop {
+		return append(items[:i], items[i+1:]...), nil
+	}
+
+	if column.Alter == rel.AlterColumnType {
+		column.Op = rel.SchemaCreate
+		items[i].def = column
+		return items, nil
+	}
+
+	// column that's already changed in the same rebuild.
+	if def, ok := items[i].def.(rel.Column); ok {
+		switch column.Alter {
+		case rel.AlterColumnNull:
+			def.Required = false
+		case rel.AlterColumnNotNull:
+			def.Required = true
+		case rel.AlterColumnDefault:
+			def.Default = column.Default
+		case rel.AlterColumnDropDefault:
+			def.Default = nil
+		}
+
+		items[i].def = def
+		return items, nil
+	}
+
+	var (
+		tokens = tokenize(items[i].raw)
+	)
+
+	switch column.Alter {
+	case rel.AlterColumnNull:
+		tokens = removeNotNull(tokens)
+	case rel.AlterColumnNotNull:
+		tokens = append(removeNotNull(tokens), "NOT", "NULL")
+	case rel.AlterColumnDefault:
+		tokens = append(removeDefault(tokens), "DEFAULT", defaultValue(column.Default))
+	case rel.AlterColumnDropDefault:
+		tokens = removeDefault(tokens)
+	}
+
+	items[i].raw = strings.Join(tokens, " ")
+	return items, nil
+}
+
+func alterKey(items []tableItem, key rel.Key) ([]tableItem, error) {
+	if key.Op != rel.SchemaDrop {
+		return append(items, tableItem{name: key.Name, def: key}), nil
+	}
+
+	i := findItem(items, key.Name, false)
+	if key.Name == "" || i < 0 {
+		return items, errors.New("rel: constraint " + key.Name + " not found")
+	}
+
+	return append(items[:i], items[i+1:]...), nil
+}
+
+func findItem(items []tableItem, name string, column bool) int {
+	for i := range items {
+		if items[i].column == column && strings.EqualFold(items[i].name, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// dependentSchemas returns statements to recreate indexes and triggers of the table.
+// index that uses dropped column is not recreated.
+func dependentSchemas(ctx context.Context, adapter *sql.Adapter, table string, dropped []string) ([]string, error) {
+	rows, err := query(ctx, adapter, "SELECT type, name, sql FROM sqlite_master WHERE type IN ('index', 'trigger') AND tbl_name = ? AND sql IS NOT NULL;", table)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		schemas []string
+		indexes = make(map[int]string)
+	)
+
+	for rows.Next() {
+		var typ, name, statement string
+		if err := rows.Scan(&typ, &name, &statement); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		if typ == "index" {
+			indexes[len(schemas)] = name
+		}
+
+		schemas = append(schemas, statement)
+	}
+
+	if err := rows.Close(); err != nil || len(dropped) == 0 {
+		return schemas, err
+	}
+
+	var (
+		result []string
+	)
+
+	for i := range schemas {
+		if name, ok := indexes[i]; ok {
+			uses, err := indexUses(ctx, adapter, name, dropped)
+			if err != nil {
+				return nil, err
+			}
+
+			if uses {
+				continue
+			}
+		}
+
+		result = append(result, schemas[i])
+	}
+
+	return result, nil
+}
+
+// indexUses returns true if index contains any of the columns.
+func indexUses(ctx context.Context, adapter *sql.Adapter, index string, columns []string) (bool, error) {
+	rows, err := query(ctx, adapter, "SELECT name FROM pragma_index_info(?);", index)
+	if err != nil {
+		return false, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name db.NullString
+		if err := rows.Scan(&name); err != nil {
+			return false, err
+		}
+
+		for i := range columns {
+			if strings.EqualFold(columns[i], name.String) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, rows.Err()
+}
+
+// parseTable splits column and constraint definitions of create table statement.
+func parseTable(statement string) ([]tableItem, error) {
+	var (
+		start = strings.IndexByte(statement, '(')
+		end   = strings.LastIndexByte(statement, ')')
+	)
+
+	if start < 0 || end < start {
+		return nil, errors.New("rel: unable to parse table definition: " + statement)
+	}
+
+	var (
+		body  = statement[start+1 : end]
+		items []tableItem
+	)
+
+	for _, raw := range splitDefinitions(body) {
+		var (
+			tokens = tokenize(raw)
+			item   = tableItem{raw: raw}
+		)
+
+		if len(tokens) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(tokens[0]) {
+		case "CONSTRAINT":
+			if len(tokens) > 1 {
+				item.name = unquote(tokens[1])
+			}
+		case "PRIMARY", "UNIQUE", "CHECK", "FOREIGN":
+		default:
+			item.name = unquote(tokens[0])
+			item.column = true
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// splitDefinitions splits definitions by comma that's not enclosed by parentheses or quotes.
+func splitDefinitions(body string) []string {
+	var (
+		result []string
+		start  int
+	)
+
+	for i := 0; i < len(body); {
+		switch c := body[i]; {
+		case c == ',':
+			result = append(result, strings.TrimSpace(body[start:i]))
+			start = i + 1
+			i++
+		case c == '(':
+			i = closingParen(body, i)
+		case isQuote(c):
+			i = closingQuote(body, i)
+		default:
+			i++
+		}
+	}
+
+	return append(result, strings.TrimSpace(body[start:]))
+}
+
+// tokenize splits definition into words, quoted string, quoted identifier and parenthesized expression are kept as a single token.
+func tokenize(s string) []string {
+	var (
+		tokens []string
+	)
+
+	for i := 0; i < len(s); {
+		var (
+			c   = s[i]
+			end = i + 1
+		)
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '(':
+			end = closingParen(s, i)
+		case isQuote(c):
+			end = closingQuote(s, i)
+		case isWord(c):
+			for end < len(s) && isWord(s[end]) {
+				end++
+			}
+		}
+
+		tokens = append(tokens, s[i:end])
+		i = end
+	}
+
+	return tokens
+}
+
+func removeNotNull(tokens []string) []string {
+	for i := 0; i+1 < len(tokens); i++ {
+		if strings.EqualFold(tokens[i], "NOT") && strings.EqualFold(tokens[i+1], "NULL") {
+			return append(tokens[:i], tokens[i+2:]...)
+		}
+	}
+
+	return tokens
+}
+
+func removeDefault(tokens []string) []string {
+	for i := 0; i+1 < len(tokens); i++ {
+		if !strings.EqualFold(tokens[i], "DEFAULT") {
+			continue
+		}
+
+		end := i + 2
+		if (tokens[i+1] == "-" || tokens[i+1] == "+") && end < len(tokens) {
+			end++
+		}
+
+		return append(tokens[:i], tokens[end:]...)
+	}
+
+	return tokens
+}
+
+func defaultValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case time.Time:
+		return "'" + v.Format("2006-01-02 15:04:05") + "'"
+	default:
+		bytes, _ := json.Marshal(value)
+		return string(bytes)
+	}
+}
+
+// closingParen returns index after the parenthesis that closes the one at start.
+func closingParen(s string, start int) int {
+	depth := 0
+
+	for i := start; i < len(s); {
+		switch c := s[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case isQuote(c):
+			i = closingQuote(s, i)
+			continue
+		}
+
+		i++
+	}
+
+	return len(s)
+}
+
+// closingQuote returns index after the quote that closes the one at start, doubled quote is treated as escaped quote.
+func closingQuote(s string, start int) int {
+	var (
+		quote = s[start]
+	)
+
+	if quote == '[' {
+		quote = ']'
+	}
+
+	for i := start + 1; i < len(s); i++ {
+		if s[i] != quote {
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == quote && quote != ']' {
+			i++
+			continue
+		}
+
+		return i + 1
+	}
+
+	return len(s)
+}
+
+func unquote(s string) string {
+	if len(s) < 2 || !isQuote(s[0]) {
+		return s
+	}
+
+	quote := s[len(s)-1]
+	return strings.ReplaceAll(s[1:len(s)-1], string([]byte{quote, quote}), string(quote))
+}
+
+func isQuote(c byte) bool {
+	return c == '\'' || c == '"' || c == '`' || c == '['
+}
+
+func isWord(c byte) bool {
+	return c == '_' || c == '.' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func queryRow(ctx context.Context, adapter *sql.Adapter, statement string, args ...interface{}) *db.Row {
+	if adapter.Tx != nil {
+		return adapter.Tx.QueryRowContext(ctx, statement, args...)
+	}
+
+	return adapter.DB.QueryRowContext(ctx, statement, args...)
+}
+
+func query(ctx context.Context, adapter *sql.Adapter, statement string, args ...interface{}) (*db.Rows, error) {
+	if adapter.Tx != nil {
+		return adapter.Tx.QueryContext(ctx, statement, args...)
+	}
+
+	return adapter.DB.QueryContext(ctx, statement, args...)
+}
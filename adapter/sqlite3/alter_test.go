@@ -0,0 +1,231 @@
+package sqlite3
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+type Post struct {
+	ID    int
+	Title string
+	Score int
+}
+
+func TestParseTable(t *testing.T) {
+	items, err := parseTable("CREATE TABLE `posts` (`id` INTEGER PRIMARY KEY, \"title\" VARCHAR(255) NOT NULL DEFAULT 'a, b', [score] DECIMAL(6,2) CHECK (score IN (1, 2)), UNIQUE (`title`), CONSTRAINT `score_check` CHECK (score >= 0))")
+	assert.Nil(t, err)
+	assert.Equal(t, []tableItem{
+		{name: "id", column: true, raw: "`id` INTEGER PRIMARY KEY"},
+		{name: "title", column: true, raw: "\"title\" VARCHAR(255) NOT NULL DEFAULT 'a, b'"},
+		{name: "score", column: true, raw: "[score] DECIMAL(6,2) CHECK (score IN (1, 2))"},
+		{raw: "UNIQUE (`title`)"},
+		{name: "score_check", raw: "CONSTRAINT `score_check` CHECK (score >= 0)"},
+	}, items)
+
+	_, err = parseTable("CREATE TABLE posts")
+	assert.NotNil(t, err)
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"`na``me`", "VARCHAR", "(255)", "DEFAULT", "-", "1", "NOT", "NULL", "CHECK", "(`na``me` <> ')')"},
+		tokenize("`na``me` VARCHAR(255) DEFAULT -1 NOT NULL CHECK (`na``me` <> ')')"))
+}
+
+func TestAlterColumn(t *testing.T) {
+	tests := []struct {
+		name   string
+		column rel.Column
+		result string
+	}{
+		{
+			name:   "SetNull",
+			column: rel.Column{Name: "title", Op: rel.SchemaAlter, Alter: rel.AlterColumnNull},
+			result: "`title` VARCHAR (255) DEFAULT - 1",
+		},
+		{
+			name:   "SetNotNull",
+			column: rel.Column{Name: "title", Op: rel.SchemaAlter, Alter: rel.AlterColumnNotNull},
+			result: "`title` VARCHAR (255) DEFAULT - 1 NOT NULL",
+		},
+		{
+			name:   "SetDefault",
+			column: rel.Column{Name: "title", Default: "it's", Op: rel.SchemaAlter, Alter: rel.AlterColumnDefault},
+			result: "`title` VARCHAR (255) NOT NULL DEFAULT 'it''s'",
+		},
+		{
+			name:   "DropDefault",
+			column: rel.Column{Name: "title", Op: rel.SchemaAlter, Alter: rel.AlterColumnDropDefault},
+			result: "`title` VARCHAR (255) NOT NULL",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := alterColumn([]tableItem{
+				{name: "title", column: true, raw: "`title` VARCHAR(255) DEFAULT -1 NOT NULL"},
+			}, test.column)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.result, items[0].raw)
+		})
+	}
+
+	t.Run("ChangeColumn", func(t *testing.T) {
+		items, err := alterColumn([]tableItem{{name: "title", column: true, raw: "`title` TEXT"}},
+			rel.Column{Name: "title", Type: rel.String, Op: rel.SchemaAlter, Alter: rel.AlterColumnType})
+		assert.Nil(t, err)
+
+		items, err = alterColumn(items, rel.Column{Name: "title", Op: rel.SchemaAlter, Alter: rel.AlterColumnNotNull})
+		assert.Nil(t, err)
+		assert.Equal(t, rel.Column{Name: "title", Type: rel.String, Required: true}, items[0].def)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := alterColumn(nil, rel.Column{Name: "title", Op: rel.SchemaDrop})
+		assert.NotNil(t, err)
+
+		_, err = alterKey(nil, rel.Key{Name: "title_unique", Op: rel.SchemaDrop})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAdapter_Apply_alterTable(t *testing.T) {
+	adapter, err := Open("file:alter?mode=memory&cache=shared&_foreign_keys=1")
+	assert.Nil(t, err)
+	defer adapter.Close()
+
+	var (
+		repo = rel.New(adapter)
+		post Post
+	)
+
+	assert.Nil(t, adapter.Apply(ctx, rel.Table{
+		Op:   rel.SchemaCreate,
+		Name: "posts",
+		Definitions: []rel.TableDefinition{
+			rel.Column{Name: "id", Type: rel.ID},
+			rel.Column{Name: "title", Type: rel.String},
+			rel.Column{Name: "score", Type: rel.Int},
+			rel.Column{Name: "rank", Type: rel.Int},
+		},
+	}))
+	assert.Nil(t, adapter.Apply(ctx, rel.Index{Op: rel.SchemaCreate, Table: "posts", Name: "posts_title", Columns: []string{"title"}}))
+	assert.Nil(t, adapter.Apply(ctx, rel.Index{Op: rel.SchemaCreate, Table: "posts", Name: "posts_rank", Columns: []string{"rank"}}))
+	_, _, err = adapter.Exec(ctx, "INSERT INTO posts (title, score, rank) VALUES ('post', 1, 1);", nil)
+	assert.Nil(t, err)
+
+	alter := rel.Table{Op: rel.SchemaAlter, Name: "posts"}
+
+	at := rel.AlterTable{Table: alter}
+	at.DropColumn("rank")
+	at.Column("body", rel.Text)
+	at.SetNotNull("title")
+	at.SetDefault("score", 10)
+	at.Check("score_check", "score >= 0")
+
+	assert.Nil(t, adapter.Apply(ctx, at.Table))
+
+	var indexes []string
+	rows, err := adapter.DB.Query("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'posts';")
+	assert.Nil(t, err)
+	for rows.Next() {
+		var name string
+		assert.Nil(t, rows.Scan(&name))
+		indexes = append(indexes, name)
+	}
+	rows.Close()
+	assert.Equal(t, []string{"posts_title"}, indexes)
+
+	assert.Nil(t, repo.Find(ctx, &post))
+	assert.Equal(t, "post", post.Title)
+	assert.Equal(t, 1, post.Score)
+
+	_, _, err = adapter.Exec(ctx, "INSERT INTO posts (title) VALUES ('default');", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, repo.Find(ctx, &post, rel.Eq("title", "default")))
+	assert.Equal(t, 10, post.Score)
+
+	_, _, err = adapter.Exec(ctx, "INSERT INTO posts (score) VALUES (1);", nil)
+	assert.NotNil(t, err)
+
+	_, _, err = adapter.Exec(ctx, "INSERT INTO posts (title, score) VALUES ('check', -1);", nil)
+	assert.NotNil(t, err)
+
+	at = rel.AlterTable{Table: alter}
+	at.DropConstraint("score_check")
+	at.DropColumn("unknown")
+	assert.NotNil(t, adapter.Apply(ctx, at.Table))
+
+	// rolled back.
+	_, _, err = adapter.Exec(ctx, "INSERT INTO posts (title, score) VALUES ('check', -1);", nil)
+	assert.NotNil(t, err)
+}
+
+func TestAdapter_Apply_alterTableReferenced(t *testing.T) {
+	adapter, err := Open("file:alter_referenced?mode=memory&cache=shared&_foreign_keys=1")
+	assert.Nil(t, err)
+	defer adapter.Close()
+
+	for _, statement := range []string{
+		"CREATE TABLE authors (id INTEGER PRIMARY KEY, name TEXT, rank INTEGER);",
+		"CREATE TABLE books (id INTEGER PRIMARY KEY, author_id INTEGER REFERENCES authors (id) ON DELETE CASCADE);",
+		"INSERT INTO authors (id, name, rank) VALUES (1, 'author', 1);",
+		"INSERT INTO books (id, author_id) VALUES (1, 1);",
+	} {
+		_, _, err = adapter.Exec(ctx, statement, nil)
+		assert.Nil(t, err)
+	}
+
+	countBooks := func() int {
+		var count int
+		assert.Nil(t, adapter.DB.QueryRow("SELECT COUNT(*) FROM books;").Scan(&count))
+		return count
+	}
+
+	alter := rel.AlterTable{Table: rel.Table{Op: rel.SchemaAlter, Name: "authors"}}
+	alter.DropColumn("rank")
+	alter.SetNotNull("name")
+
+	assert.Nil(t, adapter.Apply(ctx, alter.Table))
+	assert.Equal(t, 1, countBooks())
+
+	// foreign keys is restored.
+	_, _, err = adapter.Exec(ctx, "INSERT INTO books (id, author_id) VALUES (2, 2);", nil)
+	assert.NotNil(t, err)
+
+	// foreign keys can't be disabled inside transaction.
+	txAdapter, err := adapter.Begin(ctx)
+	assert.Nil(t, err)
+
+	alter = rel.AlterTable{Table: rel.Table{Op: rel.SchemaAlter, Name: "authors"}}
+	alter.SetNull("name")
+
+	assert.Equal(t, "rel: unable to rebuild table authors inside transaction, it's referenced by books using ON DELETE action", txAdapter.Apply(ctx, alter.Table).Error())
+	assert.Nil(t, txAdapter.Rollback(ctx))
+	assert.Equal(t, 1, countBooks())
+
+	// migration transaction is started with foreign keys disabled.
+	txAdapter, err = adapter.Begin(rel.WithMigration(ctx))
+	assert.Nil(t, err)
+	assert.Nil(t, txAdapter.Apply(ctx, alter.Table))
+	assert.Nil(t, txAdapter.Commit(ctx))
+	assert.Equal(t, 1, countBooks())
+
+	_, _, err = adapter.Exec(ctx, "INSERT INTO books (id, author_id) VALUES (2, 2);", nil)
+	assert.NotNil(t, err)
+
+	// migration transaction isn't committed when it breaks foreign keys.
+	txAdapter, err = adapter.Begin(rel.WithMigration(ctx))
+	assert.Nil(t, err)
+	_, _, err = txAdapter.(*Adapter).Exec(ctx, "INSERT INTO books (id, author_id) VALUES (2, 2);", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "rel: foreign key constraint failed on table books referencing authors", txAdapter.Commit(ctx).Error())
+	assert.Equal(t, 1, countBooks())
+
+	// references to rebuilt table still apply.
+	_, _, err = adapter.Exec(ctx, "DELETE FROM authors;", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, countBooks())
+}
@@ -0,0 +1,249 @@
+package sqlite3
+
+import (
+	"context"
+	db "database/sql"
+	"strings"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/adapter/sql"
+)
+
+// InspectTables returns the name of user tables.
+func (a *Adapter) InspectTables(ctx context.Context) ([]string, error) {
+	rows, err := query(ctx, a.Adapter, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, name)
+	}
+
+	return tables, rows.Err()
+}
+
+// InspectTable returns the columns, primary key, unique keys and foreign keys of a table.
+// Single INTEGER PRIMARY KEY column is returned as ID column.
+// Name of key is only available when it's declared as named table constraint.
+func (a *Adapter) InspectTable(ctx context.Context, name string) (rel.Table, error) {
+	var (
+		table     = rel.Table{Name: name}
+		statement string
+	)
+
+	if err := queryRow(ctx, a.Adapter, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?;", name).Scan(&statement); err != nil {
+		if err == db.ErrNoRows {
+			return table, rel.ErrNotFound
+		}
+
+		return table, err
+	}
+
+	items, err := parseTable(statement)
+	if err != nil {
+		return table, err
+	}
+
+	if table.Definitions, err = a.inspectColumns(ctx, name); err != nil {
+		return table, err
+	}
+
+	uniques, err := a.inspectIndexes(ctx, name, "u")
+	if err != nil {
+		return table, err
+	}
+
+	for _, index := range uniques {
+		table.Definitions = append(table.Definitions, rel.Key{
+			Name:    constraintName(items, "UNIQUE", index.Columns),
+			Type:    rel.UniqueKey,
+			Columns: index.Columns,
+		})
+	}
+
+	keys, err := a.inspectForeignKeys(ctx, name)
+	if err != nil {
+		return table, err
+	}
+
+	for _, key := range keys {
+		key.Name = constraintName(items, "FOREIGN", key.Columns)
+		table.Definitions = append(table.Definitions, key)
+	}
+
+	return table, nil
+}
+
+func (a *Adapter) inspectColumns(ctx context.Context, table string) ([]rel.TableDefinition, error) {
+	rows, err := query(ctx, a.Adapter, "SELECT name, type, \"notnull\", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid;", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		definitions []rel.TableDefinition
+		columns     []rel.Column
+		primaries   = map[int]string{}
+	)
+
+	for rows.Next() {
+		var (
+			column  rel.Column
+			typ     string
+			def     db.NullString
+			primary int
+		)
+
+		if err := rows.Scan(&column.Name, &typ, &column.Required, &def, &primary); err != nil {
+			return nil, err
+		}
+
+		sql.ParseColumnType(&column, typ)
+		if def.Valid {
+			column.Default = sql.ParseDefault(def.String)
+		}
+
+		if primary > 0 {
+			primaries[primary] = column.Name
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range columns {
+		if len(primaries) == 1 && columns[i].Name == primaries[1] && columns[i].Type == rel.Int && !columns[i].Unsigned {
+			columns[i] = rel.Column{Name: columns[i].Name, Type: rel.ID}
+			primaries = nil
+		}
+
+		definitions = append(definitions, columns[i])
+	}
+
+	if len(primaries) > 0 {
+		key := rel.Key{Type: rel.PrimaryKey}
+		for i := 1; i <= len(primaries); i++ {
+			key.Columns = append(key.Columns, primaries[i])
+		}
+
+		definitions = append(definitions, key)
+	}
+
+	return definitions, nil
+}
+
+func (a *Adapter) inspectForeignKeys(ctx context.Context, table string) ([]rel.Key, error) {
+	rows, err := query(ctx, a.Adapter, "SELECT id, \"table\", \"from\", \"to\", on_update, on_delete FROM pragma_foreign_key_list(?) ORDER BY id, seq;", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		keys []rel.Key
+		last = -1
+	)
+
+	for rows.Next() {
+		var (
+			id                 int
+			ref, from          string
+			to                 db.NullString
+			onUpdate, onDelete string
+		)
+
+		if err := rows.Scan(&id, &ref, &from, &to, &onUpdate, &onDelete); err != nil {
+			return nil, err
+		}
+
+		if id != last {
+			keys = append(keys, rel.Key{
+				Type: rel.ForeignKey,
+				Reference: rel.ForeignKeyReference{
+					Table:    ref,
+					OnDelete: sql.ReferentialAction(onDelete),
+					OnUpdate: sql.ReferentialAction(onUpdate),
+				},
+			})
+			last = id
+		}
+
+		key := &keys[len(keys)-1]
+		key.Columns = append(key.Columns, from)
+		if to.Valid {
+			key.Reference.Columns = append(key.Reference.Columns, to.String)
+		}
+	}
+
+	return keys, rows.Err()
+}
+
+// InspectIndexes returns the indexes of a table that are created using create index.
+func (a *Adapter) InspectIndexes(ctx context.Context, table string) ([]rel.Index, error) {
+	return a.inspectIndexes(ctx, table, "c")
+}
+
+// inspectIndexes returns indexes of a table by its origin, c for create index and u for unique constraint.
+func (a *Adapter) inspectIndexes(ctx context.Context, table string, origin string) ([]rel.Index, error) {
+	rows, err := query(ctx, a.Adapter, `SELECT il.name, il."unique", ii.name FROM pragma_index_list(?) il, pragma_index_info(il.name) ii
+		WHERE il.origin = ? ORDER BY il.seq DESC, ii.seqno;`, table, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.ScanIndexes(table, rows)
+}
+
+// constraintName finds the name of table constraint with the given keyword and columns, it returns empty string for unnamed constraint.
+func constraintName(items []tableItem, keyword string, columns []string) string {
+	for _, item := range items {
+		if item.column || item.name == "" {
+			continue
+		}
+
+		tokens := tokenize(item.raw)
+		for i := range tokens {
+			if !strings.EqualFold(tokens[i], keyword) {
+				continue
+			}
+
+			for j := i + 1; j < len(tokens); j++ {
+				if strings.HasPrefix(tokens[j], "(") {
+					if sameColumns(splitDefinitions(tokens[j][1:len(tokens[j])-1]), columns) {
+						return item.name
+					}
+
+					break
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func sameColumns(names []string, columns []string) bool {
+	if len(names) != len(columns) {
+		return false
+	}
+
+	for i := range names {
+		if !strings.EqualFold(unquote(strings.TrimSpace(names[i])), columns[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package sqlite3
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraintName(t *testing.T) {
+	items, err := parseTable("CREATE TABLE `posts` (`a` INT UNIQUE, `b` INT, CONSTRAINT `ab_unique` UNIQUE (`a`, \"b\"), CONSTRAINT posts_a_fk FOREIGN KEY (a) REFERENCES users(id), UNIQUE (b))")
+	assert.Nil(t, err)
+
+	assert.Equal(t, "ab_unique", constraintName(items, "UNIQUE", []string{"a", "b"}))
+	assert.Equal(t, "posts_a_fk", constraintName(items, "FOREIGN", []string{"a"}))
+	assert.Equal(t, "", constraintName(items, "UNIQUE", []string{"a"}))
+	assert.Equal(t, "", constraintName(items, "UNIQUE", []string{"b"}))
+}
+
+func TestAdapter_Inspect(t *testing.T) {
+	adapter, err := Open("file:inspect?mode=memory&cache=shared&_foreign_keys=1")
+	assert.Nil(t, err)
+	defer adapter.Close()
+
+	for _, statement := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name VARCHAR(30) NOT NULL DEFAULT 'it''s', score DECIMAL(6,2), UNIQUE (name));",
+		"CREATE TABLE posts (user_id INTEGER, code VARCHAR(10), title TEXT, PRIMARY KEY (user_id, code), CONSTRAINT posts_user_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE);",
+		"CREATE INDEX posts_title ON posts(title);",
+		"CREATE UNIQUE INDEX posts_code_title ON posts(code, title);",
+	} {
+		_, _, err := adapter.Exec(ctx, statement, nil)
+		assert.Nil(t, err)
+	}
+
+	tables, err := adapter.InspectTables(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"posts", "users"}, tables)
+
+	table, err := adapter.InspectTable(ctx, "users")
+	assert.Nil(t, err)
+	assert.Equal(t, rel.Table{
+		Name: "users",
+		Definitions: []rel.TableDefinition{
+			rel.Column{Name: "id", Type: rel.ID},
+			rel.Column{Name: "name", Type: rel.String, Limit: 30, Required: true, Default: "it's"},
+			rel.Column{Name: "score", Type: rel.Decimal, Precision: 6, Scale: 2},
+			rel.Key{Type: rel.UniqueKey, Columns: []string{"name"}},
+		},
+	}, table)
+
+	table, err = adapter.InspectTable(ctx, "posts")
+	assert.Nil(t, err)
+	assert.Equal(t, rel.Table{
+		Name: "posts",
+		Definitions: []rel.TableDefinition{
+			rel.Column{Name: "user_id", Type: rel.Int},
+			rel.Column{Name: "code", Type: rel.String, Limit: 10},
+			rel.Column{Name: "title", Type: rel.Text},
+			rel.Key{Type: rel.PrimaryKey, Columns: []string{"user_id", "code"}},
+			rel.Key{
+				Name:      "posts_user_fk",
+				Type:      rel.ForeignKey,
+				Columns:   []string{"user_id"},
+				Reference: rel.ForeignKeyReference{Table: "users", Columns: []string{"id"}, OnDelete: "CASCADE"},
+			},
+		},
+	}, table)
+
+	_, err = adapter.InspectTable(ctx, "unknown")
+	assert.Equal(t, rel.ErrNotFound, err)
+
+	indexes, err := adapter.InspectIndexes(ctx, "posts")
+	assert.Nil(t, err)
+	assert.Equal(t, []rel.Index{
+		{Table: "posts", Name: "posts_title", Columns: []string{"title"}},
+		{Table: "posts", Name: "posts_code_title", Unique: true, Columns: []string{"code", "title"}},
+	}, indexes)
+}
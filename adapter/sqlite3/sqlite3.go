@@ -10,9 +10,14 @@
 //
 //	// initialize rel's repo.
 //	repo := rel.New(adapter)
+//
+// JSON filters (JSONContains and JSONExtract) use sqlite's json1 extension,
+// which go-sqlite3 only compiles when built with the sqlite_json tag:
+//	go build -tags=sqlite_json
 package sqlite3
 
 import (
+	"context"
 	db "database/sql"
 	"strings"
 
@@ -23,10 +28,13 @@ import (
 // Adapter definition for mysql database.
 type Adapter struct {
 	*sql.Adapter
+	conn        *db.Conn
+	foreignKeys bool
 }
 
 var (
-	_ rel.Adapter = (*Adapter)(nil)
+	_ rel.Adapter          = (*Adapter)(nil)
+	_ rel.InspectorAdapter = (*Adapter)(nil)
 
 	// Config for mysql adapter.
 	Config = sql.Config{
@@ -36,6 +44,10 @@ var (
 		IncrementFunc:       incrementFunc,
 		ErrorFunc:           errorFunc,
 		MapColumnFunc:       mapColumnFunc,
+		JSONContainsFunc:    jsonContainsFunc,
+		JSONExtractFunc:     jsonExtractFunc,
+		ArgumentFunc:        sql.JSONArray,
+		AlterTableFunc:      alterTableFunc,
 	}
 )
 
@@ -55,6 +67,94 @@ func Open(dsn string) (*Adapter, error) {
 	return New(database), err
 }
 
+// Begin begins a new transaction, the transaction adapter is wrapped, so it's still able to inspect the database.
+// Migration transaction is started on a dedicated connection with foreign keys disabled, following sqlite's documented
+// procedure for schema changes, so tables can be rebuilt without triggering ON DELETE actions of referencing tables.
+func (a *Adapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	if a.Tx != nil || !rel.IsMigration(ctx) {
+		newAdapter, err := a.Adapter.Begin(ctx)
+
+		return &Adapter{
+			Adapter: newAdapter.(*sql.Adapter),
+		}, err
+	}
+
+	conn, err := a.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		foreignKeys bool
+		tx          *db.Tx
+	)
+
+	if err = conn.QueryRowContext(ctx, "PRAGMA foreign_keys;").Scan(&foreignKeys); err == nil && foreignKeys {
+		_, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF;")
+	}
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	finish := a.Instrumenter.Observe(ctx, "adapter-begin", "begin transaction")
+	tx, err = conn.BeginTx(ctx, nil)
+	finish(err)
+
+	txAdapter := &Adapter{
+		Adapter:     &sql.Adapter{Instrumenter: a.Instrumenter, Config: a.Config, Tx: tx},
+		conn:        conn,
+		foreignKeys: foreignKeys,
+	}
+
+	if err != nil {
+		txAdapter.release()
+		return nil, err
+	}
+
+	return txAdapter, nil
+}
+
+// Commit commits current transaction.
+// Migration transaction is committed only when foreign keys are still satisfied.
+func (a *Adapter) Commit(ctx context.Context) error {
+	if a.conn == nil {
+		return a.Adapter.Commit(ctx)
+	}
+
+	defer a.release()
+
+	if a.foreignKeys {
+		if err := checkForeignKeys(ctx, a.Adapter); err != nil {
+			a.Adapter.Rollback(ctx)
+			return err
+		}
+	}
+
+	return a.Adapter.Commit(ctx)
+}
+
+// Rollback revert current transaction.
+func (a *Adapter) Rollback(ctx context.Context) error {
+	if a.conn == nil {
+		return a.Adapter.Rollback(ctx)
+	}
+
+	defer a.release()
+	return a.Adapter.Rollback(ctx)
+}
+
+// release restores foreign keys of migration transaction connection and returns it to the pool.
+// it uses background context, so the connection isn't returned with foreign keys disabled when ctx is canceled.
+func (a *Adapter) release() {
+	if a.foreignKeys {
+		a.conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON;")
+	}
+
+	a.conn.Close()
+}
+
 func incrementFunc(adapter sql.Adapter) int {
 	// decrement
 	return -1
@@ -104,11 +204,19 @@ func mapColumnFunc(column *rel.Column) (string, int, int) {
 	column.Unsigned = false
 
 	switch column.Type {
-	case rel.ID:
+	case rel.ID, rel.BigID, rel.Serial:
 		typ = "INTEGER PRIMARY KEY"
+	case rel.Binary:
+		typ = "BLOB"
+	case rel.Enum:
+		typ = "VARCHAR"
+		m = 255
+		column.Options = strings.TrimSpace(sql.EnumCheck("`"+column.Name+"`", column.Values) + " " + column.Options)
 	case rel.Int:
 		typ = "INTEGER"
 		m = column.Limit
+	case rel.JSON, rel.Array:
+		typ = "TEXT"
 	default:
 		typ, m, n = sql.MapColumn(column)
 	}
@@ -119,3 +227,12 @@ func mapColumnFunc(column *rel.Column) (string, int, int) {
 
 	return typ, m, n
 }
+
+// jsonContainsFunc checks whether json array contains the element, the element is decoded from json placeholder.
+func jsonContainsFunc(field string, placeholder string) string {
+	return "EXISTS (SELECT 1 FROM json_each(" + field + ") WHERE json_each.value=json_extract(" + placeholder + ",'$'))"
+}
+
+func jsonExtractFunc(field string, placeholder string, value interface{}) string {
+	return "json_extract(" + field + "," + placeholder + ")"
+}
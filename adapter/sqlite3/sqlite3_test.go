@@ -21,6 +21,15 @@ func dsn() string {
 	return "./rel_test.db?_foreign_keys=1&_loc=Local"
 }
 
+// jsonFlags skips json filter specs when sqlite is built without json1 extension, it's enabled using sqlite_json build tag.
+func jsonFlags(adapter *Adapter) []specs.Flag {
+	if _, _, err := adapter.Exec(ctx, "SELECT json('[]');", nil); err != nil {
+		return []specs.Flag{specs.SkipJSONFilter}
+	}
+
+	return nil
+}
+
 func TestAdapter_specs(t *testing.T) {
 	adapter, err := Open(dsn())
 	assert.Nil(t, err)
@@ -35,10 +44,21 @@ func TestAdapter_specs(t *testing.T) {
 	// Migration Specs
 	specs.Migrate(t, repo, specs.SkipDropColumn)
 
+	// Inspect Specs
+	specs.Inspect(t, repo)
+
 	// Query Specs
 	specs.Query(t, repo)
 	specs.QueryJoin(t, repo)
+	specs.QueryJoinAssoc(t, repo)
+	specs.QueryJSON(t, repo, jsonFlags(adapter)...)
+	specs.QueryArray(t, repo)
+	specs.QuerySubquery(t, repo)
+	specs.QueryWith(t, repo)
+	specs.QueryCombination(t, repo)
+	specs.QuerySelectExpr(t, repo)
 	specs.QueryNotFound(t, repo)
+	specs.QueryPage(t, repo)
 
 	// Preload specs
 	specs.PreloadHasMany(t, repo)
@@ -50,9 +70,12 @@ func TestAdapter_specs(t *testing.T) {
 	specs.PreloadBelongsTo(t, repo)
 	specs.PreloadBelongsToWithQuery(t, repo)
 	specs.PreloadBelongsToSlice(t, repo)
+	specs.PreloadNested(t, repo)
+	specs.PreloadLimit(t, repo)
 
 	// Aggregate Specs
 	specs.Aggregate(t, repo)
+	specs.AggregateAll(t, repo)
 
 	// Insert Specs
 	specs.Insert(t, repo)
@@ -61,6 +84,12 @@ func TestAdapter_specs(t *testing.T) {
 	specs.InsertBelongsTo(t, repo)
 	specs.Inserts(t, repo)
 	specs.InsertAll(t, repo)
+	specs.InsertOnConflictIgnore(t, repo)
+	specs.InsertOnConflictReplace(t, repo)
+	specs.InsertOnConflictUpdate(t, repo)
+	specs.InsertAllOnConflictIgnore(t, repo)
+	specs.InsertReload(t, repo)
+	specs.InsertAllReload(t, repo)
 	// specs.InsertAllPartialCustomPrimary(t, repo) - not supported
 
 	// Update Specs
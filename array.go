@@ -0,0 +1,116 @@
+package rel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// isJSONArray returns true if src is json array, which is used to store array on database without native array column.
+func isJSONArray(src string) bool {
+	return len(src) >= 2 && src[0] == '[' && src[len(src)-1] == ']'
+}
+
+// decodeJSONArray decodes json array into dest, null element is decoded as zero value.
+func decodeJSONArray(dest interface{}, src string) error {
+	if err := json.Unmarshal([]byte(src), dest); err != nil {
+		return fmt.Errorf("rel: cannot parse %q as array: %v", src, err)
+	}
+
+	return nil
+}
+
+func parseStringArray(dest *[]string, src string) error {
+	if isJSONArray(src) {
+		return decodeJSONArray(dest, src)
+	}
+
+	elems, err := parseArray(src)
+	if err == nil {
+		*dest = elems
+	}
+
+	return err
+}
+
+// parseArray parses one dimensional array literal returned by postgres, for example {a,"b c",NULL}.
+// Null element is parsed as empty string.
+func parseArray(src string) ([]string, error) {
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return nil, fmt.Errorf("rel: cannot parse %q as array", src)
+	}
+
+	var (
+		elems  = []string{}
+		body   = src[1 : len(src)-1]
+		buffer strings.Builder
+		quoted bool
+		inside bool
+	)
+
+	if body == "" {
+		return elems, nil
+	}
+
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && inside && i+1 < len(body):
+			i++
+			buffer.WriteByte(body[i])
+		case c == '"':
+			inside = !inside
+			quoted = true
+		case c == ',' && !inside:
+			elems = append(elems, arrayElement(buffer.String(), quoted))
+			buffer.Reset()
+			quoted = false
+		case c == '{' && !inside:
+			return nil, fmt.Errorf("rel: cannot parse %q as array, multi dimensional array is not supported", src)
+		default:
+			buffer.WriteByte(c)
+		}
+	}
+
+	if inside {
+		return nil, fmt.Errorf("rel: cannot parse %q as array, unterminated quote", src)
+	}
+
+	return append(elems, arrayElement(buffer.String(), quoted)), nil
+}
+
+func arrayElement(elem string, quoted bool) string {
+	if !quoted && elem == "NULL" {
+		return ""
+	}
+
+	return elem
+}
+
+func parseInt64Array(dest *[]int64, src string) error {
+	if isJSONArray(src) {
+		return decodeJSONArray(dest, src)
+	}
+
+	elems, err := parseArray(src)
+	if err != nil {
+		return err
+	}
+
+	var (
+		result = make([]int64, len(elems))
+	)
+
+	for i := range elems {
+		if elems[i] == "" {
+			continue
+		}
+
+		if result[i], err = strconv.ParseInt(elems[i], 10, 64); err != nil {
+			return fmt.Errorf("rel: cannot parse %q as int64 array: %v", src, err)
+		}
+	}
+
+	*dest = result
+	return nil
+}
@@ -0,0 +1,71 @@
+package rel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArray(t *testing.T) {
+	tests := []struct {
+		src    string
+		result []string
+		err    error
+	}{
+		{src: "{}", result: []string{}},
+		{src: "{a,b,c}", result: []string{"a", "b", "c"}},
+		{src: `{"a b","c,d",NULL,"NULL"}`, result: []string{"a b", "c,d", "", "NULL"}},
+		{src: `{"a \"b\"","c\\d"}`, result: []string{`a "b"`, `c\d`}},
+		{src: `{""}`, result: []string{""}},
+		{src: "a,b", err: errors.New(`rel: cannot parse "a,b" as array`)},
+		{src: "{{1,2},{3,4}}", err: errors.New(`rel: cannot parse "{{1,2},{3,4}}" as array, multi dimensional array is not supported`)},
+		{src: `{"a}`, err: errors.New(`rel: cannot parse "{\"a}" as array, unterminated quote`)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.src, func(t *testing.T) {
+			result, err := parseArray(test.src)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
+
+func TestConvertAssign_array(t *testing.T) {
+	var (
+		strings []string
+		ints    []int64
+	)
+
+	assert.Nil(t, convertAssign(&strings, []byte(`{go,"rel orm"}`)))
+	assert.Equal(t, []string{"go", "rel orm"}, strings)
+
+	assert.Nil(t, convertAssign(&ints, "{1,NULL,3}"))
+	assert.Equal(t, []int64{1, 0, 3}, ints)
+
+	assert.NotNil(t, convertAssign(&ints, "{1,a}"))
+	assert.NotNil(t, convertAssign(&strings, "a"))
+
+	assert.Nil(t, convertAssign(&strings, nil))
+	assert.Nil(t, strings)
+}
+
+func TestConvertAssign_jsonArray(t *testing.T) {
+	var (
+		strings []string
+		ints    []int64
+	)
+
+	assert.Nil(t, convertAssign(&strings, []byte(`["go","rel \"orm\"",null]`)))
+	assert.Equal(t, []string{"go", `rel "orm"`, ""}, strings)
+
+	assert.Nil(t, convertAssign(&strings, "[]"))
+	assert.Equal(t, []string{}, strings)
+
+	assert.Nil(t, convertAssign(&ints, "[1,null,3]"))
+	assert.Equal(t, []int64{1, 0, 3}, ints)
+
+	assert.NotNil(t, convertAssign(&ints, `["a"]`))
+	assert.NotNil(t, convertAssign(&strings, "[a]"))
+}
@@ -2,9 +2,8 @@ package rel
 
 import (
 	"reflect"
+	"strings"
 	"sync"
-
-	"github.com/serenize/snaker"
 )
 
 // AssociationType defines the type of association in database.
@@ -17,21 +16,30 @@ const (
 	HasOne
 	// HasMany association.
 	HasMany
+	// ManyToMany association using join table.
+	ManyToMany
 )
 
 type associationKey struct {
-	rt    reflect.Type
-	index int
+	rt     reflect.Type
+	index  int
+	naming NamingStrategy
 }
 
 type associationData struct {
 	typ            AssociationType
 	targetIndex    []int
 	referenceField string
-	referenceIndex int
+	referenceIndex []int
 	foreignField   string
-	foreignIndex   int
+	foreignIndex   []int
 	through        string
+	joinTable      string
+	joinRefField   string
+	joinFkField    string
+	polyField      string
+	polyIndex      []int
+	polyValue      string
 	autoload       bool
 	autosave       bool
 }
@@ -40,8 +48,9 @@ var associationCache sync.Map
 
 // Association provides abstraction to work with association of document or collection.
 type Association struct {
-	data associationData
-	rv   reflect.Value
+	data   associationData
+	rv     reflect.Value
+	naming NamingStrategy
 }
 
 // Type of association.
@@ -60,17 +69,17 @@ func (a Association) Document() (*Document, bool) {
 	case reflect.Ptr:
 		if rv.IsNil() {
 			rv.Set(reflect.New(rv.Type().Elem()))
-			return NewDocument(rv), false
+			return documentWithNaming(rv, a.naming, false), false
 		}
 
 		var (
-			doc = NewDocument(rv)
+			doc = documentWithNaming(rv, a.naming, false)
 		)
 
 		return doc, doc.Persisted()
 	default:
 		var (
-			doc = NewDocument(rv.Addr())
+			doc = documentWithNaming(rv.Addr(), a.naming, false)
 		)
 
 		return doc, doc.Persisted()
@@ -91,14 +100,14 @@ func (a Association) Collection() (*Collection, bool) {
 			rv.Elem().Set(reflect.MakeSlice(rv.Elem().Type(), 0, 0))
 		}
 
-		return NewCollection(rv), loaded
+		return collectionWithNaming(rv, a.naming, false), loaded
 	}
 
 	if !loaded {
 		rv.Set(reflect.MakeSlice(rv.Type(), 0, 0))
 	}
 
-	return NewCollection(rv.Addr()), loaded
+	return collectionWithNaming(rv.Addr(), a.naming, false), loaded
 }
 
 // IsZero returns true if association is not loaded.
@@ -117,7 +126,7 @@ func (a Association) ReferenceField() string {
 
 // ReferenceValue of the association.
 func (a Association) ReferenceValue() interface{} {
-	return indirect(a.rv.Field(a.data.referenceIndex))
+	return indirect(fieldByIndex(a.rv, a.data.referenceIndex, false))
 }
 
 // ForeignField of the association.
@@ -126,9 +135,9 @@ func (a Association) ForeignField() string {
 }
 
 // ForeignValue of the association.
-// It'll panic if association type is has many.
+// It'll panic if association type is has many or many to many.
 func (a Association) ForeignValue() interface{} {
-	if a.Type() == HasMany {
+	if a.Type() == HasMany || a.Type() == ManyToMany {
 		panic("rel: cannot infer foreign value for has many or many to many association")
 	}
 
@@ -140,7 +149,7 @@ func (a Association) ForeignValue() interface{} {
 		rv = rv.Elem()
 	}
 
-	return indirect(rv.Field(a.data.foreignIndex))
+	return indirect(fieldByIndex(rv, a.data.foreignIndex, false))
 }
 
 // Through return intermediary association.
@@ -148,6 +157,48 @@ func (a Association) Through() string {
 	return a.data.through
 }
 
+// JoinTable of many to many association.
+func (a Association) JoinTable() string {
+	return a.data.joinTable
+}
+
+// JoinReferenceField is a field in join table that references to the reference field of the association.
+func (a Association) JoinReferenceField() string {
+	return a.data.joinRefField
+}
+
+// JoinForeignField is a field in join table that references to the foreign field of the association.
+// For through association, it's a field of intermediate document declared using join_fk tag.
+func (a Association) JoinForeignField() string {
+	return a.data.joinFkField
+}
+
+// Polymorphic returns true if association is polymorphic.
+func (a Association) Polymorphic() bool {
+	return a.data.polyField != ""
+}
+
+// PolymorphicField is a field that stores type of polymorphic association.
+// The field is located in parent document for belongs to association, and in target document otherwise.
+func (a Association) PolymorphicField() string {
+	return a.data.polyField
+}
+
+// PolymorphicValue is a type value that identifies this association in polymorphic field.
+func (a Association) PolymorphicValue() string {
+	return a.data.polyValue
+}
+
+// polymorphicMatched returns false if type stored in polymorphic belongs to is not referencing this association.
+func (a Association) polymorphicMatched() bool {
+	if a.data.polyField == "" || a.data.typ != BelongsTo {
+		return true
+	}
+
+	value, _ := indirect(fieldByIndex(a.rv, a.data.polyIndex, false)).(string)
+	return value == a.data.polyValue
+}
+
 // Autoload assoc setting when parent is loaded.
 func (a Association) Autoload() bool {
 	return a.data.autoload
@@ -158,22 +209,24 @@ func (a Association) Autosave() bool {
 	return a.data.autosave
 }
 
-func newAssociation(rv reflect.Value, index int) Association {
+func newAssociation(rv reflect.Value, index int, naming NamingStrategy) Association {
 	if rv.Kind() == reflect.Ptr {
 		rv = rv.Elem()
 	}
 
 	return Association{
-		data: extractAssociationData(rv.Type(), index),
-		rv:   rv,
+		data:   extractAssociationData(rv.Type(), index, naming),
+		rv:     rv,
+		naming: naming,
 	}
 }
 
-func extractAssociationData(rt reflect.Type, index int) associationData {
+func extractAssociationData(rt reflect.Type, index int, naming NamingStrategy) associationData {
 	var (
 		key = associationKey{
-			rt:    rt,
-			index: index,
+			rt:     rt,
+			index:  index,
+			naming: naming,
 		}
 	)
 
@@ -186,10 +239,12 @@ func extractAssociationData(rt reflect.Type, index int) associationData {
 		ft        = sf.Type
 		ref       = sf.Tag.Get("ref")
 		fk        = sf.Tag.Get("fk")
-		fName     = fieldName(sf)
 		assocData = associationData{
 			targetIndex: sf.Index,
 			through:     sf.Tag.Get("through"),
+			joinTable:   sf.Tag.Get("many2many"),
+			polyField:   sf.Tag.Get("polymorphic_type"),
+			polyValue:   sf.Tag.Get("polymorphic_value"),
 			autoload:    sf.Tag.Get("auto") == "true" || sf.Tag.Get("autoload") == "true",
 			autosave:    sf.Tag.Get("auto") == "true" || sf.Tag.Get("autosave") == "true",
 		}
@@ -199,38 +254,103 @@ func extractAssociationData(rt reflect.Type, index int) associationData {
 		panic("rel: autosave is not supported for has one/has many through association")
 	}
 
+	// through association may declare the field of intermediate document that references the target using join_fk.
+	if assocData.through != "" {
+		assocData.joinFkField = sf.Tag.Get("join_fk")
+	}
+
 	for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice {
 		ft = ft.Elem()
 	}
 
+	if joinTable, ok := sf.Tag.Lookup("many2many"); ok && joinTable == "" {
+		assocData.joinTable = naming.JoinTable(rt.Name(), ft.Name())
+	}
+
 	var (
-		refDocData = extractDocumentData(rt, true)
-		fkDocData  = extractDocumentData(ft, true)
+		refDocData  = extractDocumentData(rt, naming, true)
+		fkDocData   = extractDocumentData(ft, naming, true)
+		refPrimary  = primaryFieldOrID(refDocData)
+		fkPrimary   = primaryFieldOrID(fkDocData)
+		polymorphic = sf.Tag.Get("polymorphic")
+		polyBelongs = false
 	)
 
+	if assocData.joinTable != "" && (polymorphic != "" || assocData.polyField != "") {
+		panic("rel: polymorphic many to many association is not supported")
+	}
+
+	if polymorphic != "" && assocData.polyField == "" {
+		assocData.polyField = polymorphic + "_type"
+	}
+
 	// Try to guess ref and fk if not defined.
-	if ref == "" || fk == "" {
-		// TODO: replace "id" with inferred primary field
+	if assocData.polyField != "" {
+		if polymorphic == "" {
+			polymorphic = strings.TrimSuffix(assocData.polyField, "_type")
+		}
+
+		if id, exist := refDocData.fieldIndex[assocData.polyField]; exist {
+			polyBelongs = true
+			assocData.polyIndex = id
+
+			if ref == "" {
+				ref = polymorphic + "_id"
+			}
+
+			if fk == "" {
+				fk = fkPrimary
+			}
+
+			if assocData.polyValue == "" {
+				assocData.polyValue = typeTableName(ft, naming)
+			}
+		} else if id, exist := fkDocData.fieldIndex[assocData.polyField]; exist {
+			assocData.polyIndex = id
+
+			if ref == "" {
+				ref = refPrimary
+			}
+
+			if fk == "" {
+				fk = polymorphic + "_id"
+			}
+
+			if assocData.polyValue == "" {
+				assocData.polyValue = typeTableName(rt, naming)
+			}
+		} else {
+			panic("rel: polymorphic type (" + assocData.polyField + ") field not found")
+		}
+	} else if assocData.joinTable != "" {
+		if ref == "" {
+			ref = refPrimary
+		}
+
+		if fk == "" {
+			fk = fkPrimary
+		}
+	} else if ref == "" || fk == "" {
 		if assocData.through != "" {
-			ref = "id"
-			fk = "id"
-		} else if _, isBelongsTo := refDocData.index[fName+"_id"]; isBelongsTo {
-			ref = fName + "_id"
-			fk = "id"
+			ref = refPrimary
+			fk = fkPrimary
+		} else if _, isBelongsTo := refDocData.fieldIndex[naming.ForeignKey(sf.Name)]; isBelongsTo {
+			ref = naming.ForeignKey(sf.Name)
+			fk = fkPrimary
 		} else {
-			ref = "id"
-			fk = snaker.CamelToSnake(rt.Name()) + "_id"
+			ref = refPrimary
+			fk = naming.ForeignKey(rt.Name())
 		}
 	}
 
-	if id, exist := refDocData.index[ref]; !exist {
+	if id, exist := refDocData.fieldIndex[ref]; !exist {
 		panic("rel: references (" + ref + ") field not found ")
 	} else {
 		assocData.referenceIndex = id
 		assocData.referenceField = ref
 	}
 
-	if id, exist := fkDocData.index[fk]; !exist {
+	if id, exist := fkDocData.fieldIndex[fk]; !exist {
 		panic("rel: foreign_key (" + fk + ") field not found")
 	} else {
 		assocData.foreignIndex = id
@@ -238,8 +358,32 @@ func extractAssociationData(rt reflect.Type, index int) associationData {
 	}
 
 	// guess assoc type
-	if sf.Type.Kind() == reflect.Slice || (sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Slice) {
+	if assocData.joinTable != "" {
+		if sf.Type.Kind() != reflect.Slice && (sf.Type.Kind() != reflect.Ptr || sf.Type.Elem().Kind() != reflect.Slice) {
+			panic("rel: many to many association must be a slice")
+		}
+
+		assocData.typ = ManyToMany
+		assocData.joinRefField = sf.Tag.Get("join_ref")
+		assocData.joinFkField = sf.Tag.Get("join_fk")
+
+		if assocData.joinRefField == "" {
+			assocData.joinRefField = naming.ForeignKey(rt.Name())
+		}
+
+		if assocData.joinFkField == "" {
+			assocData.joinFkField = naming.ForeignKey(ft.Name())
+		}
+	} else if sf.Type.Kind() == reflect.Slice || (sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Slice) {
+		if polyBelongs {
+			panic("rel: polymorphic belongs to association cannot be a slice")
+		}
+
 		assocData.typ = HasMany
+	} else if polyBelongs {
+		assocData.typ = BelongsTo
+	} else if assocData.polyField != "" {
+		assocData.typ = HasOne
 	} else {
 		if len(assocData.referenceField) > len(assocData.foreignField) {
 			assocData.typ = BelongsTo
@@ -252,3 +396,12 @@ func extractAssociationData(rt reflect.Type, index int) associationData {
 
 	return assocData
 }
+
+// primaryFieldOrID returns primary field of document, or id if the document has no or composite primary field.
+func primaryFieldOrID(data documentData) string {
+	if len(data.primaryField) == 1 {
+		return data.primaryField[0]
+	}
+
+	return "id"
+}
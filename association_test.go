@@ -121,7 +121,7 @@ func TestAssociation_Document(t *testing.T) {
 			var (
 				rv          = reflect.ValueOf(test.data)
 				sf, _       = rv.Type().Elem().FieldByName(test.field)
-				assoc       = newAssociation(rv, sf.Index[0])
+				assoc       = newAssociation(rv, sf.Index[0], DefaultNamingStrategy{})
 				doc, loaded = assoc.Document()
 			)
 
@@ -160,6 +160,9 @@ func TestAssociation_Collection(t *testing.T) {
 			ID: 2, Address: *address,
 			Transactions: []Transaction{*transaction},
 		}
+		article       = &Article{ID: 5}
+		tag           = &Tag{ID: 6}
+		articleLoaded = &Article{ID: 5, Tags: []Tag{*tag}}
 	)
 
 	tests := []struct {
@@ -177,6 +180,9 @@ func TestAssociation_Collection(t *testing.T) {
 		foreignValue     interface{}
 		foreignThrough   string
 		through          string
+		joinTable        string
+		joinRefField     string
+		joinFkField      string
 		autoload         bool
 		autosave         bool
 	}{
@@ -219,6 +225,7 @@ func TestAssociation_Collection(t *testing.T) {
 			foreignField:   "id",
 			foreignValue:   nil,
 			through:        "user_roles",
+			joinFkField:    "role_id",
 		},
 		{
 			record:         "Role",
@@ -233,6 +240,7 @@ func TestAssociation_Collection(t *testing.T) {
 			foreignField:   "id",
 			foreignValue:   nil,
 			through:        "user_roles",
+			joinFkField:    "user_id",
 		},
 		{
 			record:         "User",
@@ -247,6 +255,7 @@ func TestAssociation_Collection(t *testing.T) {
 			foreignField:   "id",
 			foreignValue:   nil,
 			through:        "followeds",
+			joinFkField:    "follower_id",
 		},
 		{
 			record:         "User",
@@ -261,6 +270,57 @@ func TestAssociation_Collection(t *testing.T) {
 			foreignField:   "id",
 			foreignValue:   nil,
 			through:        "follows",
+			joinFkField:    "following_id",
+		},
+		{
+			record:         "Article",
+			field:          "Tags",
+			data:           article,
+			typ:            ManyToMany,
+			col:            NewCollection(&article.Tags),
+			loaded:         false,
+			isZero:         true,
+			referenceField: "id",
+			referenceValue: article.ID,
+			foreignField:   "id",
+			foreignValue:   nil,
+			joinTable:      "article_tags",
+			joinRefField:   "article_id",
+			joinFkField:    "tag_id",
+			autosave:       true,
+		},
+		{
+			record:         "Article",
+			field:          "Tags",
+			data:           articleLoaded,
+			typ:            ManyToMany,
+			col:            NewCollection(&articleLoaded.Tags),
+			loaded:         true,
+			isZero:         false,
+			referenceField: "id",
+			referenceValue: articleLoaded.ID,
+			foreignField:   "id",
+			foreignValue:   nil,
+			joinTable:      "article_tags",
+			joinRefField:   "article_id",
+			joinFkField:    "tag_id",
+			autosave:       true,
+		},
+		{
+			record:         "Tag",
+			field:          "Articles",
+			data:           tag,
+			typ:            ManyToMany,
+			col:            NewCollection(&tag.Articles),
+			loaded:         false,
+			isZero:         true,
+			referenceField: "id",
+			referenceValue: tag.ID,
+			foreignField:   "id",
+			foreignValue:   nil,
+			joinTable:      "article_tags",
+			joinRefField:   "tag_id",
+			joinFkField:    "article_id",
 		},
 	}
 
@@ -269,7 +329,7 @@ func TestAssociation_Collection(t *testing.T) {
 			var (
 				rv          = reflect.ValueOf(test.data)
 				sf, _       = rv.Type().Elem().FieldByName(test.field)
-				assoc       = newAssociation(rv, sf.Index[0])
+				assoc       = newAssociation(rv, sf.Index[0], DefaultNamingStrategy{})
 				col, loaded = assoc.Collection()
 			)
 
@@ -281,10 +341,13 @@ func TestAssociation_Collection(t *testing.T) {
 			assert.Equal(t, test.referenceValue, assoc.ReferenceValue())
 			assert.Equal(t, test.foreignField, assoc.ForeignField())
 			assert.Equal(t, test.through, assoc.Through())
+			assert.Equal(t, test.joinTable, assoc.JoinTable())
+			assert.Equal(t, test.joinRefField, assoc.JoinReferenceField())
+			assert.Equal(t, test.joinFkField, assoc.JoinForeignField())
 			assert.Equal(t, test.autoload, assoc.Autoload())
 			assert.Equal(t, test.autosave, assoc.Autosave())
 
-			if test.typ == HasMany {
+			if test.typ == HasMany || test.typ == ManyToMany {
 				assert.Panics(t, func() {
 					assert.Equal(t, test.foreignValue, assoc.ForeignValue())
 				})
@@ -312,6 +375,139 @@ func TestAssociation_autosaveWithThrough(t *testing.T) {
 	})
 }
 
+func TestAssociation_manyToManyNotSlice(t *testing.T) {
+	type Alpha struct {
+		ID int
+	}
+
+	type Beta struct {
+		ID    int
+		Alpha Alpha `many2many:"alpha_betas"`
+	}
+
+	assert.Panics(t, func() {
+		NewDocument(&Beta{})
+	})
+}
+
+func TestAssociation_polymorphic(t *testing.T) {
+	var (
+		postType  = "posts"
+		videoType = "video"
+	)
+
+	tests := []struct {
+		record         interface{}
+		field          string
+		typ            AssociationType
+		referenceField string
+		foreignField   string
+		polyField      string
+		polyValue      string
+		matched        bool
+	}{
+		{
+			record:         &Post{},
+			field:          "comments",
+			typ:            HasMany,
+			referenceField: "id",
+			foreignField:   "owner_id",
+			polyField:      "owner_type",
+			polyValue:      postType,
+			matched:        true,
+		},
+		{
+			record:         &Post{},
+			field:          "cover",
+			typ:            HasOne,
+			referenceField: "id",
+			foreignField:   "owner_id",
+			polyField:      "owner_type",
+			polyValue:      postType,
+			matched:        true,
+		},
+		{
+			record:         &Video{},
+			field:          "comments",
+			typ:            HasMany,
+			referenceField: "id",
+			foreignField:   "owner_id",
+			polyField:      "owner_type",
+			polyValue:      videoType,
+			matched:        true,
+		},
+		{
+			record:         &Comment{OwnerType: postType},
+			field:          "post",
+			typ:            BelongsTo,
+			referenceField: "owner_id",
+			foreignField:   "id",
+			polyField:      "owner_type",
+			polyValue:      postType,
+			matched:        true,
+		},
+		{
+			record:         &Comment{OwnerType: postType},
+			field:          "video",
+			typ:            BelongsTo,
+			referenceField: "owner_id",
+			foreignField:   "id",
+			polyField:      "owner_type",
+			polyValue:      videoType,
+			matched:        false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(reflect.TypeOf(test.record).Elem().Name()+"/"+test.field, func(t *testing.T) {
+			var (
+				doc   = NewDocument(test.record)
+				assoc = doc.Association(test.field)
+			)
+
+			assert.Equal(t, test.typ, assoc.Type())
+			assert.True(t, assoc.Polymorphic())
+			assert.Equal(t, test.referenceField, assoc.ReferenceField())
+			assert.Equal(t, test.foreignField, assoc.ForeignField())
+			assert.Equal(t, test.polyField, assoc.PolymorphicField())
+			assert.Equal(t, test.polyValue, assoc.PolymorphicValue())
+			assert.Equal(t, test.matched, assoc.polymorphicMatched())
+		})
+	}
+}
+
+func TestAssociation_polymorphicTypeNotFound(t *testing.T) {
+	type Alpha struct {
+		ID int
+	}
+
+	type Beta struct {
+		ID    int
+		Alpha Alpha `polymorphic:"owner"`
+	}
+
+	assert.Panics(t, func() {
+		NewDocument(&Beta{})
+	})
+}
+
+func TestAssociation_polymorphicBelongsToSlice(t *testing.T) {
+	type Alpha struct {
+		ID int
+	}
+
+	type Beta struct {
+		ID        int
+		OwnerType string
+		OwnerID   int
+		Alphas    []Alpha `polymorphic:"owner"`
+	}
+
+	assert.Panics(t, func() {
+		NewDocument(&Beta{})
+	})
+}
+
 func TestAssociation_refNotFound(t *testing.T) {
 	type Alpha struct {
 		ID int
@@ -1,6 +1,8 @@
 package rel
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"time"
 )
@@ -29,24 +31,79 @@ func (c Changeset) valueChanged(typ reflect.Type, old interface{}, new interface
 	return !(typ.Comparable() && old == new)
 }
 
+// snapshotValue returns type and value of field to be stored in snapshot.
+// Json field is copied through its encoded value, since it may contains referenced value that's modified in place.
+func snapshotValue(doc *Document, field string) (reflect.Type, interface{}) {
+	var (
+		typ, _   = doc.Type(field)
+		value, _ = doc.Value(field)
+	)
+
+	if doc.data.jsonField[field] && !isNilValue(value) {
+		if data, err := json.Marshal(value); err == nil {
+			rv := reflect.New(typ)
+			if json.Unmarshal(data, rv.Interface()) == nil {
+				return typ, rv.Elem().Interface()
+			}
+		}
+	}
+
+	return typ, value
+}
+
+// fieldChanged returns current value of field and whether it's changed from the snapshot value.
+// Json field is compared using its encoded value, since it may contains uncomparable value.
+func (c Changeset) fieldChanged(doc *Document, field string, old interface{}) (interface{}, bool) {
+	var (
+		typ, _   = doc.Type(field)
+		value, _ = doc.Value(field)
+	)
+
+	if doc.data.jsonField[field] {
+		var (
+			oldEncoded, _ = jsonValue{v: old}.Value()
+			newEncoded, _ = jsonValue{v: value}.Value()
+		)
+
+		return value, oldEncoded != newEncoded
+	}
+
+	return value, c.valueChanged(typ, old, value)
+}
+
+// snapshotField returns original value of the field.
+func (c Changeset) snapshotField(field string) interface{} {
+	for i, f := range c.doc.Fields() {
+		if f == field {
+			return c.snapshot[i]
+		}
+	}
+
+	return nil
+}
+
 // FieldChanged returns true if field exists and it's already changed.
 // returns false otherwise.
 func (c Changeset) FieldChanged(field string) bool {
 	for i, f := range c.doc.Fields() {
 		if f == field {
-			var (
-				typ, _ = c.doc.Type(field)
-				old    = c.snapshot[i]
-				new, _ = c.doc.Value(field)
-			)
-
-			return c.valueChanged(typ, old, new)
+			_, changed := c.fieldChanged(c.doc, field, c.snapshot[i])
+			return changed
 		}
 	}
 
 	return false
 }
 
+// Validate field using validators only when the field is changed.
+func (c Changeset) Validate(field string, validators ...Validator) Validation {
+	return Validation{
+		Field:      field,
+		Validators: validators,
+		changed:    c.FieldChanged,
+	}
+}
+
 // Changes returns map of changes.
 func (c Changeset) Changes() map[string]interface{} {
 	return buildChanges(c.doc, c)
@@ -58,20 +115,16 @@ func (c Changeset) Apply(doc *Document, mut *Mutation) {
 		t = now().Truncate(time.Second)
 	)
 
-	for i, field := range c.doc.Fields() {
-		var (
-			typ, _ = c.doc.Type(field)
-			old    = c.snapshot[i]
-			new, _ = c.doc.Value(field)
-		)
+	c = c.withNaming(doc.data.naming)
 
-		if c.valueChanged(typ, old, new) {
-			mut.Add(Set(field, new))
+	for i, field := range c.doc.Fields() {
+		if value, changed := c.fieldChanged(c.doc, field, c.snapshot[i]); changed {
+			mut.Add(Set(field, c.doc.mutateValue(field, value)))
 		}
 	}
 
-	if !mut.IsMutatesEmpty() && c.doc.Flag(HasUpdatedAt) && c.doc.SetValue("updated_at", t) {
-		mut.Add(Set("updated_at", t))
+	if updatedAt := c.doc.data.flagFieldName(HasUpdatedAt); !mut.IsMutatesEmpty() && updatedAt != "" && c.doc.SetValue(updatedAt, t) {
+		mut.Add(Set(updatedAt, t))
 	}
 
 	if mut.Cascade {
@@ -115,6 +168,7 @@ func (c Changeset) applyAssocMany(field string, mut *Mutation) {
 			muts       = make([]Mutation, 0, col.Len())
 			updatedIDs = make(map[interface{}]struct{})
 			deletedIDs []interface{}
+			changed    bool
 		)
 
 		for i := 0; i < col.Len(); i++ {
@@ -128,22 +182,39 @@ func (c Changeset) applyAssocMany(field string, mut *Mutation) {
 
 				if amod := Apply(doc, ch); !amod.IsEmpty() {
 					muts = append(muts, amod)
+					changed = true
+				} else if assoc.Type() == ManyToMany {
+					// many to many mutations must be aligned with the collection, unchanged record still needs to be linked.
+					muts = append(muts, amod)
 				}
 			} else {
 				muts = append(muts, Apply(doc, newStructset(doc, false)))
+				changed = true
 			}
 		}
 
 		// leftover snapshot.
+		// many to many is unlinked from join table using the referenced foreign value instead of primary value.
 		if len(updatedIDs) != len(chs) {
-			for id := range chs {
-				if _, ok := updatedIDs[id]; !ok {
+			for id, ch := range chs {
+				if _, ok := updatedIDs[id]; ok {
+					continue
+				}
+
+				if assoc.Type() == ManyToMany {
+					deletedIDs = append(deletedIDs, ch.snapshotField(assoc.ForeignField()))
+				} else {
 					deletedIDs = append(deletedIDs, id)
 				}
 			}
 		}
 
-		if len(muts) > 0 || len(deletedIDs) > 0 {
+		// many to many is always diffed, nil deleted ids would unlink all records instead.
+		if deletedIDs == nil && assoc.Type() == ManyToMany {
+			deletedIDs = []interface{}{}
+		}
+
+		if changed || len(deletedIDs) > 0 {
 			mut.SetAssoc(field, muts...)
 			mut.SetDeletedIDs(field, deletedIDs)
 		}
@@ -152,6 +223,66 @@ func (c Changeset) applyAssocMany(field string, mut *Mutation) {
 	}
 }
 
+// withNaming returns the changeset using the given naming strategy, so the changes are applied using the repository's naming.
+// Snapshot and association changesets are matched by struct field index, since field names depend on the naming strategy.
+func (c Changeset) withNaming(naming NamingStrategy) Changeset {
+	if c.doc.data.naming == naming {
+		return c
+	}
+
+	var (
+		doc       = newDocument(c.doc.v, reflect.ValueOf(c.doc.v), naming, false)
+		names     = make(map[string]string, len(c.doc.data.fieldIndex))
+		positions = make(map[string]int, len(c.doc.Fields()))
+		ch        = Changeset{
+			doc:       doc,
+			snapshot:  make([]interface{}, len(doc.Fields())),
+			assoc:     make(map[string]Changeset, len(c.assoc)),
+			assocMany: make(map[string]map[interface{}]Changeset, len(c.assocMany)),
+		}
+	)
+
+	for name, index := range c.doc.data.fieldIndex {
+		names[fmt.Sprint(index)] = name
+	}
+
+	rename := func(field string) string {
+		return names[fmt.Sprint(doc.data.fieldIndex[field])]
+	}
+
+	for i, field := range c.doc.Fields() {
+		positions[field] = i
+	}
+
+	for i, field := range doc.Fields() {
+		if j, ok := positions[rename(field)]; ok {
+			ch.snapshot[i] = c.snapshot[j]
+		} else {
+			_, ch.snapshot[i] = snapshotValue(doc, field)
+		}
+	}
+
+	for _, field := range doc.BelongsTo() {
+		if assoc, ok := c.assoc[rename(field)]; ok {
+			ch.assoc[field] = assoc
+		}
+	}
+
+	for _, field := range doc.HasOne() {
+		if assoc, ok := c.assoc[rename(field)]; ok {
+			ch.assoc[field] = assoc
+		}
+	}
+
+	for _, field := range doc.HasMany() {
+		if assocMany, ok := c.assocMany[rename(field)]; ok {
+			ch.assocMany[field] = assocMany
+		}
+	}
+
+	return ch
+}
+
 // NewChangeset returns new changeset mutator for given record.
 func NewChangeset(record interface{}) Changeset {
 	return newChangeset(NewDocument(record))
@@ -166,7 +297,7 @@ func newChangeset(doc *Document) Changeset {
 	}
 
 	for i, field := range doc.Fields() {
-		c.snapshot[i], _ = doc.Value(field)
+		_, c.snapshot[i] = snapshotValue(doc, field)
 	}
 
 	for _, field := range doc.BelongsTo() {
@@ -232,13 +363,12 @@ func buildChanges(doc *Document, c Changeset) map[string]interface{} {
 				changes[field] = pair{old, nil}
 			}
 		case i >= len(c.snapshot):
-			if new, _ := doc.Value(field); new != nil {
+			if _, new := snapshotValue(doc, field); new != nil {
 				changes[field] = pair{nil, new}
 			}
 		default:
 			old := c.snapshot[i]
-			new, _ := doc.Value(field)
-			if typ, _ := doc.Type(field); c.valueChanged(typ, old, new) {
+			if new, changed := c.fieldChanged(doc, field, old); changed {
 				changes[field] = pair{old, new}
 			}
 		}
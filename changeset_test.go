@@ -160,6 +160,76 @@ func TestChangeset(t *testing.T) {
 	})
 }
 
+func TestChangeset_embedded(t *testing.T) {
+	var (
+		ts    = time.Now()
+		place = Place{
+			BaseModel: BaseModel{ID: 1, Timestamps: Timestamps{CreatedAt: ts, UpdatedAt: ts}},
+			Name:      "name",
+			Address:   Location{City: "city"},
+		}
+		doc       = NewDocument(&place)
+		changeset = NewChangeset(&place)
+	)
+
+	place.Address.City = "new city"
+	place.Audit = &Audit{CreatedBy: "admin"}
+
+	assert.Equal(t, map[string]interface{}{
+		"address_city": pair{"city", "new city"},
+		"created_by":   pair{nil, "admin"},
+	}, changeset.Changes())
+
+	assert.Equal(t, Mutation{
+		Cascade: true,
+		Mutates: map[string]Mutate{
+			"address_city": Set("address_city", "new city"),
+			"created_by":   Set("created_by", "admin"),
+			"updated_at":   Set("updated_at", now()),
+		},
+	}, Apply(doc, changeset))
+}
+
+func TestChangeset_json(t *testing.T) {
+	var (
+		setting   = Setting{ID: 1, Tags: []string{"go"}}
+		doc       = NewDocument(&setting)
+		changeset = NewChangeset(&setting)
+	)
+
+	setting.Tags = append(setting.Tags, "rel")
+	setting.Details.Theme = "dark"
+
+	assert.Equal(t, map[string]interface{}{
+		"labels":  pair{[]string{"go"}, []string{"go", "rel"}},
+		"details": pair{struct{ Theme string }{}, struct{ Theme string }{Theme: "dark"}},
+	}, changeset.Changes())
+
+	assert.Equal(t, Mutation{
+		Cascade: true,
+		Mutates: map[string]Mutate{
+			"labels":  Set("labels", jsonValue{v: []string{"go", "rel"}}),
+			"details": Set("details", jsonValue{v: setting.Details}),
+		},
+	}, Apply(doc, changeset))
+}
+
+func TestChangeset_jsonModifiedInPlace(t *testing.T) {
+	var (
+		setting   = Setting{ID: 1, Tags: []string{"go"}}
+		changeset = NewChangeset(&setting)
+	)
+
+	assert.Empty(t, changeset.Changes())
+
+	setting.Tags[0] = "rel"
+
+	assert.True(t, changeset.FieldChanged("labels"))
+	assert.Equal(t, map[string]interface{}{
+		"labels": pair{[]string{"go"}, []string{"rel"}},
+	}, changeset.Changes())
+}
+
 func TestChangeset_ptr(t *testing.T) {
 	var (
 		userID  = 2
@@ -527,3 +597,46 @@ func TestChangeset_hasMany(t *testing.T) {
 		}, Apply(doc, changeset))
 	})
 }
+
+func TestChangeset_manyToMany(t *testing.T) {
+	var (
+		article = Article{
+			ID: 1,
+			Tags: []Tag{
+				{ID: 11, Name: "go"},
+				{ID: 12, Name: "orm"},
+			},
+		}
+		doc       = NewDocument(&article)
+		changeset = NewChangeset(&article)
+	)
+
+	t.Run("apply clean", func(t *testing.T) {
+		assert.Equal(t, Mutation{
+			Cascade: true,
+		}, Apply(doc, changeset))
+	})
+
+	t.Run("apply changeset", func(t *testing.T) {
+		article.Tags = append(article.Tags, Tag{Name: "sql"})
+
+		assert.Equal(t, Mutation{
+			Cascade: true,
+			Assoc: map[string]AssocMutation{
+				"tags": {
+					Mutations: []Mutation{
+						{Cascade: true},
+						{Cascade: true},
+						{
+							Cascade: true,
+							Mutates: map[string]Mutate{
+								"name": Set("name", "sql"),
+							},
+						},
+					},
+					DeletedIDs: []interface{}{},
+				},
+			},
+		}, Apply(doc, changeset))
+	})
+}
@@ -5,11 +5,17 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"html/template"
 	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/serenize/snaker"
 )
@@ -19,6 +25,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 	"time"
@@ -60,6 +67,23 @@ func logger(ctx context.Context, op string, message string) func(err error) {
 	}
 }
 
+func status(ctx context.Context, m migrator.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statuses {
+		if s.Applied {
+			fmt.Println("applied", s.Version, s.AppliedAt.Format(time.RFC3339))
+		} else {
+			fmt.Println("pending", s.Version)
+		}
+	}
+
+	return nil
+}
+
 func main() {
 	var (
 		ctx = context.Background()
@@ -80,10 +104,12 @@ func main() {
 	m.Instrumentation(logger)
 
 	{{range .Migrations}}
-	m.Register({{.Version}}, migrations.Migrate{{.Name}}, migrations.Rollback{{.Name}})
+	{{if .Reversible}}m.Change({{.Version}}, migrations.Migrate{{.Name}}){{else}}m.Register({{.Version}}, migrations.Migrate{{.Name}}, migrations.Rollback{{.Name}}){{end}}
 	{{end}}
 
-	{{.Command}}
+	if err := {{.Command}}; err != nil {
+		log.Fatal(err)
+	}
 }
 `
 
@@ -99,17 +125,22 @@ func ExecMigrate(ctx context.Context, args []string) error {
 	var (
 		defAdapter, defDriver, defDSN = getDatabaseInfo()
 		fs                            = flag.NewFlagSet(args[1], flag.ExitOnError)
-		command                       = getMigrateCommand(args[1])
 		dir                           = fs.String("dir", "db/migrations", "Path to directory containing migration files")
 		module                        = fs.String("module", getModule(), "Module of the main package")
 		adapter                       = fs.String("adapter", defAdapter, "Adapter package")
 		driver                        = fs.String("driver", defDriver, "Driver package")
 		dsn                           = fs.String("dsn", defDSN, "DSN for database connection")
 		verbose                       = fs.Bool("verbose", false, "Show logs from REL")
+		steps                         = fs.Int("steps", 1, "Number of versions to rollback")
 		tmpl                          = template.Must(template.New("migration").Parse(migrationTemplate))
 	)
 
-	fs.Parse(args[2:])
+	subcommand, target, flags, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	fs.Parse(flags)
 
 	if *adapter == "" || *driver == "" || *dsn == "" {
 		return fmt.Errorf("rel: missing required parameters:\n\tadapter: %s\n\tdriver: %s\n\tdsn: %s", *adapter, *driver, *dsn)
@@ -134,7 +165,7 @@ func ExecMigrate(ctx context.Context, args []string) error {
 		Verbose    bool
 	}{
 		Package:    *module + "/" + *dir,
-		Command:    command,
+		Command:    getMigrateCommand(args[1], subcommand, target, *steps),
 		Adapter:    *adapter,
 		Driver:     *driver,
 		DSN:        *dsn,
@@ -150,9 +181,11 @@ func ExecMigrate(ctx context.Context, args []string) error {
 	return cmd.Run()
 }
 
+// migration file, it's reversible when the rollback function is not defined.
 type migration struct {
-	Version string
-	Name    string
+	Version    string
+	Name       string
+	Reversible bool
 }
 
 func scanMigration(dir string) ([]migration, error) {
@@ -172,20 +205,80 @@ func scanMigration(dir string) ([]migration, error) {
 			return nil, errors.New("rel: invalid migration file: " + f.Name())
 		}
 
+		content, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, errors.New("rel: error reading migration file: " + f.Name())
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), f.Name(), content, 0)
+		if err != nil {
+			return nil, errors.New("rel: error parsing migration file: " + f.Name())
+		}
+
+		name := snaker.SnakeToCamel(result[2])
 		mFiles = append(mFiles, migration{
-			Version: result[1],
-			Name:    snaker.SnakeToCamel(result[2]),
+			Version:    result[1],
+			Name:       name,
+			Reversible: !declaresFunc(file, "Rollback"+name),
 		})
 	}
 
 	return mFiles, err
 }
 
-func getMigrateCommand(cmd string) string {
-	switch cmd {
-	case "rollback", "down":
-		return "m.Rollback(ctx)"
+// declaresFunc returns true if the file declares top level function with the given name.
+func declaresFunc(file *ast.File, name string) bool {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseMigrateArgs splits the optional subcommand (status, to or redo) and the target version of to from the flags.
+func parseMigrateArgs(args []string) (string, int, []string, error) {
+	if len(args) < 3 || strings.HasPrefix(args[2], "-") {
+		return "", 0, args[2:], nil
+	}
+
+	switch subcommand := args[2]; subcommand {
+	case "status", "redo":
+		return subcommand, 0, args[3:], nil
+	case "to":
+		if len(args) < 4 {
+			return "", 0, nil, errors.New("rel: missing target version")
+		}
+
+		target, err := strconv.Atoi(args[3])
+		if err != nil {
+			return "", 0, nil, errors.New("rel: invalid target version: " + args[3])
+		}
+
+		return subcommand, target, args[4:], nil
+	default:
+		return "", 0, nil, errors.New("rel: unknown " + args[1] + " command: " + subcommand)
+	}
+}
+
+func getMigrateCommand(cmd string, subcommand string, target int, steps int) string {
+	var (
+		rollback = cmd == "rollback" || cmd == "down"
+	)
+
+	switch {
+	case subcommand == "status":
+		return "status(ctx, m)"
+	case subcommand == "redo":
+		return "m.Redo(ctx)"
+	case subcommand == "to" && rollback:
+		return "m.RollbackTo(ctx, " + strconv.Itoa(target) + ")"
+	case subcommand == "to":
+		return "m.MigrateTo(ctx, " + strconv.Itoa(target) + ")"
+	case rollback:
+		return "m.RollbackSteps(ctx, " + strconv.Itoa(steps) + ")"
 	default:
-		return "m.Migrate(ctx)"
+		return "m.MigrateTo(ctx, migrator.Latest)"
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +40,19 @@ func TestExecMigrate(t *testing.T) {
 		assert.Equal(t, errors.New("rel: error accessing read migration directory: db"), ExecMigrate(ctx, args))
 	})
 
+	t.Run("invalid subcommand", func(t *testing.T) {
+		var (
+			ctx  = context.TODO()
+			args = []string{
+				"rel",
+				"migrate",
+				"unknown",
+			}
+		)
+
+		assert.Equal(t, errors.New("rel: unknown migrate command: unknown"), ExecMigrate(ctx, args))
+	})
+
 	t.Run("success", func(t *testing.T) {
 		var (
 			ctx  = context.TODO()
@@ -63,6 +77,33 @@ func TestExecMigrate(t *testing.T) {
 		err := ExecMigrate(ctx, args)
 		assert.Contains(t, buff.String(), "Running: migrate 1 create table todos")
 		assert.Contains(t, buff.String(), "Done: migrate 1 create table todos")
+		assert.Contains(t, buff.String(), "Done: migrate 2 create table tags")
+		assert.Nil(t, err)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		var (
+			ctx  = context.TODO()
+			args = []string{
+				"rel",
+				"migrate",
+				"status",
+				"-dir=testdata/migrations",
+				"-module=github.com/go-rel/rel/cmd/rel/internal",
+				"-adapter=github.com/go-rel/rel/adapter/sqlite3",
+				"-driver=github.com/mattn/go-sqlite3",
+				"-dsn=:memory:",
+			}
+			dir  = "testdata"
+			buff = &bytes.Buffer{}
+		)
+
+		tempdir = dir
+		stdout = buff
+		defer func() { stdout = os.Stdout }()
+
+		err := ExecMigrate(ctx, args)
+		assert.Equal(t, "pending 1\npending 2\n", buff.String())
 		assert.Nil(t, err)
 	})
 }
@@ -80,12 +121,21 @@ func TestScanMigration(t *testing.T) {
 					Version: "1",
 					Name:    "CreateSamples",
 				},
+				{
+					Version:    "2",
+					Name:       "CreateTags",
+					Reversible: true,
+				},
 			},
 		},
 		{
 			dir: "db",
 			err: errors.New("rel: error accessing read migration directory: db"),
 		},
+		{
+			dir: "testdata/invalid_migrations",
+			err: errors.New("rel: error parsing migration file: 1_create_samples.go"),
+		},
 		{
 			dir: "../",
 			err: errors.New("rel: invalid migration file: main.go"),
@@ -105,9 +155,71 @@ func TestScanMigration(t *testing.T) {
 
 }
 
+func TestParseMigrateArgs(t *testing.T) {
+	tests := []struct {
+		args       []string
+		subcommand string
+		target     int
+		flags      []string
+		err        error
+	}{
+		{
+			args:  []string{"rel", "migrate"},
+			flags: []string{},
+		},
+		{
+			args:  []string{"rel", "migrate", "-verbose"},
+			flags: []string{"-verbose"},
+		},
+		{
+			args:       []string{"rel", "migrate", "status", "-verbose"},
+			subcommand: "status",
+			flags:      []string{"-verbose"},
+		},
+		{
+			args:       []string{"rel", "migrate", "redo"},
+			subcommand: "redo",
+			flags:      []string{},
+		},
+		{
+			args:       []string{"rel", "rollback", "to", "20200829084000", "-verbose"},
+			subcommand: "to",
+			target:     20200829084000,
+			flags:      []string{"-verbose"},
+		},
+		{
+			args: []string{"rel", "migrate", "to"},
+			err:  errors.New("rel: missing target version"),
+		},
+		{
+			args: []string{"rel", "migrate", "to", "latest"},
+			err:  errors.New("rel: invalid target version: latest"),
+		},
+		{
+			args: []string{"rel", "rollback", "all"},
+			err:  errors.New("rel: unknown rollback command: all"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			subcommand, target, flags, err := parseMigrateArgs(test.args)
+
+			assert.Equal(t, test.subcommand, subcommand)
+			assert.Equal(t, test.target, target)
+			assert.Equal(t, test.flags, flags)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
 func TestGetMigrateCommand(t *testing.T) {
-	assert.Equal(t, "m.Rollback(ctx)", getMigrateCommand("rollback"))
-	assert.Equal(t, "m.Rollback(ctx)", getMigrateCommand("down"))
-	assert.Equal(t, "m.Migrate(ctx)", getMigrateCommand("migrate"))
-	assert.Equal(t, "m.Migrate(ctx)", getMigrateCommand("up"))
+	assert.Equal(t, "m.RollbackSteps(ctx, 1)", getMigrateCommand("rollback", "", 0, 1))
+	assert.Equal(t, "m.RollbackSteps(ctx, 3)", getMigrateCommand("down", "", 0, 3))
+	assert.Equal(t, "m.RollbackTo(ctx, 2)", getMigrateCommand("rollback", "to", 2, 1))
+	assert.Equal(t, "m.MigrateTo(ctx, migrator.Latest)", getMigrateCommand("migrate", "", 0, 1))
+	assert.Equal(t, "m.MigrateTo(ctx, migrator.Latest)", getMigrateCommand("up", "", 0, 1))
+	assert.Equal(t, "m.MigrateTo(ctx, 2)", getMigrateCommand("migrate", "to", 2, 1))
+	assert.Equal(t, "m.Redo(ctx)", getMigrateCommand("migrate", "redo", 0, 1))
+	assert.Equal(t, "status(ctx, m)", getMigrateCommand("migrate", "status", 0, 1))
 }
@@ -0,0 +1,4 @@
+package migrations
+
+// MigrateCreateSamples definition
+func MigrateCreateSamples(schema *rel.Schema) {
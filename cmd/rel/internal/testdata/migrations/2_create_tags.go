@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/go-rel/rel"
+
+// MigrateCreateTags definition, rollback is derived automatically,
+// so there is no need to declare func RollbackCreateTags(schema *rel.Schema).
+func MigrateCreateTags(schema *rel.Schema) {
+	schema.CreateTable("tags", func(t *rel.Table) {
+		t.ID("id")
+		t.String("name")
+	})
+}
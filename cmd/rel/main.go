@@ -25,7 +25,7 @@ func main() {
 	)
 
 	if len(os.Args) < 2 {
-		fmt.Println("Available command are: migrate, rollback")
+		fmt.Println("Available command are: migrate [status|to <version>|redo], rollback [to <version>]")
 		os.Exit(1)
 	}
 
@@ -36,7 +36,7 @@ func main() {
 		fmt.Println("REL CLI " + version)
 	case "-help":
 		fmt.Println("Usage: rel [command] -help")
-		fmt.Println("Available commands: migrate, rollback")
+		fmt.Println("Available commands: migrate [status|to <version>|redo], rollback [to <version>]")
 	default:
 		flag.PrintDefaults()
 		os.Exit(1)
@@ -37,11 +37,12 @@ func (c *Collection) Table() string {
 
 func (c Collection) tableName() string {
 	var (
-		rt = c.rt.Elem()
+		rt  = c.rt.Elem()
+		key = namingKey{rt: rt, naming: c.data.naming}
 	)
 
 	// check for cache
-	if name, cached := tablesCache.Load(rt); cached {
+	if name, cached := tablesCache.Load(key); cached {
 		return name.(string)
 	}
 
@@ -50,11 +51,11 @@ func (c Collection) tableName() string {
 			v = reflect.Zero(rt).Interface().(table)
 		)
 
-		tablesCache.Store(rt, v.Table())
+		tablesCache.Store(key, v.Table())
 		return v.Table()
 	}
 
-	return tableName(rt)
+	return tableName(rt, c.data.naming)
 }
 
 // PrimaryFields column name of this collection.
@@ -99,7 +100,7 @@ func (c Collection) PrimaryValues() []interface{} {
 			)
 
 			for j := range values {
-				values[j] = c.rv.Index(j).Field(index[i]).Interface()
+				values[j] = fieldByIndex(c.rv.Index(j), index[i], false).Interface()
 			}
 
 			pValues[i] = values
@@ -136,7 +137,7 @@ func (c Collection) PrimaryValue() interface{} {
 
 // Get an element from the underlying slice as a document.
 func (c Collection) Get(index int) *Document {
-	return NewDocument(c.rv.Index(index).Addr())
+	return documentWithNaming(c.rv.Index(index).Addr(), c.data.naming, false)
 }
 
 // Len of the underlying slice.
@@ -159,7 +160,7 @@ func (c Collection) Add() *Document {
 
 	c.rv.Set(reflect.Append(c.rv, drv))
 
-	return NewDocument(c.rv.Index(index).Addr())
+	return documentWithNaming(c.rv.Index(index).Addr(), c.data.naming, false)
 }
 
 // Truncate collection.
@@ -169,7 +170,7 @@ func (c Collection) Truncate(i, j int) {
 
 // Slice returns a new collection that is a slice of the original collection.s
 func (c Collection) Slice(i, j int) *Collection {
-	return NewCollection(c.rv.Slice(i, j), true)
+	return collectionWithNaming(c.rv.Slice(i, j), c.data.naming, true)
 }
 
 // Swap element in the collection.
@@ -183,22 +184,32 @@ func (c Collection) Swap(i, j int) {
 
 // NewCollection used to create abstraction to work with slice.
 // COllection can be created using interface or reflect.Value.
+// Table and column names are inferred using DefaultNamingStrategy.
 func NewCollection(records interface{}, readonly ...bool) *Collection {
+	return collectionWithNaming(records, DefaultNamingStrategy{}, len(readonly) > 0 && readonly[0])
+}
+
+func collectionWithNaming(records interface{}, naming NamingStrategy, readonly bool) *Collection {
 	switch v := records.(type) {
 	case *Collection:
+		// collection created outside repository is rewrapped, so it uses the naming strategy of the repository.
+		if v.data.naming != naming {
+			return newCollection(v.v, reflect.ValueOf(v.v), naming, readonly)
+		}
+
 		return v
 	case reflect.Value:
-		return newCollection(v.Interface(), v, len(readonly) > 0 && readonly[0])
+		return newCollection(v.Interface(), v, naming, readonly)
 	case reflect.Type:
 		panic("rel: cannot use reflect.Type")
 	case nil:
 		panic("rel: cannot be nil")
 	default:
-		return newCollection(v, reflect.ValueOf(v), len(readonly) > 0 && readonly[0])
+		return newCollection(v, reflect.ValueOf(v), naming, readonly)
 	}
 }
 
-func newCollection(v interface{}, rv reflect.Value, readonly bool) *Collection {
+func newCollection(v interface{}, rv reflect.Value, naming NamingStrategy, readonly bool) *Collection {
 	var (
 		rt = rv.Type()
 	)
@@ -220,6 +231,6 @@ func newCollection(v interface{}, rv reflect.Value, readonly bool) *Collection {
 		v:    v,
 		rv:   rv,
 		rt:   rt,
-		data: extractDocumentData(rt.Elem(), false),
+		data: extractDocumentData(rt.Elem(), naming, false),
 	}
 }
@@ -50,10 +50,10 @@ func TestCollection_Table(t *testing.T) {
 	assert.Equal(t, "users", col.Table())
 
 	// cached
-	_, cached := tablesCache.Load(rt)
+	_, cached := tablesCache.Load(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 	assert.True(t, cached)
 
-	tablesCache.Delete(rt)
+	tablesCache.Delete(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 }
 
 func TestCollection_Table_usingInterface(t *testing.T) {
@@ -67,7 +67,7 @@ func TestCollection_Table_usingInterface(t *testing.T) {
 	assert.Equal(t, "_items", col.Table())
 
 	// never cache
-	_, cached := tablesCache.Load(rt)
+	_, cached := tablesCache.Load(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 	assert.False(t, cached)
 }
 
@@ -82,10 +82,10 @@ func TestCollection_Table_usingElemInterface(t *testing.T) {
 	assert.Equal(t, "_items", col.Table())
 
 	// cache
-	_, cached := tablesCache.Load(rt)
+	_, cached := tablesCache.Load(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 	assert.True(t, cached)
 
-	tablesCache.Delete(rt)
+	tablesCache.Delete(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 }
 
 func TestCollection_Primary(t *testing.T) {
@@ -103,7 +103,7 @@ func TestCollection_Primary(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 2}, col.PrimaryValue())
 
 	// cached
-	_, cached := primariesCache.Load(rt)
+	_, cached := primariesCache.Load(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 	assert.True(t, cached)
 
 	records[1].ID = 4
@@ -112,7 +112,7 @@ func TestCollection_Primary(t *testing.T) {
 	assert.Equal(t, "id", col.PrimaryField())
 	assert.Equal(t, []interface{}{1, 4}, col.PrimaryValue())
 
-	primariesCache.Delete(rt)
+	primariesCache.Delete(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 }
 
 func TestCollection_Primary_usingInterface(t *testing.T) {
@@ -143,7 +143,7 @@ func TestCollection_Primary_usingElemInterface(t *testing.T) {
 	assert.Equal(t, "_uuid", col.PrimaryField())
 	assert.Equal(t, []interface{}{"abc123", "def456"}, col.PrimaryValue())
 
-	primariesCache.Delete(rt)
+	primariesCache.Delete(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 }
 
 func TestCollection_Primary_usingTag(t *testing.T) {
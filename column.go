@@ -6,8 +6,14 @@ type ColumnType string
 const (
 	// ID ColumnType.
 	ID ColumnType = "ID"
+	// BigID ColumnType.
+	BigID ColumnType = "BIGID"
+	// Serial ColumnType.
+	Serial ColumnType = "SERIAL"
 	// Bool ColumnType.
 	Bool ColumnType = "BOOL"
+	// SmallInt ColumnType.
+	SmallInt ColumnType = "SMALLINT"
 	// Int ColumnType.
 	Int ColumnType = "INT"
 	// BigInt ColumnType.
@@ -28,11 +34,44 @@ const (
 	Time ColumnType = "TIME"
 	// Timestamp ColumnType.
 	Timestamp ColumnType = "TIMESTAMP"
+	// JSON ColumnType.
+	JSON ColumnType = "JSON"
+	// UUID ColumnType.
+	UUID ColumnType = "UUID"
+	// Binary ColumnType.
+	Binary ColumnType = "BINARY"
+	// Enum ColumnType.
+	Enum ColumnType = "ENUM"
+	// Array ColumnType.
+	Array ColumnType = "ARRAY"
+	// Inet ColumnType.
+	Inet ColumnType = "INET"
+)
+
+func (ct ColumnType) applyColumn(column *Column) {
+	column.Type = ct
+}
+
+// AlterColumnOp defines which attribute of the column is altered.
+type AlterColumnOp uint8
+
+const (
+	// AlterColumnType changes column type along with its limit, precision and scale.
+	AlterColumnType AlterColumnOp = iota
+	// AlterColumnNull allows nil values in the column.
+	AlterColumnNull
+	// AlterColumnNotNull disallows nil values in the column.
+	AlterColumnNotNull
+	// AlterColumnDefault sets default value of the column.
+	AlterColumnDefault
+	// AlterColumnDropDefault removes default value of the column.
+	AlterColumnDropDefault
 )
 
 // Column definition.
 type Column struct {
 	Op        SchemaOp
+	Alter     AlterColumnOp
 	Name      string
 	Type      ColumnType
 	Rename    string
@@ -43,6 +82,8 @@ type Column struct {
 	Precision int
 	Scale     int
 	Default   interface{}
+	Values    []string
+	Element   ColumnType
 	Options   string
 }
 
@@ -70,6 +111,27 @@ func renameColumn(name string, newName string, options []ColumnOption) Column {
 	return column
 }
 
+func changeColumn(name string, typ ColumnType, options []ColumnOption) Column {
+	column := Column{
+		Op:    SchemaAlter,
+		Alter: AlterColumnType,
+		Name:  name,
+		Type:  typ,
+	}
+
+	applyColumnOptions(&column, options)
+	return column
+}
+
+func alterColumn(name string, alter AlterColumnOp, def interface{}) Column {
+	return Column{
+		Op:      SchemaAlter,
+		Alter:   alter,
+		Name:    name,
+		Default: def,
+	}
+}
+
 func dropColumn(name string, options []ColumnOption) Column {
 	column := Column{
 		Op:   SchemaDrop,
@@ -0,0 +1,46 @@
+package rel
+
+// CombinationQuery defines set operation (UNION, UNION ALL, INTERSECT or EXCEPT) that combines result of two queries.
+type CombinationQuery struct {
+	Operator string
+	Query    Query
+}
+
+// Build query.
+func (cq CombinationQuery) Build(query *Query) {
+	query.CombinationQuery = append(query.CombinationQuery, cq)
+}
+
+// NewUnion combines result of the query with other query, removing duplicate rows.
+func NewUnion(query Query) CombinationQuery {
+	return CombinationQuery{
+		Operator: "UNION",
+		Query:    query,
+	}
+}
+
+// NewUnionAll combines result of the query with other query, including duplicate rows.
+func NewUnionAll(query Query) CombinationQuery {
+	return CombinationQuery{
+		Operator: "UNION ALL",
+		Query:    query,
+	}
+}
+
+// NewIntersect returns rows that exists in both result of the query and other query.
+// It requires MySQL 8.0.31 or later.
+func NewIntersect(query Query) CombinationQuery {
+	return CombinationQuery{
+		Operator: "INTERSECT",
+		Query:    query,
+	}
+}
+
+// NewExcept returns rows of the query that doesn't exists in result of other query.
+// It requires MySQL 8.0.31 or later.
+func NewExcept(query Query) CombinationQuery {
+	return CombinationQuery{
+		Operator: "EXCEPT",
+		Query:    query,
+	}
+}
@@ -0,0 +1,40 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombinationQuery(t *testing.T) {
+	var (
+		archived = rel.From("archived_users")
+		tests    = []struct {
+			Operator string
+			Query    rel.Query
+			Querier  rel.Querier
+		}{
+			{"UNION", rel.From("users").Union(archived), rel.NewUnion(archived)},
+			{"UNION ALL", rel.From("users").UnionAll(archived), rel.NewUnionAll(archived)},
+			{"INTERSECT", rel.From("users").Intersect(archived), rel.NewIntersect(archived)},
+			{"EXCEPT", rel.From("users").Except(archived), rel.NewExcept(archived)},
+		}
+	)
+
+	for _, test := range tests {
+		t.Run(test.Operator, func(t *testing.T) {
+			result := rel.Query{
+				Table: "users",
+				CombinationQuery: []rel.CombinationQuery{
+					{Operator: test.Operator, Query: archived},
+				},
+				CascadeQuery: true,
+			}
+
+			assert.Equal(t, result, test.Query)
+			assert.Equal(t, result, rel.Build("users", test.Querier))
+			assert.Equal(t, result, rel.Build("", rel.From("users"), test.Query))
+		})
+	}
+}
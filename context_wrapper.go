@@ -11,7 +11,37 @@ type contextWrapper struct {
 	adapter Adapter
 }
 
-var ctxKey contextKey
+var (
+	ctxKey       contextKey
+	primaryKey   contextKey = 1
+	migrationKey contextKey = 2
+)
+
+// WithPrimary returns context that forces read queries to be routed to primary database.
+// It's useful to read own writes without being affected by replication lag, repository uses it to reload records
+// after writes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey, true)
+}
+
+// UsePrimary returns true when read queries using given context must be routed to primary database.
+func UsePrimary(ctx context.Context) bool {
+	force, _ := ctx.Value(primaryKey).(bool)
+	return force
+}
+
+// WithMigration returns context for transaction that applies schema migration.
+// Adapter may prepare the connection before the transaction begins, for example sqlite disables foreign keys,
+// so tables can be rebuilt without triggering ON DELETE actions.
+func WithMigration(ctx context.Context) context.Context {
+	return context.WithValue(ctx, migrationKey, true)
+}
+
+// IsMigration returns true when transaction using given context applies schema migration.
+func IsMigration(ctx context.Context) bool {
+	migration, _ := ctx.Value(migrationKey).(bool)
+	return migration
+}
 
 // fetchContext and use adapter passed by context if exists.
 // it stores contextData values to struct for fast repeated access.
@@ -33,3 +63,11 @@ func wrapContext(ctx context.Context, adapter Adapter) contextWrapper {
 		adapter: adapter,
 	}
 }
+
+// withPrimary returns context wrapper that routes read queries to primary database.
+func (cw contextWrapper) withPrimary() contextWrapper {
+	return contextWrapper{
+		ctx:     WithPrimary(cw.ctx),
+		adapter: cw.adapter,
+	}
+}
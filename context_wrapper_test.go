@@ -35,3 +35,24 @@ func TestContextWrapper(t *testing.T) {
 		assert.Equal(t, adapter, cw.adapter)
 	})
 }
+
+func TestWithPrimary(t *testing.T) {
+	var (
+		ctx = context.TODO()
+		cw  = fetchContext(ctx, &testAdapter{})
+	)
+
+	assert.False(t, UsePrimary(ctx))
+	assert.True(t, UsePrimary(WithPrimary(ctx)))
+	assert.True(t, UsePrimary(cw.withPrimary().ctx))
+	assert.Equal(t, cw.adapter, cw.withPrimary().adapter)
+}
+
+func TestWithMigration(t *testing.T) {
+	var (
+		ctx = context.TODO()
+	)
+
+	assert.False(t, IsMigration(ctx))
+	assert.True(t, IsMigration(WithMigration(ctx)))
+}
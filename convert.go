@@ -104,6 +104,22 @@ func convertAssign(dest, src interface{}) error {
 	case *interface{}:
 		*d = src
 		return nil
+	case *[]string:
+		if s, ok := src.([]byte); ok {
+			src = string(s)
+		}
+
+		if s, ok := src.(string); ok {
+			return parseStringArray(d, s)
+		}
+	case *[]int64:
+		if s, ok := src.([]byte); ok {
+			src = string(s)
+		}
+
+		if s, ok := src.(string); ok {
+			return parseInt64Array(d, s)
+		}
 	}
 
 	dpv := reflect.ValueOf(dest)
@@ -3,6 +3,9 @@ package rel
 import (
 	"database/sql"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Cursor is interface to work with database result (used by adapter).
@@ -14,6 +17,18 @@ type Cursor interface {
 	NopScanner() interface{} // TODO: conflict with manual scanners interface
 }
 
+// FieldType describes the type of result field reported by database driver, it's implemented by *sql.ColumnType.
+type FieldType interface {
+	ScanType() reflect.Type
+	DatabaseTypeName() string
+}
+
+// FieldTypesCursor is implemented by cursor that can report the type of its fields.
+// It's used to convert textual value returned by driver when scanning into map.
+type FieldTypesCursor interface {
+	FieldTypes() ([]FieldType, error)
+}
+
 func scanOne(cur Cursor, doc *Document) error {
 	defer cur.Close()
 
@@ -55,6 +70,184 @@ func scanAll(cur Cursor, col *Collection) error {
 	return nil
 }
 
+func scanMaps(cur Cursor, rows *[]map[string]interface{}) error {
+	defer cur.Close()
+
+	fields, err := cur.Fields()
+	if err != nil {
+		return err
+	}
+
+	var (
+		types []FieldType
+	)
+
+	if tc, ok := cur.(FieldTypesCursor); ok {
+		if types, err = tc.FieldTypes(); err != nil {
+			return err
+		}
+	}
+
+	*rows = []map[string]interface{}{}
+
+	for cur.Next() {
+		var (
+			row      = make(map[string]interface{}, len(fields))
+			values   = make([]interface{}, len(fields))
+			scanners = make([]interface{}, len(fields))
+		)
+
+		for i := range values {
+			scanners[i] = &values[i]
+		}
+
+		if err := cur.Scan(scanners...); err != nil {
+			return err
+		}
+
+		for i, field := range fields {
+			// bytes returned by driver may be reused, and textual value such as decimal is returned as bytes by some drivers.
+			if b, ok := values[i].([]byte); ok {
+				if i < len(types) {
+					values[i] = convertBytes(b, types[i])
+				} else {
+					values[i] = string(b)
+				}
+			}
+
+			row[field] = values[i]
+		}
+
+		*rows = append(*rows, row)
+	}
+
+	return nil
+}
+
+// convertBytes converts bytes returned by driver into value of the column's type.
+// Binary is copied as bytes, and value that can't be parsed is returned as string.
+func convertBytes(b []byte, typ FieldType) interface{} {
+	var (
+		str  = string(b)
+		name = strings.TrimPrefix(strings.ToUpper(typ.DatabaseTypeName()), "UNSIGNED ")
+	)
+
+	switch name {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "INT2", "INT4", "INT8", "YEAR":
+		return parseInt(str)
+	case "DECIMAL", "NUMERIC", "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8":
+		return parseFloat(str)
+	case "DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		return parseTime(str)
+	case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "BYTEA":
+		return append([]byte(nil), b...)
+	}
+
+	if st := typ.ScanType(); st != nil {
+		switch st.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return parseInt(str)
+		case reflect.Float32, reflect.Float64:
+			return parseFloat(str)
+		}
+	}
+
+	return str
+}
+
+func parseInt(str string) interface{} {
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return i
+	}
+
+	if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+		return u
+	}
+
+	return str
+}
+
+func parseFloat(str string) interface{} {
+	if f, err := strconv.ParseFloat(str, 64); err == nil {
+		return f
+	}
+
+	return str
+}
+
+// timeLayouts used to parse textual time, layouts with time zone are tried first so the offset is kept,
+// while time without zone is parsed as UTC.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTime(str string) interface{} {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t
+		}
+	}
+
+	return str
+}
+
+func scanEach(cur Cursor, col *Collection) error {
+	defer cur.Close()
+
+	fields, err := cur.Fields()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < col.Len() && cur.Next(); i++ {
+		var (
+			scanners = col.Get(i).Scanners(fields)
+		)
+
+		if err := cur.Scan(scanners...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanMatch scans each row into record in collection that has the same keys value, row that doesn't match any record is skipped.
+func scanMatch(cur Cursor, col *Collection, keys []string) error {
+	defer cur.Close()
+
+	fields, err := cur.Fields()
+	if err != nil {
+		return err
+	}
+
+	var (
+		index = indexCollection(col, keys[0])
+	)
+
+	for cur.Next() {
+		var (
+			row      = documentWithNaming(reflect.New(col.rt.Elem()), col.data.naming, false)
+			scanners = row.Scanners(fields)
+		)
+
+		if err := cur.Scan(scanners...); err != nil {
+			return err
+		}
+
+		if doc := matchDocument(col, index, row, keys); doc != nil {
+			copyFields(doc, row, fields)
+		}
+	}
+
+	return nil
+}
+
 func scanMulti(cur Cursor, keyField string, keyType reflect.Type, cols map[interface{}][]slice) error {
 	defer cur.Close()
 
@@ -75,8 +268,9 @@ func scanMulti(cur Cursor, keyField string, keyType reflect.Type, cols map[inter
 			keyScanners[i] = keyValue.Interface()
 		} else {
 			// need to create distinct copies
-			// otherwise next scan result will be corrupted
-			keyScanners[i] = &sql.RawBytes{}
+			// otherwise next scan result will be corrupted.
+			// raw bytes is avoided because the row is scanned twice.
+			keyScanners[i] = new(interface{})
 		}
 	}
 
@@ -101,6 +295,13 @@ func scanMulti(cur Cursor, keyField string, keyType reflect.Type, cols map[inter
 				scanners = doc.Scanners(fields)
 			)
 
+			// raw bytes holds the row until next is called, so it can't be used when the row is scanned more than once.
+			for i := range scanners {
+				if _, ok := scanners[i].(*sql.RawBytes); ok {
+					scanners[i] = new(interface{})
+				}
+			}
+
 			if err := cur.Scan(scanners...); err != nil {
 				return err
 			}
@@ -305,3 +305,118 @@ func TestScanMulti_fieldsError(t *testing.T) {
 	assert.Equal(t, err, scanMulti(cur, keyField, keyType, cols))
 	cur.AssertExpectations(t)
 }
+
+type testFieldType struct {
+	name string
+	scan reflect.Type
+}
+
+func (tft testFieldType) ScanType() reflect.Type {
+	return tft.scan
+}
+
+func (tft testFieldType) DatabaseTypeName() string {
+	return tft.name
+}
+
+type testFieldTypesCursor struct {
+	*testCursor
+	types []FieldType
+	err   error
+}
+
+func (tc testFieldTypesCursor) FieldTypes() ([]FieldType, error) {
+	return tc.types, tc.err
+}
+
+func TestScanMaps_fieldTypes(t *testing.T) {
+	var (
+		rows []map[string]interface{}
+		cur  = testFieldTypesCursor{
+			testCursor: &testCursor{},
+			types: []FieldType{
+				testFieldType{name: "VARCHAR"},
+				testFieldType{name: "DECIMAL"},
+				testFieldType{name: "DATETIME"},
+				testFieldType{name: "BIGINT"},
+			},
+		}
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"gender", "average", "latest", "total"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan([]byte("female"), []byte("10.50"), []byte("2020-01-02 03:04:05"), []byte("20")).Once()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, scanMaps(cur, &rows))
+	assert.Equal(t, []map[string]interface{}{
+		{"gender": "female", "average": 10.5, "latest": time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), "total": int64(20)},
+	}, rows)
+
+	cur.AssertExpectations(t)
+}
+
+func TestScanMaps_fieldTypesError(t *testing.T) {
+	var (
+		rows []map[string]interface{}
+		err  = errors.New("error")
+		cur  = testFieldTypesCursor{testCursor: &testCursor{}, err: err}
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"gender"}, nil).Once()
+
+	assert.Equal(t, err, scanMaps(cur, &rows))
+	cur.AssertExpectations(t)
+}
+
+func TestConvertBytes(t *testing.T) {
+	tests := []struct {
+		typ    FieldType
+		bytes  string
+		result interface{}
+	}{
+		{typ: testFieldType{name: "INT"}, bytes: "-10", result: int64(-10)},
+		{typ: testFieldType{name: "UNSIGNED BIGINT"}, bytes: "18446744073709551615", result: uint64(18446744073709551615)},
+		{typ: testFieldType{name: "NUMERIC"}, bytes: "1.25", result: 1.25},
+		{typ: testFieldType{name: "NUMERIC"}, bytes: "NaN?", result: "NaN?"},
+		{typ: testFieldType{name: "DATE"}, bytes: "2020-01-02", result: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{typ: testFieldType{name: "TIMESTAMP"}, bytes: "2020-01-02T03:04:05Z", result: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{typ: testFieldType{name: "DATETIME"}, bytes: "invalid", result: "invalid"},
+		{typ: testFieldType{name: "BLOB"}, bytes: "raw", result: []byte("raw")},
+		{typ: testFieldType{scan: reflect.TypeOf(0)}, bytes: "10", result: int64(10)},
+		{typ: testFieldType{scan: reflect.TypeOf(0.0)}, bytes: "1.5", result: 1.5},
+		{typ: testFieldType{name: "TEXT", scan: reflect.TypeOf("")}, bytes: "text", result: "text"},
+		{typ: testFieldType{name: "NULL"}, bytes: "10", result: "10"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typ.DatabaseTypeName()+" "+test.bytes, func(t *testing.T) {
+			assert.Equal(t, test.result, convertBytes([]byte(test.bytes), test.typ))
+		})
+	}
+}
+
+func TestConvertBytes_timeZone(t *testing.T) {
+	tests := []struct {
+		bytes  string
+		offset int
+	}{
+		{bytes: "2020-01-02 10:04:05+07:00", offset: 7 * 3600},
+		{bytes: "2020-01-02 10:04:05.123+07", offset: 7 * 3600},
+		{bytes: "2020-01-01 22:34:05-04:30", offset: -(4*3600 + 30*60)},
+		{bytes: "2020-01-02T10:04:05+07:00", offset: 7 * 3600},
+	}
+
+	for _, test := range tests {
+		t.Run(test.bytes, func(t *testing.T) {
+			result, ok := convertBytes([]byte(test.bytes), testFieldType{name: "TIMESTAMPTZ"}).(time.Time)
+			assert.True(t, ok)
+			assert.True(t, result.Truncate(time.Second).Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+			_, offset := result.Zone()
+			assert.Equal(t, test.offset, offset)
+		})
+	}
+}
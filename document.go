@@ -6,9 +6,6 @@ import (
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/jinzhu/inflection"
-	"github.com/serenize/snaker"
 )
 
 // DocumentFlag stores information about document as a flag.
@@ -28,6 +25,8 @@ const (
 	HasUpdatedAt
 	// HasDeletedAt flag.
 	HasDeletedAt
+	// HasLockVersion flag.
+	HasLockVersion
 )
 
 var (
@@ -48,21 +47,30 @@ type primary interface {
 	PrimaryValues() []interface{}
 }
 
+type namingKey struct {
+	rt     reflect.Type
+	naming NamingStrategy
+}
+
 type primaryData struct {
 	field []string
-	index []int
+	index [][]int
 }
 
 type documentData struct {
 	index        map[string]int
+	fieldIndex   map[string][]int
+	jsonField    map[string]bool
 	fields       []string
 	belongsTo    []string
 	hasOne       []string
 	hasMany      []string
 	primaryField []string
-	primaryIndex []int
+	primaryIndex [][]int
 	preload      []string
 	flag         DocumentFlag
+	flagField    map[DocumentFlag]string
+	naming       NamingStrategy
 }
 
 // Document provides an abstraction over reflect to easily works with struct for database purpose.
@@ -85,7 +93,7 @@ func (d Document) Table() string {
 	}
 
 	// TODO: handle anonymous struct
-	return tableName(d.rt)
+	return tableName(d.rt, d.data.naming)
 }
 
 // PrimaryFields column name of this document.
@@ -126,7 +134,7 @@ func (d Document) PrimaryValues() []interface{} {
 	)
 
 	for i := range pValues {
-		pValues[i] = d.rv.Field(d.data.primaryIndex[i]).Interface()
+		pValues[i] = fieldByIndex(d.rv, d.data.primaryIndex[i], false).Interface()
 	}
 
 	return pValues
@@ -158,6 +166,7 @@ func (d Document) Persisted() bool {
 }
 
 // Index returns map of column name and it's struct index.
+// Fields flattened from embedded struct are not included since they don't have a single struct index.
 func (d Document) Index() map[string]int {
 	return d.data.index
 }
@@ -169,9 +178,9 @@ func (d Document) Fields() []string {
 
 // Type returns reflect.Type of given field. if field does not exist, second returns value will be false.
 func (d Document) Type(field string) (reflect.Type, bool) {
-	if i, ok := d.data.index[field]; ok {
+	if index, ok := d.data.fieldIndex[field]; ok {
 		var (
-			ft = d.rt.Field(i).Type
+			ft = d.rt.FieldByIndex(index).Type
 		)
 
 		if ft.Kind() == reflect.Ptr {
@@ -188,14 +197,17 @@ func (d Document) Type(field string) (reflect.Type, bool) {
 
 // Value returns value of given field. if field does not exist, second returns value will be false.
 func (d Document) Value(field string) (interface{}, bool) {
-	if i, ok := d.data.index[field]; ok {
+	if index, ok := d.data.fieldIndex[field]; ok {
 		var (
 			value interface{}
-			fv    = d.rv.Field(i)
-			ft    = fv.Type()
+			fv    = fieldByIndex(d.rv, index, false)
 		)
 
-		if ft.Kind() == reflect.Ptr {
+		if !fv.IsValid() {
+			return nil, true
+		}
+
+		if fv.Kind() == reflect.Ptr {
 			if !fv.IsNil() {
 				value = fv.Elem().Interface()
 			}
@@ -211,11 +223,11 @@ func (d Document) Value(field string) (interface{}, bool) {
 
 // SetValue of the field, it returns false if field does not exist, or it's not assignable.
 func (d Document) SetValue(field string, value interface{}) bool {
-	if i, ok := d.data.index[field]; ok {
+	if index, ok := d.data.fieldIndex[field]; ok {
 		var (
 			rv reflect.Value
 			rt reflect.Type
-			fv = d.rv.Field(i)
+			fv = fieldByIndex(d.rv, index, true)
 			ft = fv.Type()
 		)
 
@@ -282,17 +294,21 @@ func (d Document) Scanners(fields []string) []interface{} {
 	)
 
 	for index, field := range fields {
-		if structIndex, ok := d.data.index[field]; ok {
+		if structIndex, ok := d.data.fieldIndex[field]; ok {
 			var (
-				fv = d.rv.Field(structIndex)
+				fv = fieldByIndex(d.rv, structIndex, true)
 				ft = fv.Type()
 			)
 
-			if ft.Kind() == reflect.Ptr {
+			if d.data.jsonField[field] {
+				result[index] = jsonValue{v: fv.Addr().Interface()}
+			} else if ft.Kind() == reflect.Ptr {
 				result[index] = fv.Addr().Interface()
 			} else {
 				result[index] = Nullable(fv.Addr().Interface())
 			}
+		} else if assoc, ok := d.associationField(field); ok {
+			result[index] = assoc
 		} else {
 			result[index] = &sql.RawBytes{}
 		}
@@ -301,6 +317,89 @@ func (d Document) Scanners(fields []string) []interface{} {
 	return result
 }
 
+// mutateValue returns value to be saved into the field, value of json field is wrapped so it's encoded as json.
+func (d Document) mutateValue(field string, value interface{}) interface{} {
+	if d.data.jsonField[field] {
+		return jsonValue{v: value}
+	}
+
+	return value
+}
+
+// associationField returns scanner for dot separated field of belongs to or has one association.
+func (d Document) associationField(field string) (interface{}, bool) {
+	i := strings.IndexByte(field, '.')
+	if i < 0 {
+		return nil, false
+	}
+
+	var (
+		name = field[:i]
+	)
+
+	if _, ok := d.data.index[name]; !ok || !d.isSingleAssociation(name) {
+		return nil, false
+	}
+
+	return associationScanner{doc: d, name: name, field: field[i+1:]}, true
+}
+
+// associationScanner scans populated field of belongs to or has one association.
+// Association pointer is only allocated once non null value is scanned, so association that isn't matched by left join
+// is left nil.
+type associationScanner struct {
+	doc   Document
+	name  string
+	field string
+}
+
+// Scan implements sql.Scanner.
+func (as associationScanner) Scan(src interface{}) error {
+	if fv := fieldByIndex(as.doc.rv, as.doc.data.fieldIndex[as.name], false); src == nil && (!fv.IsValid() || (fv.Kind() == reflect.Ptr && fv.IsNil())) {
+		return nil
+	}
+
+	var (
+		doc, _  = as.doc.Association(as.name).Document()
+		scanner = doc.Scanners([]string{as.field})[0]
+	)
+
+	if s, ok := scanner.(sql.Scanner); ok {
+		return s.Scan(src)
+	}
+
+	return convertAssign(scanner, src)
+}
+
+// isAssociation returns true when name is an association of the document.
+func (d documentData) isAssociation(name string) bool {
+	for _, assocs := range [][]string{d.belongsTo, d.hasOne, d.hasMany} {
+		for _, assoc := range assocs {
+			if assoc == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (d Document) isSingleAssociation(name string) bool {
+	for _, assoc := range d.data.belongsTo {
+		if assoc == name {
+			return true
+		}
+	}
+
+	for _, assoc := range d.data.hasOne {
+		if assoc == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 // BelongsTo fields of this document.
 func (d Document) BelongsTo() []string {
 	return d.data.belongsTo
@@ -311,7 +410,7 @@ func (d Document) HasOne() []string {
 	return d.data.hasOne
 }
 
-// HasMany fields of this document.
+// HasMany fields of this document, including many to many association.
 func (d Document) HasMany() []string {
 	return d.data.hasMany
 }
@@ -328,7 +427,7 @@ func (d Document) Association(name string) Association {
 		panic("rel: no field named (" + name + ") in type " + d.rt.String() + " found ")
 	}
 
-	return newAssociation(d.rv, index)
+	return newAssociation(d.rv, index, d.data.naming)
 }
 
 // Reset this document, this is a noop for compatibility with collection.
@@ -357,22 +456,32 @@ func (d Document) Flag(flag DocumentFlag) bool {
 
 // NewDocument used to create abstraction to work with struct.
 // Document can be created using interface or reflect.Value.
+// Table and column names are inferred using DefaultNamingStrategy.
 func NewDocument(record interface{}, readonly ...bool) *Document {
+	return documentWithNaming(record, DefaultNamingStrategy{}, len(readonly) > 0 && readonly[0])
+}
+
+func documentWithNaming(record interface{}, naming NamingStrategy, readonly bool) *Document {
 	switch v := record.(type) {
 	case *Document:
+		// document created outside repository is rewrapped, so it uses the naming strategy of the repository.
+		if v.data.naming != naming {
+			return newDocument(v.v, reflect.ValueOf(v.v), naming, readonly)
+		}
+
 		return v
 	case reflect.Value:
-		return newDocument(v.Interface(), v, len(readonly) > 0 && readonly[0])
+		return newDocument(v.Interface(), v, naming, readonly)
 	case reflect.Type:
 		panic("rel: cannot use reflect.Type")
 	case nil:
 		panic("rel: cannot be nil")
 	default:
-		return newDocument(v, reflect.ValueOf(v), len(readonly) > 0 && readonly[0])
+		return newDocument(v, reflect.ValueOf(v), naming, readonly)
 	}
 }
 
-func newDocument(v interface{}, rv reflect.Value, readonly bool) *Document {
+func newDocument(v interface{}, rv reflect.Value, naming NamingStrategy, readonly bool) *Document {
 	var (
 		rt = rv.Type()
 	)
@@ -394,18 +503,25 @@ func newDocument(v interface{}, rv reflect.Value, readonly bool) *Document {
 		v:    v,
 		rv:   rv,
 		rt:   rt,
-		data: extractDocumentData(rt, false),
+		data: extractDocumentData(rt, naming, false),
 	}
 }
 
-func extractDocumentData(rt reflect.Type, skipAssoc bool) documentData {
-	if data, cached := documentDataCache.Load(rt); cached {
+func extractDocumentData(rt reflect.Type, naming NamingStrategy, skipAssoc bool) documentData {
+	var (
+		key = namingKey{rt: rt, naming: naming}
+	)
+
+	if data, cached := documentDataCache.Load(key); cached {
 		return data.(documentData)
 	}
 
 	var (
-		data = documentData{
-			index: make(map[string]int, rt.NumField()),
+		embedded []int
+		data     = documentData{
+			index:      make(map[string]int, rt.NumField()),
+			fieldIndex: make(map[string][]int, rt.NumField()),
+			naming:     naming,
 		}
 	)
 
@@ -414,40 +530,51 @@ func extractDocumentData(rt reflect.Type, skipAssoc bool) documentData {
 		var (
 			sf   = rt.Field(i)
 			typ  = sf.Type
-			name = fieldName(sf)
+			name = fieldName(sf, naming)
 		)
 
+		if _, ok := embeddedPrefix(sf); ok {
+			embedded = append(embedded, i)
+			continue
+		}
+
 		if c := sf.Name[0]; c < 'A' || c > 'Z' || name == "" {
 			continue
 		}
 
 		data.index[name] = i
+		data.fieldIndex[name] = sf.Index
+
+		if _, ok := fieldTagOption(sf, "json"); ok {
+			data.addJSONField(name)
+			continue
+		}
 
 		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Interface || typ.Kind() == reflect.Slice {
 			typ = typ.Elem()
 		}
 
-		if typ.Kind() != reflect.Struct {
+		if flag := extractFlag(typ, sf.Name, name); flag != Invalid {
 			data.fields = append(data.fields, name)
+			data.addFlag(flag, name)
 			continue
 		}
 
-		if flag := extractFlag(typ, name); flag != Invalid {
+		if typ.Kind() != reflect.Struct {
 			data.fields = append(data.fields, name)
-			data.flag |= flag
 			continue
 		}
 
 		// struct without primary key is a field
 		// TODO: test by scanner/valuer instead?
-		if pk, _ := searchPrimary(typ); len(pk) == 0 {
+		if pk, _ := searchPrimary(typ, naming); len(pk) == 0 {
 			data.fields = append(data.fields, name)
 			continue
 		}
 
 		if !skipAssoc {
 			var (
-				assocData = extractAssociationData(rt, i)
+				assocData = extractAssociationData(rt, i, naming)
 			)
 
 			switch assocData.typ {
@@ -455,7 +582,7 @@ func extractDocumentData(rt reflect.Type, skipAssoc bool) documentData {
 				data.belongsTo = append(data.belongsTo, name)
 			case HasOne:
 				data.hasOne = append(data.hasOne, name)
-			case HasMany:
+			case HasMany, ManyToMany:
 				data.hasMany = append(data.hasMany, name)
 			}
 
@@ -465,34 +592,51 @@ func extractDocumentData(rt reflect.Type, skipAssoc bool) documentData {
 		}
 	}
 
-	data.primaryField, data.primaryIndex = searchPrimary(rt)
+	// fields declared directly on the struct takes precedence over embedded fields.
+	for _, i := range embedded {
+		flattenEmbedded(&data, rt.Field(i), []int{i}, "", naming)
+	}
+
+	data.primaryField, data.primaryIndex = searchPrimary(rt, naming)
 
 	if !skipAssoc {
-		documentDataCache.Store(rt, data)
+		documentDataCache.Store(key, data)
 	}
 
 	return data
 }
 
-func extractFlag(rt reflect.Type, name string) DocumentFlag {
+// extractFlag returns flag of timestamp, soft delete and lock version field.
+// Field is detected using its go name, so it doesn't depend on naming strategy, or using its column name when it's named using db tag.
+func extractFlag(rt reflect.Type, fieldName string, name string) DocumentFlag {
 	flag := Invalid
+	if fieldName == "LockVersion" || name == "lock_version" {
+		switch rt.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			flag = HasLockVersion
+		}
+
+		return flag
+	}
+
 	if rt != rtTime {
 		return flag
 	}
 
-	switch name {
-	case "created_at", "inserted_at":
+	switch {
+	case fieldName == "CreatedAt" || fieldName == "InsertedAt" || name == "created_at" || name == "inserted_at":
 		flag = HasCreatedAt
-	case "updated_at":
+	case fieldName == "UpdatedAt" || name == "updated_at":
 		flag = HasUpdatedAt
-	case "deleted_at":
+	case fieldName == "DeletedAt" || name == "deleted_at":
 		flag = HasDeletedAt
 	}
 
 	return flag
 }
 
-func fieldName(sf reflect.StructField) string {
+func fieldName(sf reflect.StructField, naming NamingStrategy) string {
 	if tag := sf.Tag.Get("db"); tag != "" {
 		name := strings.Split(tag, ",")[0]
 
@@ -505,18 +649,190 @@ func fieldName(sf reflect.StructField) string {
 		}
 	}
 
-	return snaker.CamelToSnake(sf.Name)
+	return naming.Column(sf.Name)
+}
+
+// addFlag sets the flag and stores the column name of the flagged field, only the first field is used when the flag is already set.
+func (data *documentData) addFlag(flag DocumentFlag, name string) {
+	if data.flag.Is(flag) {
+		return
+	}
+
+	if data.flagField == nil {
+		data.flagField = make(map[DocumentFlag]string)
+	}
+
+	data.flag |= flag
+	data.flagField[flag] = name
+}
+
+// flagFieldName returns column name of the field that sets the flag.
+func (data documentData) flagFieldName(flag DocumentFlag) string {
+	return data.flagField[flag]
+}
+
+// addJSONField adds field that is encoded as json when saved to database.
+func (data *documentData) addJSONField(name string) {
+	if data.jsonField == nil {
+		data.jsonField = make(map[string]bool)
+	}
+
+	data.fields = append(data.fields, name)
+	data.jsonField[name] = true
 }
 
-func searchPrimary(rt reflect.Type) ([]string, []int) {
-	if result, cached := primariesCache.Load(rt); cached {
+// flattenEmbedded adds fields of embedded struct to document data, field that already exists is shadowed and skipped.
+// Association declared inside embedded struct is not supported and ignored.
+func flattenEmbedded(data *documentData, sf reflect.StructField, index []int, prefix string, naming NamingStrategy) {
+	var (
+		rt       = sf.Type
+		embedded []int
+	)
+
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	if p, _ := embeddedPrefix(sf); p != "" {
+		prefix += p
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		var (
+			sf   = rt.Field(i)
+			typ  = sf.Type
+			name = fieldName(sf, naming)
+		)
+
+		if _, ok := embeddedPrefix(sf); ok {
+			embedded = append(embedded, i)
+			continue
+		}
+
+		if c := sf.Name[0]; c < 'A' || c > 'Z' || name == "" {
+			continue
+		}
+
+		name = prefix + name
+		if _, exist := data.fieldIndex[name]; exist {
+			continue
+		}
+
+		if _, ok := fieldTagOption(sf, "json"); ok {
+			data.fieldIndex[name] = append(index[:len(index):len(index)], i)
+			data.addJSONField(name)
+			continue
+		}
+
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Interface || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+
+		flag := extractFlag(typ, sf.Name, name)
+		if flag == Invalid && typ.Kind() == reflect.Struct {
+			if pk, _ := searchPrimary(typ, naming); len(pk) != 0 {
+				continue
+			}
+		}
+
+		data.fieldIndex[name] = append(index[:len(index):len(index)], i)
+		data.fields = append(data.fields, name)
+
+		if flag != Invalid {
+			data.addFlag(flag, name)
+		}
+	}
+
+	for _, i := range embedded {
+		flattenEmbedded(data, rt.Field(i), append(index[:len(index):len(index)], i), prefix, naming)
+	}
+}
+
+// embeddedPrefix returns column prefix of struct field that is flattened into its parent.
+// Anonymous struct is flattened unless it's named explicitly using db tag, while named struct is flattened when prefix option is defined.
+func embeddedPrefix(sf reflect.StructField) (string, bool) {
+	var (
+		typ = sf.Type
+		ptr = typ.Kind() == reflect.Ptr
+	)
+
+	if ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || typ == rtTime {
+		return "", false
+	}
+
+	if _, ok := fieldTagOption(sf, "json"); ok {
+		return "", false
+	}
+
+	// fields of unexported struct are only settable when it's embedded by value.
+	if sf.PkgPath != "" && (!sf.Anonymous || ptr) {
+		return "", false
+	}
+
+	if prefix, ok := fieldTagOption(sf, "prefix"); ok {
+		return prefix, true
+	}
+
+	return "", sf.Anonymous && strings.Split(sf.Tag.Get("db"), ",")[0] == ""
+}
+
+// fieldTagOption returns value of option declared in db tag, for example prefix option in `db:",prefix=address_"`.
+func fieldTagOption(sf reflect.StructField, option string) (string, bool) {
+	options := strings.Split(sf.Tag.Get("db"), ",")
+
+	for _, opt := range options[1:] {
+		if opt == option {
+			return "", true
+		}
+
+		if strings.HasPrefix(opt, option+"=") {
+			return opt[len(option)+1:], true
+		}
+	}
+
+	return "", false
+}
+
+// fieldByIndex returns nested field of struct by index.
+// Nil embedded pointer is allocated when alloc is true, otherwise invalid value is returned.
+func fieldByIndex(rv reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !alloc {
+					return reflect.Value{}
+				}
+
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(x)
+	}
+
+	return rv
+}
+
+func searchPrimary(rt reflect.Type, naming NamingStrategy) ([]string, [][]int) {
+	var (
+		key = namingKey{rt: rt, naming: naming}
+	)
+
+	if result, cached := primariesCache.Load(key); cached {
 		p := result.(primaryData)
 		return p.field, p.index
 	}
 
 	var (
 		field         []string
-		index         []int
+		index         [][]int
+		embedded      []int
 		fallbackIndex = -1
 	)
 
@@ -532,8 +848,8 @@ func searchPrimary(rt reflect.Type) ([]string, []int) {
 			sf := rt.Field(i)
 
 			if tag := sf.Tag.Get("db"); strings.HasSuffix(tag, ",primary") {
-				index = append(index, i)
-				field = append(field, fieldName(sf))
+				index = append(index, sf.Index)
+				field = append(field, fieldName(sf, naming))
 				continue
 			}
 
@@ -541,15 +857,41 @@ func searchPrimary(rt reflect.Type) ([]string, []int) {
 			if strings.EqualFold("id", sf.Name) {
 				fallbackIndex = i
 			}
+
+			// primary key can be declared in embedded struct without prefix, for example a shared base model.
+			if prefix, ok := embeddedPrefix(sf); ok && prefix == "" && sf.Type.Kind() == reflect.Struct {
+				embedded = append(embedded, i)
+			}
 		}
 	}
 
 	if len(field) == 0 && fallbackIndex >= 0 {
-		field = []string{"id"}
-		index = []int{fallbackIndex}
+		field = []string{fieldName(rt.Field(fallbackIndex), naming)}
+		index = [][]int{{fallbackIndex}}
+	}
+
+	for _, i := range embedded {
+		if len(field) != 0 {
+			break
+		}
+
+		var (
+			embeddedField, embeddedIndex = searchPrimary(rt.Field(i).Type, naming)
+		)
+
+		// embedded struct that implements primary interface is promoted to the parent.
+		if embeddedIndex == nil {
+			continue
+		}
+
+		field = embeddedField
+		index = make([][]int, len(embeddedIndex))
+		for j := range embeddedIndex {
+			index[j] = append([]int{i}, embeddedIndex[j]...)
+		}
 	}
 
-	primariesCache.Store(rt, primaryData{
+	primariesCache.Store(key, primaryData{
 		field: field,
 		index: index,
 	})
@@ -557,16 +899,27 @@ func searchPrimary(rt reflect.Type) ([]string, []int) {
 	return field, index
 }
 
-func tableName(rt reflect.Type) string {
+func tableName(rt reflect.Type, naming NamingStrategy) string {
+	var (
+		key = namingKey{rt: rt, naming: naming}
+	)
+
 	// check for cache
-	if name, cached := tablesCache.Load(rt); cached {
+	if name, cached := tablesCache.Load(key); cached {
 		return name.(string)
 	}
 
-	name := inflection.Plural(rt.Name())
-	name = snaker.CamelToSnake(name)
-
-	tablesCache.Store(rt, name)
+	name := naming.Table(rt.Name())
+	tablesCache.Store(key, name)
 
 	return name
 }
+
+// typeTableName returns table name of struct type, respecting custom table name defined using Table method.
+func typeTableName(rt reflect.Type, naming NamingStrategy) string {
+	if tn, ok := reflect.New(rt).Interface().(table); ok {
+		return tn.Table()
+	}
+
+	return tableName(rt, naming)
+}
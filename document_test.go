@@ -47,7 +47,7 @@ func TestDocument_Table(t *testing.T) {
 	assert.Equal(t, "users", doc.Table())
 
 	// cached
-	_, cached := tablesCache.Load(rt)
+	_, cached := tablesCache.Load(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 	assert.True(t, cached)
 }
 
@@ -62,7 +62,7 @@ func TestDocument_Table_usingInterface(t *testing.T) {
 	assert.Equal(t, "_items", doc.Table())
 
 	// never cache
-	_, cached := tablesCache.Load(rt)
+	_, cached := tablesCache.Load(namingKey{rt: rt, naming: DefaultNamingStrategy{}})
 	assert.False(t, cached)
 }
 
@@ -182,6 +182,24 @@ func TestDocument_Fields(t *testing.T) {
 	assert.Equal(t, fields, doc.Fields())
 }
 
+func TestDocument_Flag(t *testing.T) {
+	var (
+		record = struct {
+			ID          int
+			LockVersion int
+			CreatedAt   time.Time
+			DeletedAt   *time.Time
+		}{}
+		doc = NewDocument(&record)
+	)
+
+	assert.True(t, doc.Flag(HasLockVersion))
+	assert.True(t, doc.Flag(HasCreatedAt))
+	assert.True(t, doc.Flag(HasDeletedAt))
+	assert.False(t, doc.Flag(HasUpdatedAt))
+	assert.Equal(t, []string{"id", "lock_version", "created_at", "deleted_at"}, doc.Fields())
+}
+
 func TestDocument_Index(t *testing.T) {
 	var (
 		record = struct {
@@ -505,3 +523,176 @@ func TestDocument_notPtrOfStruct(t *testing.T) {
 		NewDocument(&i).Table()
 	})
 }
+
+type Timestamps struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Audit struct {
+	CreatedBy string
+	DeletedAt *time.Time
+}
+
+type Location struct {
+	Street string
+	City   string
+}
+
+type BaseModel struct {
+	ID int
+	Timestamps
+}
+
+type Place struct {
+	BaseModel
+	*Audit
+	Name     string
+	Street   string
+	Address  Location `db:",prefix=address_"`
+	Location Location
+}
+
+func TestDocument_embedded(t *testing.T) {
+	var (
+		now    = time.Now()
+		record = Place{
+			BaseModel: BaseModel{ID: 1, Timestamps: Timestamps{CreatedAt: now}},
+			Name:      "name",
+			Street:    "street",
+			Address:   Location{Street: "address street", City: "address city"},
+		}
+		doc = NewDocument(&record)
+	)
+
+	assert.Equal(t, "places", doc.Table())
+	assert.Equal(t, "id", doc.PrimaryField())
+	assert.Equal(t, 1, doc.PrimaryValue())
+	assert.Equal(t, []string{"name", "street", "location", "id", "created_at", "updated_at", "created_by", "deleted_at", "address_street", "address_city"}, doc.Fields())
+	assert.True(t, doc.Flag(HasCreatedAt))
+	assert.True(t, doc.Flag(HasUpdatedAt))
+	assert.True(t, doc.Flag(HasDeletedAt))
+	assert.NotContains(t, doc.Index(), "id")
+
+	t.Run("Type", func(t *testing.T) {
+		typ, ok := doc.Type("created_at")
+		assert.True(t, ok)
+		assert.Equal(t, reflect.TypeOf(time.Time{}), typ)
+
+		typ, ok = doc.Type("deleted_at")
+		assert.True(t, ok)
+		assert.Equal(t, reflect.TypeOf(time.Time{}), typ)
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		value, ok := doc.Value("created_at")
+		assert.True(t, ok)
+		assert.Equal(t, now, value)
+
+		value, ok = doc.Value("address_city")
+		assert.True(t, ok)
+		assert.Equal(t, "address city", value)
+
+		// named struct is flattened using prefix, so it doesn't conflict with outer field.
+		value, ok = doc.Value("street")
+		assert.True(t, ok)
+		assert.Equal(t, "street", value)
+
+		// nil embedded pointer.
+		value, ok = doc.Value("created_by")
+		assert.True(t, ok)
+		assert.Nil(t, value)
+		assert.Nil(t, record.Audit)
+	})
+
+	t.Run("SetValue", func(t *testing.T) {
+		assert.True(t, doc.SetValue("updated_at", now))
+		assert.True(t, doc.SetValue("address_street", "new street"))
+		assert.True(t, doc.SetValue("created_by", "admin"))
+
+		assert.Equal(t, now, record.UpdatedAt)
+		assert.Equal(t, "new street", record.Address.Street)
+		assert.Equal(t, "admin", record.Audit.CreatedBy)
+	})
+
+	t.Run("Scanners", func(t *testing.T) {
+		record.Audit = nil
+
+		var (
+			scanners = doc.Scanners([]string{"id", "created_at", "address_city", "deleted_at"})
+		)
+
+		assert.NotNil(t, record.Audit)
+		assert.Equal(t, []interface{}{
+			Nullable(&record.ID),
+			Nullable(&record.CreatedAt),
+			Nullable(&record.Address.City),
+			&record.Audit.DeletedAt,
+		}, scanners)
+	})
+}
+
+func TestDocument_embeddedShadowed(t *testing.T) {
+	var (
+		record = struct {
+			ID int
+			Timestamps
+			CreatedAt string
+		}{
+			Timestamps: Timestamps{CreatedAt: time.Now()},
+			CreatedAt:  "shadowed",
+		}
+		doc = NewDocument(&record)
+	)
+
+	assert.Equal(t, []string{"id", "created_at", "updated_at"}, doc.Fields())
+	assert.False(t, doc.Flag(HasCreatedAt))
+	assert.True(t, doc.Flag(HasUpdatedAt))
+
+	value, ok := doc.Value("created_at")
+	assert.True(t, ok)
+	assert.Equal(t, "shadowed", value)
+}
+
+func TestCollection_embedded(t *testing.T) {
+	var (
+		records = []Place{
+			{BaseModel: BaseModel{ID: 1}},
+			{BaseModel: BaseModel{ID: 2}},
+		}
+		col = NewCollection(&records)
+	)
+
+	assert.Equal(t, "id", col.PrimaryField())
+	assert.Equal(t, []interface{}{1, 2}, col.PrimaryValue())
+}
+
+type Setting struct {
+	ID      int
+	Name    string
+	Data    map[string]interface{} `db:",json"`
+	Tags    []string               `db:"labels,json"`
+	Owner   *User                  `db:",json"`
+	Details struct {
+		Theme string
+	} `db:",json"`
+}
+
+func TestDocument_json(t *testing.T) {
+	var (
+		record = Setting{ID: 1, Tags: []string{"go"}}
+		doc    = NewDocument(&record)
+	)
+
+	assert.Equal(t, []string{"id", "name", "data", "labels", "owner", "details"}, doc.Fields())
+	assert.Nil(t, doc.BelongsTo())
+	assert.Equal(t, []interface{}{
+		Nullable(&record.ID),
+		jsonValue{v: &record.Data},
+		jsonValue{v: &record.Tags},
+		jsonValue{v: &record.Owner},
+	}, doc.Scanners([]string{"id", "data", "labels", "owner"}))
+
+	assert.Equal(t, jsonValue{v: []string{"go"}}, doc.mutateValue("labels", record.Tags))
+	assert.Equal(t, 1, doc.mutateValue("id", 1))
+}
@@ -1,9 +1,16 @@
 package rel
 
+import (
+	"errors"
+)
+
 var (
 	// ErrNotFound returned when records not found.
 	ErrNotFound = NotFoundError{}
 
+	// ErrStaleRecord returned when record is modified or deleted by other process since it's loaded.
+	ErrStaleRecord = StaleRecordError{}
+
 	// ErrCheckConstraint is an auxiliary variable for error handling.
 	// This is only to be used when checking error with errors.Is(err, ErrCheckConstraint).
 	ErrCheckConstraint = ConstraintError{Type: CheckConstraint}
@@ -23,6 +30,10 @@ var (
 	// ErrForeignKeyConstraint is an auxiliary variable for error handling.
 	// This is only to be used when checking error with errors.Is(err, ErrForeignKeyConstraint).
 	ErrForeignKeyConstraint = ConstraintError{Type: ForeignKeyConstraint}
+
+	// ErrNotSupported returned by adapter when optional operation is not supported by the underlying database.
+	// Repository falls back to the basic operation when it's returned by a returning adapter.
+	ErrNotSupported = errors.New("rel: operation is not supported by adapter")
 )
 
 // NotFoundError returned whenever Find returns no result.
@@ -33,6 +44,24 @@ func (nfe NotFoundError) Error() string {
 	return "Record not found"
 }
 
+// StaleRecordError returned whenever update or delete using optimistic locking doesn't match any record.
+type StaleRecordError struct{}
+
+// Error message.
+func (sre StaleRecordError) Error() string {
+	return "Record is stale"
+}
+
+// IrreversibleError returned when reverse of a migration can't be derived, the down migration needs to be defined explicitly.
+type IrreversibleError struct {
+	Migration string
+}
+
+// Error message.
+func (ie IrreversibleError) Error() string {
+	return "Migration is irreversible: " + ie.Migration
+}
+
 // ConstraintType defines the type of constraint error.
 type ConstraintType int8
 
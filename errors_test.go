@@ -11,6 +11,14 @@ func TestNoResultError(t *testing.T) {
 	assert.Equal(t, "Record not found", NotFoundError{}.Error())
 }
 
+func TestStaleRecordError(t *testing.T) {
+	assert.Equal(t, "Record is stale", StaleRecordError{}.Error())
+}
+
+func TestIrreversibleError(t *testing.T) {
+	assert.Equal(t, "Migration is irreversible: drop table users", IrreversibleError{Migration: "drop table users"}.Error())
+}
+
 func TestConstraintType(t *testing.T) {
 	assert.Equal(t, "CheckConstraint", CheckConstraint.String())
 	assert.Equal(t, "NotNullConstraint", NotNullConstraint.String())
@@ -2,6 +2,7 @@ package rel
 
 import (
 	"errors"
+	"reflect"
 )
 
 // FilterOp defines enumeration of all supported filter types.
@@ -46,6 +47,16 @@ const (
 
 	// FilterFragmentOp is filter type for custom filter.
 	FilterFragmentOp
+
+	// FilterExistsOp is filter type for exists subquery.
+	FilterExistsOp
+	// FilterNotExistsOp is filter type for not exists subquery.
+	FilterNotExistsOp
+
+	// FilterJSONContainsOp is filter type for json contains check.
+	FilterJSONContainsOp
+	// FilterJSONExtractOp is filter type for comparison of value extracted from json path.
+	FilterJSONExtractOp
 )
 
 // FilterQuery defines details of a coundition type.
@@ -292,6 +303,8 @@ func Not(inner ...FilterQuery) FilterQuery {
 			fq.Type = FilterNinOp
 		case FilterLikeOp:
 			fq.Type = FilterNotLikeOp
+		case FilterExistsOp:
+			fq.Type = FilterNotExistsOp
 		default:
 			return FilterQuery{
 				Type:  FilterNotOp,
@@ -379,6 +392,7 @@ func NotNil(field string) FilterQuery {
 }
 
 // In check whethers value of the field is included in values.
+// A single Query value is treated as subquery.
 func In(field string, values ...interface{}) FilterQuery {
 	return FilterQuery{
 		Type:  FilterInOp,
@@ -427,6 +441,7 @@ func InString(field string, values []string) FilterQuery {
 }
 
 // Nin check whethers value of the field is not included in values.
+// A single Query value is treated as subquery.
 func Nin(field string, values ...interface{}) FilterQuery {
 	return FilterQuery{
 		Type:  FilterNinOp,
@@ -492,6 +507,86 @@ func NotLike(field string, pattern string) FilterQuery {
 	}
 }
 
+// Exists check whethers subquery returns any rows.
+func Exists(query Query) FilterQuery {
+	return FilterQuery{
+		Type:  FilterExistsOp,
+		Value: query,
+	}
+}
+
+// NotExists check whethers subquery returns no rows.
+func NotExists(query Query) FilterQuery {
+	return FilterQuery{
+		Type:  FilterNotExistsOp,
+		Value: query,
+	}
+}
+
+// JSONContains checks whether json field contains given value, the value is encoded as json.
+// On sqlite, only scalar element of json array is supported.
+func JSONContains(field string, value interface{}) FilterQuery {
+	return FilterQuery{
+		Type:  FilterJSONContainsOp,
+		Field: field,
+		Value: value,
+	}
+}
+
+// JSONPath refers to a value inside json field.
+type JSONPath struct {
+	Field string
+	Path  string
+}
+
+// Eq compares value at json path to be equal.
+func (jp JSONPath) Eq(value interface{}) FilterQuery {
+	return jp.compare(FilterEqOp, value)
+}
+
+// Ne compares value at json path to be not equal.
+func (jp JSONPath) Ne(value interface{}) FilterQuery {
+	return jp.compare(FilterNeOp, value)
+}
+
+// Lt compares value at json path to be less than.
+func (jp JSONPath) Lt(value interface{}) FilterQuery {
+	return jp.compare(FilterLtOp, value)
+}
+
+// Lte compares value at json path to be less than or equal.
+func (jp JSONPath) Lte(value interface{}) FilterQuery {
+	return jp.compare(FilterLteOp, value)
+}
+
+// Gt compares value at json path to be greater than.
+func (jp JSONPath) Gt(value interface{}) FilterQuery {
+	return jp.compare(FilterGtOp, value)
+}
+
+// Gte compares value at json path to be greater than or equal.
+func (jp JSONPath) Gte(value interface{}) FilterQuery {
+	return jp.compare(FilterGteOp, value)
+}
+
+// compare builds json extract filter, the comparison is stored as inner filter with json path as its field.
+func (jp JSONPath) compare(op FilterOp, value interface{}) FilterQuery {
+	return FilterQuery{
+		Type:  FilterJSONExtractOp,
+		Field: jp.Field,
+		Inner: []FilterQuery{{Type: op, Field: jp.Path, Value: value}},
+	}
+}
+
+// JSONExtract refers to a value inside json field using dot separated path, for example address.city or tags.0.
+// The value can be compared using its methods, for example JSONExtract("data", "address.city").Eq("Jakarta").
+func JSONExtract(field string, path string) JSONPath {
+	return JSONPath{
+		Field: field,
+		Path:  path,
+	}
+}
+
 // FilterFragment add custom filter.
 func FilterFragment(expr string, values ...interface{}) FilterQuery {
 	return FilterQuery{
@@ -510,6 +605,34 @@ func filterDocument(doc *Document) FilterQuery {
 	return filterDocumentPrimary(pFields, pValues, FilterEqOp)
 }
 
+// filterLockVersion returns filter that matches current lock version of the document and the incremented version.
+func filterLockVersion(doc *Document) (FilterQuery, interface{}) {
+	var (
+		field      = doc.data.flagFieldName(HasLockVersion)
+		ft, _      = doc.Type(field)
+		current, _ = doc.Value(field)
+		next       = reflect.New(ft).Elem()
+	)
+
+	if current == nil {
+		next.Set(reflect.ValueOf(1).Convert(ft))
+		return Nil(field), next.Interface()
+	}
+
+	var (
+		cv = reflect.ValueOf(current)
+	)
+
+	switch ft.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		next.SetUint(cv.Uint() + 1)
+	default:
+		next.SetInt(cv.Int() + 1)
+	}
+
+	return Eq(field, current), next.Interface()
+}
+
 func filterDocumentPrimary(pFields []string, pValues []interface{}, op FilterOp) FilterQuery {
 	var filter FilterQuery
 
@@ -576,9 +699,30 @@ func filterBelongsTo(assoc Association) (FilterQuery, error) {
 		}
 	}
 
+	if !assoc.polymorphicMatched() {
+		return filter, ConstraintError{
+			Key:  assoc.PolymorphicField(),
+			Type: ForeignKeyConstraint,
+			Err:  errors.New("rel: inconsistent polymorphic belongs to type"),
+		}
+	}
+
 	return filter, nil
 }
 
+// filterHasMany returns filter that matches all records of has many association.
+func filterHasMany(assoc Association) FilterQuery {
+	var (
+		filter = Eq(assoc.ForeignField(), assoc.ReferenceValue())
+	)
+
+	if assoc.Polymorphic() {
+		filter = filter.AndEq(assoc.PolymorphicField(), assoc.PolymorphicValue())
+	}
+
+	return filter
+}
+
 func filterHasOne(assoc Association, asssocDoc *Document) (FilterQuery, error) {
 	var (
 		fField = assoc.ForeignField()
@@ -587,6 +731,10 @@ func filterHasOne(assoc Association, asssocDoc *Document) (FilterQuery, error) {
 		filter = filterDocument(asssocDoc).AndEq(fField, rValue)
 	)
 
+	if assoc.Polymorphic() {
+		filter = filter.AndEq(assoc.PolymorphicField(), assoc.PolymorphicValue())
+	}
+
 	if rValue != fValue {
 		return filter, ConstraintError{
 			Key:  fField,
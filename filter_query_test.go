@@ -429,6 +429,11 @@ func TestFilterQuery_Not(t *testing.T) {
 			FilterLikeOp,
 			FilterNotLikeOp,
 		},
+		{
+			`Not Exists`,
+			FilterExistsOp,
+			FilterNotExistsOp,
+		},
 		{
 			`And Op`,
 			FilterAndOp,
@@ -906,6 +911,56 @@ func TestNotLike(t *testing.T) {
 	}, NotLike("field", "%expr%"))
 }
 
+func TestExists(t *testing.T) {
+	assert.Equal(t, FilterQuery{
+		Type:  FilterExistsOp,
+		Value: From("users"),
+	}, Exists(From("users")))
+}
+
+func TestNotExists(t *testing.T) {
+	assert.Equal(t, FilterQuery{
+		Type:  FilterNotExistsOp,
+		Value: From("users"),
+	}, NotExists(From("users")))
+}
+
+func TestJSONContains(t *testing.T) {
+	assert.Equal(t, FilterQuery{
+		Type:  FilterJSONContainsOp,
+		Field: "tags",
+		Value: []string{"go"},
+	}, JSONContains("tags", []string{"go"}))
+}
+
+func TestJSONExtract(t *testing.T) {
+	var (
+		path = JSONExtract("data", "address.city")
+	)
+
+	assert.Equal(t, JSONPath{Field: "data", Path: "address.city"}, path)
+
+	tests := []struct {
+		op     FilterOp
+		filter FilterQuery
+	}{
+		{op: FilterEqOp, filter: path.Eq("value")},
+		{op: FilterNeOp, filter: path.Ne("value")},
+		{op: FilterLtOp, filter: path.Lt("value")},
+		{op: FilterLteOp, filter: path.Lte("value")},
+		{op: FilterGtOp, filter: path.Gt("value")},
+		{op: FilterGteOp, filter: path.Gte("value")},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, FilterQuery{
+			Type:  FilterJSONExtractOp,
+			Field: "data",
+			Inner: []FilterQuery{{Type: test.op, Field: "address.city", Value: "value"}},
+		}, test.filter)
+	}
+}
+
 func TestFilterFragment(t *testing.T) {
 	assert.Equal(t, FilterQuery{
 		Type:  FilterFragmentOp,
@@ -955,3 +1010,33 @@ func TestFilterCollection_compositePrimaryKey(t *testing.T) {
 
 	assert.Equal(t, Or(Eq("user_id", 1).AndEq("role_id", 2), Eq("user_id", 3).AndEq("role_id", 4)), filterCollection(col))
 }
+
+func TestFilterLockVersion(t *testing.T) {
+	var (
+		version = uint(2)
+		signed  = struct {
+			ID          int
+			LockVersion int
+		}{ID: 1, LockVersion: 2}
+		unsigned = struct {
+			ID          int
+			LockVersion *uint
+		}{ID: 1, LockVersion: &version}
+		unset = struct {
+			ID          int
+			LockVersion *uint
+		}{ID: 1}
+	)
+
+	filter, next := filterLockVersion(NewDocument(&signed))
+	assert.Equal(t, Eq("lock_version", 2), filter)
+	assert.Equal(t, 3, next)
+
+	filter, next = filterLockVersion(NewDocument(&unsigned))
+	assert.Equal(t, Eq("lock_version", uint(2)), filter)
+	assert.Equal(t, uint(3), next)
+
+	filter, next = filterLockVersion(NewDocument(&unset))
+	assert.Equal(t, Nil("lock_version"), filter)
+	assert.Equal(t, uint(1), next)
+}
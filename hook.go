@@ -0,0 +1,148 @@
+package rel
+
+import (
+	"context"
+	"reflect"
+)
+
+// BeforeInsertHook is implemented by record that needs to run logic before it's inserted.
+// Changes that need to be persisted should be added to the mutation.
+type BeforeInsertHook interface {
+	BeforeInsert(ctx context.Context, mutation *Mutation) error
+}
+
+// AfterInsertHook is implemented by record that needs to run logic after it's inserted.
+type AfterInsertHook interface {
+	AfterInsert(ctx context.Context, mutation *Mutation) error
+}
+
+// BeforeUpdateHook is implemented by record that needs to run logic before it's updated.
+// Changes that need to be persisted should be added to the mutation.
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx context.Context, mutation *Mutation) error
+}
+
+// AfterUpdateHook is implemented by record that needs to run logic after it's updated.
+type AfterUpdateHook interface {
+	AfterUpdate(ctx context.Context, mutation *Mutation) error
+}
+
+// BeforeDeleteHook is implemented by record that needs to run logic before it's deleted.
+// Delete hooks only run for records deleted one by one, records deleted using DeleteAll or removed from has many association
+// when its parent is updated are deleted in bulk without loading them, hence their delete hooks are not called.
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+// AfterDeleteHook is implemented by record that needs to run logic after it's deleted.
+// See BeforeDeleteHook for records that are deleted without calling the hooks.
+type AfterDeleteHook interface {
+	AfterDelete(ctx context.Context) error
+}
+
+// AfterFindHook is implemented by record that needs to run logic after it's loaded from database, including preloaded records.
+type AfterFindHook interface {
+	AfterFind(ctx context.Context) error
+}
+
+var (
+	afterInsertHookType = reflect.TypeOf((*AfterInsertHook)(nil)).Elem()
+	afterFindHookType   = reflect.TypeOf((*AfterFindHook)(nil)).Elem()
+)
+
+func beforeInsert(cw contextWrapper, doc *Document, mutation *Mutation) error {
+	if hook, ok := doc.v.(BeforeInsertHook); ok {
+		return hook.BeforeInsert(cw.ctx, mutation)
+	}
+
+	return nil
+}
+
+func afterInsert(cw contextWrapper, doc *Document, mutation *Mutation) error {
+	if hook, ok := doc.v.(AfterInsertHook); ok {
+		return hook.AfterInsert(cw.ctx, mutation)
+	}
+
+	return nil
+}
+
+func beforeUpdate(cw contextWrapper, doc *Document, mutation *Mutation) error {
+	if hook, ok := doc.v.(BeforeUpdateHook); ok {
+		return hook.BeforeUpdate(cw.ctx, mutation)
+	}
+
+	return nil
+}
+
+func afterUpdate(cw contextWrapper, doc *Document, mutation *Mutation) error {
+	if hook, ok := doc.v.(AfterUpdateHook); ok {
+		return hook.AfterUpdate(cw.ctx, mutation)
+	}
+
+	return nil
+}
+
+func beforeDelete(cw contextWrapper, doc *Document) error {
+	if hook, ok := doc.v.(BeforeDeleteHook); ok {
+		return hook.BeforeDelete(cw.ctx)
+	}
+
+	return nil
+}
+
+func afterDelete(cw contextWrapper, doc *Document) error {
+	if hook, ok := doc.v.(AfterDeleteHook); ok {
+		return hook.AfterDelete(cw.ctx)
+	}
+
+	return nil
+}
+
+func afterFind(cw contextWrapper, doc *Document) error {
+	if hook, ok := doc.v.(AfterFindHook); ok {
+		return hook.AfterFind(cw.ctx)
+	}
+
+	return nil
+}
+
+func afterFindAll(cw contextWrapper, col *Collection) error {
+	if !collectionImplements(col, afterFindHookType) {
+		return nil
+	}
+
+	for i := 0; i < col.Len(); i++ {
+		if err := afterFind(cw, col.Get(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// afterFindTargets runs after find hook of preloaded records.
+// Document target is skipped when its key field is not set, which means no record was loaded into it.
+func afterFindTargets(cw contextWrapper, keyField string, targets map[interface{}][]slice) error {
+	for _, slices := range targets {
+		for i := range slices {
+			if doc, ok := slices[i].(*Document); ok {
+				if value, _ := doc.Value(keyField); isZero(value) {
+					continue
+				}
+			}
+
+			for j := 0; j < slices[i].Len(); j++ {
+				if err := afterFind(cw, slices[i].Get(j)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectionImplements returns true if pointer to collection's element implements given interface.
+func collectionImplements(col *Collection, iface reflect.Type) bool {
+	return reflect.PtrTo(col.rt.Elem()).Implements(iface)
+}
@@ -0,0 +1,355 @@
+package rel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type hookLog struct {
+	calls  []string
+	failOn string
+}
+
+func (hl *hookLog) call(name string) error {
+	hl.calls = append(hl.calls, name)
+	if hl.failOn == name {
+		return errors.New(name + " error")
+	}
+
+	return nil
+}
+
+type Hooked struct {
+	ID       int
+	Name     string
+	ParentID int
+	log      *hookLog
+}
+
+func (h *Hooked) BeforeInsert(ctx context.Context, mutation *Mutation) error {
+	mutation.Add(Set("name", "before insert"))
+	return h.log.call("BeforeInsert")
+}
+
+func (h *Hooked) AfterInsert(ctx context.Context, mutation *Mutation) error {
+	return h.log.call("AfterInsert")
+}
+
+func (h *Hooked) BeforeUpdate(ctx context.Context, mutation *Mutation) error {
+	mutation.Add(Set("name", "before update"))
+	return h.log.call("BeforeUpdate")
+}
+
+func (h *Hooked) AfterUpdate(ctx context.Context, mutation *Mutation) error {
+	return h.log.call("AfterUpdate")
+}
+
+func (h *Hooked) BeforeDelete(ctx context.Context) error {
+	return h.log.call("BeforeDelete")
+}
+
+func (h *Hooked) AfterDelete(ctx context.Context) error {
+	return h.log.call("AfterDelete")
+}
+
+func (h *Hooked) AfterFind(ctx context.Context) error {
+	return h.log.call("AfterFind")
+}
+
+type Parent struct {
+	ID      int
+	Hookeds []Hooked `autosave:"true"`
+}
+
+func TestHook_insert(t *testing.T) {
+	var (
+		log     = &hookLog{}
+		record  = Hooked{log: log}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("hookeds"), map[string]Mutate{
+		"name":      Set("name", "before insert"),
+		"parent_id": Set("parent_id", 0),
+	}, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &record))
+	assert.Equal(t, 1, record.ID)
+	assert.Equal(t, []string{"BeforeInsert", "AfterInsert"}, log.calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_insertError(t *testing.T) {
+	tests := []string{
+		"BeforeInsert",
+		"AfterInsert",
+	}
+
+	for _, failOn := range tests {
+		t.Run(failOn, func(t *testing.T) {
+			var (
+				log     = &hookLog{failOn: failOn}
+				record  = Hooked{log: log}
+				adapter = &testAdapter{}
+				repo    = New(adapter)
+			)
+
+			adapter.On("Begin").Return(nil).Once()
+			if failOn == "AfterInsert" {
+				adapter.On("Insert", From("hookeds"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+			}
+			adapter.On("Rollback").Return(nil).Once()
+
+			assert.Equal(t, errors.New(failOn+" error"), repo.Insert(context.TODO(), &record))
+
+			adapter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHook_insertAll(t *testing.T) {
+	var (
+		log     = &hookLog{}
+		records = []Hooked{{log: log}, {log: log}}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("InsertAll", From("hookeds"), mock.Anything, []map[string]Mutate{
+		{"name": Set("name", "before insert"), "parent_id": Set("parent_id", 0)},
+		{"name": Set("name", "before insert"), "parent_id": Set("parent_id", 0)},
+	}, OnConflict{}).Return([]interface{}{1, 2}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &records))
+	assert.Equal(t, []string{"BeforeInsert", "BeforeInsert", "AfterInsert", "AfterInsert"}, log.calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_insertHasMany(t *testing.T) {
+	var (
+		log    = &hookLog{}
+		parent = Parent{
+			Hookeds: []Hooked{{log: log}},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("parents"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("hookeds"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{2}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &parent))
+	assert.Equal(t, []string{"BeforeInsert", "AfterInsert"}, log.calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_update(t *testing.T) {
+	var (
+		log     = &hookLog{}
+		record  = Hooked{ID: 1, log: log}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Update", From("hookeds").Where(Eq("id", 1)), map[string]Mutate{
+		"name": Set("name", "before update"),
+	}).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &record, Set("name", "name")))
+	assert.Equal(t, []string{"BeforeUpdate", "AfterUpdate"}, log.calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_updateError(t *testing.T) {
+	var (
+		log     = &hookLog{failOn: "AfterUpdate"}
+		record  = Hooked{ID: 1, log: log}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Update", From("hookeds").Where(Eq("id", 1)), mock.Anything).Return(1, nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, errors.New("AfterUpdate error"), repo.Update(context.TODO(), &record))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_delete(t *testing.T) {
+	var (
+		log     = &hookLog{}
+		record  = Hooked{ID: 1, log: log}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("hookeds").Where(Eq("id", 1))).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &record))
+	assert.Equal(t, []string{"BeforeDelete", "AfterDelete"}, log.calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_deleteError(t *testing.T) {
+	var (
+		log     = &hookLog{failOn: "BeforeDelete"}
+		record  = Hooked{ID: 1, log: log}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, errors.New("BeforeDelete error"), repo.Delete(context.TODO(), &record))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_deleteHasMany(t *testing.T) {
+	var (
+		log    = &hookLog{}
+		parent = Parent{
+			ID:      1,
+			Hookeds: []Hooked{{ID: 2, ParentID: 1, log: log}},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("hookeds").Where(Eq("parent_id", 1).And(In("id", 2)))).Return(1, nil).Once()
+	adapter.On("Delete", From("parents").Where(Eq("id", 1))).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &parent, Cascade(true)))
+	assert.Equal(t, []string{"BeforeDelete", "AfterDelete"}, log.calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHook_find(t *testing.T) {
+	var (
+		log     = &hookLog{failOn: "AfterFind"}
+		record  = Hooked{log: log}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = createCursor(1)
+	)
+
+	adapter.On("Query", From("hookeds").Limit(1)).Return(cur, nil).Once()
+
+	assert.Equal(t, errors.New("AfterFind error"), repo.Find(context.TODO(), &record))
+	assert.Equal(t, []string{"AfterFind"}, log.calls)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+type FoundHooked struct {
+	ID       int
+	ParentID int
+	found    bool
+}
+
+func (fh *FoundHooked) AfterFind(ctx context.Context) error {
+	fh.found = true
+	return nil
+}
+
+type FoundParent struct {
+	ID   int
+	Many []FoundHooked `ref:"id" fk:"parent_id"`
+	One  FoundHooked   `ref:"id" fk:"parent_id"`
+}
+
+func TestHook_preloadHasMany(t *testing.T) {
+	var (
+		parent  = FoundParent{ID: 1}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("found_hookeds").Where(In("parent_id", 1))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "parent_id"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(2, 1).Twice()
+	cur.MockScan(3, 1).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &parent, "many"))
+	assert.Equal(t, []FoundHooked{{ID: 2, ParentID: 1, found: true}, {ID: 3, ParentID: 1, found: true}}, parent.Many)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestHook_preloadHasOne(t *testing.T) {
+	var (
+		parent  = FoundParent{ID: 1}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("found_hookeds").Where(In("parent_id", 1))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "parent_id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(2, 1).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &parent, "one"))
+	assert.Equal(t, FoundHooked{ID: 2, ParentID: 1, found: true}, parent.One)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestHook_preloadHasOneNotFound(t *testing.T) {
+	var (
+		parent  = FoundParent{ID: 1}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("found_hookeds").Where(In("parent_id", 1))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "parent_id"}, nil).Once()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &parent, "one"))
+	assert.Equal(t, FoundHooked{}, parent.One)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
@@ -49,17 +49,37 @@ func Finish(id ...interface{}) IteratorOption {
 	return finish(id)
 }
 
+// IteratorStrategy defines how iterator paginates through the records.
+type IteratorStrategy int
+
+const (
+	// KeysetStrategy paginates using primary values of the last fetched record (WHERE pk > last).
+	// This is the default strategy, iterator falls back to OffsetStrategy when query is sorted,
+	// because keyset pagination requires records to be sorted by primary fields only.
+	KeysetStrategy IteratorStrategy = iota
+	// OffsetStrategy paginates using limit and offset.
+	OffsetStrategy
+)
+
+func (is IteratorStrategy) apply(i *iterator) {
+	i.strategy = is
+}
+
 type iterator struct {
 	ctx       context.Context
 	start     []interface{}
 	finish    []interface{}
 	batchSize int
+	strategy  IteratorStrategy
 	current   int
 	query     Query
+	pFields   []string
+	last      []interface{}
 	adapter   Adapter
 	cursor    Cursor
 	fields    []string
 	closed    bool
+	naming    NamingStrategy
 }
 
 func (i *iterator) Close() error {
@@ -83,12 +103,20 @@ func (i *iterator) Next(record interface{}) error {
 	}
 
 	var (
-		doc      = NewDocument(record)
+		doc      = documentWithNaming(record, i.naming, false)
 		scanners = doc.Scanners(i.fields)
 	)
 
+	if err := i.cursor.Scan(scanners...); err != nil {
+		return err
+	}
+
+	if i.strategy == KeysetStrategy {
+		i.last = doc.PrimaryValues()
+	}
+
 	i.current++
-	return i.cursor.Scan(scanners...)
+	return nil
 }
 
 func (i *iterator) fetch(ctx context.Context, record interface{}) error {
@@ -98,9 +126,17 @@ func (i *iterator) fetch(ctx context.Context, record interface{}) error {
 		i.cursor.Close()
 	}
 
-	i.query = i.query.Limit(i.batchSize).Offset(i.current)
+	var (
+		query = i.query.Limit(i.batchSize)
+	)
+
+	if i.strategy == OffsetStrategy {
+		query = query.Offset(i.current)
+	} else if len(i.last) > 0 {
+		query = query.Where(filterKeyset(i.pFields, i.last))
+	}
 
-	cursor, err := i.adapter.Query(ctx, i.query)
+	cursor, err := i.adapter.Query(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -118,13 +154,15 @@ func (i *iterator) fetch(ctx context.Context, record interface{}) error {
 
 func (i *iterator) init(record interface{}) {
 	var (
-		doc = NewDocument(record)
+		doc = documentWithNaming(record, i.naming, false)
 	)
 
 	if i.query.Table == "" {
 		i.query.Table = doc.Table()
 	}
 
+	i.pFields = doc.PrimaryFields()
+
 	if len(i.start) > 0 {
 		i.query = i.query.Where(filterDocumentPrimary(doc.PrimaryFields(), i.start, FilterGteOp))
 	}
@@ -133,15 +171,49 @@ func (i *iterator) init(record interface{}) {
 		i.query = i.query.Where(filterDocumentPrimary(doc.PrimaryFields(), i.finish, FilterLteOp))
 	}
 
+	// keyset pagination requires records to be sorted by primary fields only,
+	// offset pagination keeps the given sort and uses primary fields as tie-breaker.
+	if len(i.query.SortQuery) > 0 {
+		i.strategy = OffsetStrategy
+		i.query.SortQuery = i.query.SortQuery[:len(i.query.SortQuery):len(i.query.SortQuery)]
+	}
+
+	i.query.OffsetQuery = 0
 	i.query = i.query.SortAsc(doc.PrimaryFields()...)
+
+	// prevents filters appended on each fetch from sharing the same backing array.
+	i.query.WhereQuery.Inner = i.query.WhereQuery.Inner[:len(i.query.WhereQuery.Inner):len(i.query.WhereQuery.Inner)]
+}
+
+// filterKeyset builds filter that matches records positioned after given primary values.
+// composite primary is expanded as: (a > x) OR (a = x AND b > y) OR ...
+func filterKeyset(pFields []string, pValues []interface{}) FilterQuery {
+	var (
+		filters = make([]FilterQuery, len(pFields))
+	)
+
+	for i := range pFields {
+		var (
+			inner = make([]FilterQuery, 0, i+1)
+		)
+
+		for j := 0; j < i; j++ {
+			inner = append(inner, Eq(pFields[j], pValues[j]))
+		}
+
+		filters[i] = And(append(inner, Gt(pFields[i], pValues[i]))...)
+	}
+
+	return Or(filters...)
 }
 
-func newIterator(ctx context.Context, adapter Adapter, query Query, options []IteratorOption) Iterator {
+func newIterator(ctx context.Context, adapter Adapter, query Query, naming NamingStrategy, options []IteratorOption) Iterator {
 	it := &iterator{
 		ctx:       ctx,
 		batchSize: 1000,
 		query:     query,
 		adapter:   adapter,
+		naming:    naming,
 	}
 
 	for i := range options {
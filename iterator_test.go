@@ -18,7 +18,50 @@ func TestIterator(t *testing.T) {
 		cur2    = createCursor(5)
 		cur3    = createCursor(3)
 		options = []IteratorOption{BatchSize(5)}
-		it      = newIterator(context.TODO(), adapter, query, options)
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, options)
+	)
+
+	query = query.From("users").SortAsc("id").Limit(5)
+	adapter.On("Query", query).Return(cur1, nil).Once()
+	adapter.On("Query", query.Where(Gt("id", 10))).Return(cur2, nil).Once()
+	adapter.On("Query", query.Where(Gt("id", 10))).Return(cur3, nil).Once()
+
+	recordsCount := 0
+	for {
+		if err := it.Next(&user); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+
+		assert.NotEqual(t, 0, user.ID)
+		recordsCount++
+	}
+	it.Close()
+
+	assert.Equal(t, 13, recordsCount)
+
+	// the last next is not called because it's already refetched.
+	// call here to make expectation pass.
+	cur1.Next()
+	cur2.Next()
+
+	adapter.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+	cur2.AssertExpectations(t)
+	cur3.AssertExpectations(t)
+}
+
+func TestIterator_offsetStrategy(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		query   = From("users")
+		cur1    = createCursor(5)
+		cur2    = createCursor(5)
+		cur3    = createCursor(3)
+		options = []IteratorOption{BatchSize(5), OffsetStrategy}
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, options)
 	)
 
 	query = query.From("users").SortAsc("id").Limit(5)
@@ -52,13 +95,124 @@ func TestIterator(t *testing.T) {
 	cur3.AssertExpectations(t)
 }
 
+func TestIterator_sortFallbackToOffset(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		query   = From("users").SortDesc("name")
+		cur1    = createCursor(5)
+		cur2    = createCursor(3)
+		options = []IteratorOption{BatchSize(5)}
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, options)
+	)
+
+	query = query.SortAsc("id").Limit(5)
+	adapter.On("Query", query).Return(cur1, nil).Once()
+	adapter.On("Query", query.Offset(5)).Return(cur2, nil).Once()
+
+	recordsCount := 0
+	for {
+		if err := it.Next(&user); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+
+		recordsCount++
+	}
+	it.Close()
+
+	assert.Equal(t, 8, recordsCount)
+
+	// the last next is not called because it's already refetched.
+	// call here to make expectation pass.
+	cur1.Next()
+
+	adapter.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+	cur2.AssertExpectations(t)
+}
+
+func TestIterator_offsetStrategyKeepSort(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		query   = From("users").SortDesc("name")
+		cur     = createCursor(1)
+		options = []IteratorOption{OffsetStrategy}
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, options)
+	)
+
+	adapter.On("Query", From("users").SortDesc("name").SortAsc("id").Limit(1000).Offset(0)).Return(cur, nil).Once()
+
+	for {
+		if err := it.Next(&user); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+	it.Close()
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestIterator_compositePrimaryKeyset(t *testing.T) {
+	var (
+		follow  Follow
+		adapter = &testAdapter{}
+		query   = From("follows").Where(Eq("accepted", true))
+		cur1    = &testCursor{}
+		cur2    = createCursor(0)
+		options = []IteratorOption{BatchSize(1), Start(1, 1), Finish(5, 5)}
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, options)
+	)
+
+	cur1.On("Fields").Return([]string{"follower_id", "following_id"}, nil).Once()
+	cur1.On("Next").Return(true).Once()
+	cur1.MockScan(1, 2).Once()
+	cur1.On("Close").Return(nil).Once()
+
+	query = query.
+		Where(Gte("follower_id", 1).AndGte("following_id", 1)).
+		Where(Lte("follower_id", 5).AndLte("following_id", 5)).
+		SortAsc("follower_id", "following_id").
+		Limit(1)
+
+	adapter.On("Query", query).Return(cur1, nil).Once()
+	adapter.On("Query", query.Where(Or(
+		Gt("follower_id", 1),
+		And(Eq("follower_id", 1), Gt("following_id", 2)),
+	))).Return(cur2, nil).Once()
+
+	count := 0
+	for {
+		if err := it.Next(&follow); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+
+		count++
+	}
+	it.Close()
+
+	assert.Equal(t, 1, count)
+	assert.Equal(t, Follow{FollowerID: 1, FollowingID: 2}, follow)
+
+	adapter.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+	cur2.AssertExpectations(t)
+}
+
 func TestIterator_setTableName(t *testing.T) {
 	var (
 		user    User
 		adapter = &testAdapter{}
 		query   = Query{}
 		cur     = createCursor(1)
-		it      = newIterator(context.TODO(), adapter, query, nil)
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, nil)
 	)
 
 	adapter.On("Query", query.From("users").SortAsc("id").Limit(1000)).Return(cur, nil).Once()
@@ -85,7 +239,7 @@ func TestIterator_setStartAndFinishID(t *testing.T) {
 		query   = From("users")
 		cur     = createCursor(1)
 		options = []IteratorOption{Start(10), Finish(20)}
-		it      = newIterator(context.TODO(), adapter, query, options)
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, options)
 	)
 
 	adapter.On("Query", query.Where(Gte("id", 10).AndLte("id", 20)).SortAsc("id").Limit(1000)).Return(cur, nil).Once()
@@ -111,7 +265,7 @@ func TestIterator_cursorFieldsError(t *testing.T) {
 		adapter = &testAdapter{}
 		query   = From("users")
 		cur     = &testCursor{}
-		it      = newIterator(context.TODO(), adapter, query, nil)
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, nil)
 		err     = errors.New("cursor error")
 	)
 
@@ -140,7 +294,7 @@ func TestIterator_queryError(t *testing.T) {
 		adapter = &testAdapter{}
 		query   = From("users")
 		cur     = &testCursor{}
-		it      = newIterator(context.TODO(), adapter, query, nil)
+		it      = newIterator(context.TODO(), adapter, query, DefaultNamingStrategy{}, nil)
 		err     = errors.New("query error")
 	)
 
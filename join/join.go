@@ -26,4 +26,12 @@ var (
 	Full = rel.NewFullJoin
 	// FullOn is alias for rel.NewFullJoinOn
 	FullOn = rel.NewFullJoinOn
+	// Assoc is alias for rel.NewJoinAssoc
+	Assoc = rel.NewJoinAssoc
+	// AssocWith is alias for rel.NewJoinAssocWith
+	AssocWith = rel.NewJoinAssocWith
+	// Populate is alias for rel.NewJoinPopulate
+	Populate = rel.NewJoinPopulate
+	// PopulateWith is alias for rel.NewJoinPopulateWith
+	PopulateWith = rel.NewJoinPopulateWith
 )
@@ -1,12 +1,21 @@
 package rel
 
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
 // JoinQuery defines join clause in query.
 type JoinQuery struct {
 	Mode      string
 	Table     string
 	From      string
 	To        string
+	Filter    FilterQuery
 	Arguments []interface{}
+	Assoc     string
+	Populate  bool
 }
 
 // Build query.
@@ -86,3 +95,251 @@ func NewFullJoin(table string) JoinQuery {
 func NewFullJoinOn(table string, from string, to string) JoinQuery {
 	return NewJoinWith("FULL JOIN", table, from, to)
 }
+
+// NewJoinAssoc defines a join clause using association path.
+// Path is a dot separated association name, for example "buyer.address".
+func NewJoinAssoc(assoc string) JoinQuery {
+	return NewJoinAssocWith("JOIN", assoc)
+}
+
+// NewJoinAssocWith defines a join clause using association path with custom join mode.
+func NewJoinAssocWith(mode string, assoc string) JoinQuery {
+	return JoinQuery{
+		Mode:  mode,
+		Assoc: assoc,
+	}
+}
+
+// NewJoinPopulate defines a join clause using association path, and scans joined columns into the associated struct.
+// Only belongs to and has one association can be populated.
+func NewJoinPopulate(assoc string) JoinQuery {
+	return NewJoinPopulateWith("JOIN", assoc)
+}
+
+// NewJoinPopulateWith defines a join clause using association path with custom join mode,
+// and scans joined columns into the associated struct.
+func NewJoinPopulateWith(mode string, assoc string) JoinQuery {
+	return JoinQuery{
+		Mode:     mode,
+		Assoc:    assoc,
+		Populate: true,
+	}
+}
+
+type joinAssocTarget struct {
+	rt     reflect.Type
+	table  string
+	single bool
+}
+
+type joinAssocResolver struct {
+	naming   NamingStrategy
+	mode     string
+	unscoped bool
+	joins    []JoinQuery
+	tables   map[string]bool
+	targets  map[string]joinAssocTarget
+}
+
+func (r *joinAssocResolver) resolve(from joinAssocTarget, prefix string, name string) (joinAssocTarget, error) {
+	var (
+		path = prefix + name
+		data = extractDocumentData(from.rt, r.naming, false)
+	)
+
+	if target, ok := r.targets[path]; ok {
+		return target, nil
+	}
+
+	index, ok := data.index[name]
+	if !ok || !data.isAssociation(name) {
+		return joinAssocTarget{}, errors.New("rel: no association named (" + name + ") in type " + from.rt.String() + " found")
+	}
+
+	var (
+		ft     = from.rt.Field(index).Type
+		assoc  = extractAssociationData(from.rt, index, r.naming)
+		target = joinAssocTarget{
+			single: from.single && (assoc.typ == BelongsTo || assoc.typ == HasOne),
+		}
+	)
+
+	for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice {
+		ft = ft.Elem()
+	}
+
+	target.rt = ft
+
+	switch {
+	case assoc.through != "":
+		through, err := r.resolve(from, prefix, assoc.through)
+		if err != nil {
+			return target, err
+		}
+
+		target.single = false
+
+		// intermediate document may define association with the same name to the final target,
+		// otherwise the field of intermediate document that references the target must be declared using join_fk tag.
+		if throughData := extractDocumentData(through.rt, r.naming, false); throughData.isAssociation(name) {
+			final, err := r.resolve(through, prefix+assoc.through+".", name)
+			if err != nil {
+				return target, err
+			}
+
+			target.table = final.table
+		} else if _, ok := throughData.index[assoc.joinFkField]; ok {
+			target.table = r.join(target.rt, r.table(target.rt, path), assoc.foreignField, through.table+"."+assoc.joinFkField, FilterQuery{})
+		} else {
+			return target, errors.New("rel: join_fk of through association (" + name + ") is not declared in type " + through.rt.String())
+		}
+	case assoc.typ == ManyToMany:
+		var (
+			joinTable = r.alias(assoc.joinTable, strings.Replace(path, ".", "_", -1)+"_"+assoc.joinTable)
+			joinName  = r.name(joinTable)
+		)
+
+		r.joins = append(r.joins, JoinQuery{
+			Mode:  r.mode,
+			Table: joinTable,
+			From:  joinName + "." + assoc.joinRefField,
+			To:    from.table + "." + assoc.referenceField,
+		})
+
+		target.table = r.join(target.rt, r.table(target.rt, path), assoc.foreignField, joinName+"."+assoc.joinFkField, FilterQuery{})
+	default:
+		var (
+			filter FilterQuery
+			table  = r.table(target.rt, path)
+		)
+
+		if assoc.polyField != "" {
+			if assoc.typ == BelongsTo {
+				filter = Eq(from.table+"."+assoc.polyField, assoc.polyValue)
+			} else {
+				filter = Eq(r.name(table)+"."+assoc.polyField, assoc.polyValue)
+			}
+		}
+
+		target.table = r.join(target.rt, table, assoc.foreignField, from.table+"."+assoc.referenceField, filter)
+	}
+
+	r.targets[path] = target
+	return target, nil
+}
+
+// join appends join clause to the table of rt, and returns the name used to refer the joined table.
+func (r *joinAssocResolver) join(rt reflect.Type, table string, field string, to string, filter FilterQuery) string {
+	var (
+		name = r.name(table)
+	)
+
+	if deletedAt := extractDocumentData(rt, r.naming, false).flagFieldName(HasDeletedAt); !r.unscoped && deletedAt != "" {
+		filter = filter.And(Nil(name + "." + deletedAt))
+	}
+
+	r.joins = append(r.joins, JoinQuery{
+		Mode:   r.mode,
+		Table:  table,
+		From:   name + "." + field,
+		To:     to,
+		Filter: filter,
+	})
+
+	return name
+}
+
+// table returns table of rt to be joined, aliased using association path when needed.
+func (r *joinAssocResolver) table(rt reflect.Type, path string) string {
+	return r.alias(typeTableName(rt, r.naming), strings.Replace(path, ".", "_", -1))
+}
+
+// alias returns table name, or aliased table name if the table is already used in the query.
+func (r *joinAssocResolver) alias(table string, alias string) string {
+	if r.tables[table] {
+		r.tables[alias] = true
+		return table + " AS " + alias
+	}
+
+	r.tables[table] = true
+	return table
+}
+
+func (r joinAssocResolver) name(table string) string {
+	if i := strings.Index(table, " AS "); i >= 0 {
+		return table[i+4:]
+	}
+
+	return table
+}
+
+// resolveJoinAssoc replaces association joins in query with join clauses inferred from association of rt.
+// Error is returned when association path or populated association is invalid.
+func resolveJoinAssoc(rt reflect.Type, naming NamingStrategy, query Query) (Query, error) {
+	var (
+		populate []string
+		root     = joinAssocTarget{rt: rt, table: query.Table, single: true}
+		resolver = joinAssocResolver{
+			naming:   naming,
+			unscoped: bool(query.UnscopedQuery),
+			tables:   map[string]bool{query.Table: true},
+			targets:  make(map[string]joinAssocTarget),
+		}
+	)
+
+	if query.Alias != "" {
+		root.table = query.Alias
+		resolver.tables[query.Alias] = true
+	}
+
+	for _, jq := range query.JoinQuery {
+		if jq.Assoc == "" {
+			resolver.tables[resolver.name(jq.Table)] = true
+		}
+	}
+
+	for _, jq := range query.JoinQuery {
+		if jq.Assoc == "" {
+			resolver.joins = append(resolver.joins, jq)
+			continue
+		}
+
+		var (
+			target = root
+			prefix = ""
+		)
+
+		resolver.mode = jq.Mode
+		for _, name := range strings.Split(jq.Assoc, ".") {
+			var err error
+			if target, err = resolver.resolve(target, prefix, name); err != nil {
+				return query, err
+			}
+
+			prefix += name + "."
+		}
+
+		if jq.Populate {
+			if !target.single {
+				return query, errors.New("rel: populate is only supported for belongs to and has one association")
+			}
+
+			for _, field := range extractDocumentData(target.rt, resolver.naming, false).fields {
+				populate = append(populate, target.table+"."+field+" AS "+prefix+field)
+			}
+		}
+	}
+
+	if len(resolver.targets) == 0 {
+		return query, nil
+	}
+
+	if len(query.SelectQuery.Fields) == 0 && len(query.SelectQuery.Exprs) == 0 {
+		query.SelectQuery.Fields = []string{root.table + ".*"}
+	}
+
+	query.SelectQuery.Fields = append(query.SelectQuery.Fields, populate...)
+	query.JoinQuery = resolver.joins
+
+	return query, nil
+}
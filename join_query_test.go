@@ -107,3 +107,29 @@ func TestFullJoinOn(t *testing.T) {
 		To:    "id",
 	}, rel.NewFullJoinOn("transactions", "user_id", "id"))
 }
+
+func TestJoinAssoc(t *testing.T) {
+	assert.Equal(t, rel.JoinQuery{
+		Mode:  "JOIN",
+		Assoc: "buyer.address",
+	}, rel.NewJoinAssoc("buyer.address"))
+
+	assert.Equal(t, rel.JoinQuery{
+		Mode:  "LEFT JOIN",
+		Assoc: "buyer.address",
+	}, rel.NewJoinAssocWith("LEFT JOIN", "buyer.address"))
+}
+
+func TestJoinPopulate(t *testing.T) {
+	assert.Equal(t, rel.JoinQuery{
+		Mode:     "JOIN",
+		Assoc:    "buyer",
+		Populate: true,
+	}, rel.NewJoinPopulate("buyer"))
+
+	assert.Equal(t, rel.JoinQuery{
+		Mode:     "LEFT JOIN",
+		Assoc:    "buyer",
+		Populate: true,
+	}, rel.NewJoinPopulateWith("LEFT JOIN", "buyer"))
+}
@@ -0,0 +1,67 @@
+package rel
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonValue encodes and decodes value of field tagged with json option when it's saved to or loaded from database.
+type jsonValue struct {
+	v interface{}
+}
+
+// JSONValue wraps value, so it's encoded as json when it's passed to database.
+func JSONValue(v interface{}) driver.Valuer {
+	return jsonValue{v: v}
+}
+
+// Scan decodes json from database into the referenced field, null resets the field into its zero value.
+func (jv jsonValue) Scan(src interface{}) error {
+	var (
+		data []byte
+	)
+
+	switch s := src.(type) {
+	case nil:
+		rv := reflect.ValueOf(jv.v).Elem()
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("rel: cannot scan %T into json field", src)
+	}
+
+	return json.Unmarshal(data, jv.v)
+}
+
+// Value encodes the value as json string, nil is saved as null.
+func (jv jsonValue) Value() (driver.Value, error) {
+	if isNilValue(jv.v) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(jv.v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func isNilValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
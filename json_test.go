@@ -0,0 +1,49 @@
+package rel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONValue_Scan(t *testing.T) {
+	var (
+		data map[string]interface{}
+		tags []string
+	)
+
+	assert.Nil(t, jsonValue{v: &data}.Scan([]byte(`{"theme":"dark"}`)))
+	assert.Equal(t, map[string]interface{}{"theme": "dark"}, data)
+
+	assert.Nil(t, jsonValue{v: &tags}.Scan(`["go","rel"]`))
+	assert.Equal(t, []string{"go", "rel"}, tags)
+
+	assert.Nil(t, jsonValue{v: &tags}.Scan(nil))
+	assert.Nil(t, tags)
+
+	assert.NotNil(t, jsonValue{v: &tags}.Scan(`{"invalid"`))
+	assert.Equal(t, "rel: cannot scan int into json field", jsonValue{v: &tags}.Scan(1).Error())
+}
+
+func TestJSONValue_Value(t *testing.T) {
+	tests := []struct {
+		value  interface{}
+		result interface{}
+	}{
+		{value: nil, result: nil},
+		{value: []string(nil), result: nil},
+		{value: (*struct{})(nil), result: nil},
+		{value: []string{"go"}, result: `["go"]`},
+		{value: map[string]int{"size": 12}, result: `{"size":12}`},
+		{value: 10, result: `10`},
+	}
+
+	for _, test := range tests {
+		result, err := JSONValue(test.value).Value()
+		assert.Nil(t, err)
+		assert.Equal(t, test.result, result)
+	}
+
+	_, err := jsonValue{v: make(chan int)}.Value()
+	assert.NotNil(t, err)
+}
@@ -10,6 +10,8 @@ const (
 	ForeignKey KeyType = "FOREIGN KEY"
 	// UniqueKey KeyType.
 	UniqueKey = "UNIQUE"
+	// CheckKey KeyType.
+	CheckKey KeyType = "CHECK"
 )
 
 // ForeignKeyReference definition.
@@ -28,6 +30,7 @@ type Key struct {
 	Columns   []string
 	Rename    string
 	Reference ForeignKeyReference
+	Expr      string
 	Options   string
 }
 
@@ -63,4 +66,25 @@ func createForeignKey(column string, refTable string, refColumn string, options
 	return key
 }
 
-// TODO: Rename and Drop, PR welcomed.
+func createCheck(name string, expr string, options []KeyOption) Key {
+	key := Key{
+		Op:   SchemaCreate,
+		Type: CheckKey,
+		Name: name,
+		Expr: expr,
+	}
+
+	applyKeyOptions(&key, options)
+	return key
+}
+
+func dropKey(name string, typ KeyType, options []KeyOption) Key {
+	key := Key{
+		Op:   SchemaDrop,
+		Type: typ,
+		Name: name,
+	}
+
+	applyKeyOptions(&key, options)
+	return key
+}
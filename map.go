@@ -112,7 +112,15 @@ func applyMaps(maps []Map, assoc Association) ([]Mutation, []interface{}) {
 	}
 
 	// delete stales
-	if curr < col.Len() {
+	// many to many is unlinked from join table using the referenced foreign value instead of primary value.
+	if curr < col.Len() && assoc.Type() == ManyToMany {
+		for i := curr; i < col.Len(); i++ {
+			fValue, _ := col.Get(i).Value(assoc.ForeignField())
+			deletedIDs = append(deletedIDs, fValue)
+		}
+
+		col.Truncate(0, curr)
+	} else if curr < col.Len() {
 		deletedIDs = pValues[curr:]
 		col.Truncate(0, curr)
 	} else {
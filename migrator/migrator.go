@@ -12,6 +12,16 @@ import (
 
 const versionTable = "rel_schema_versions"
 
+// Latest is the target version that includes every registered migration.
+const Latest = int(^uint(0) >> 1)
+
+// Status of a registered migration version.
+type Status struct {
+	Version   int
+	Applied   bool
+	AppliedAt time.Time
+}
+
 type version struct {
 	ID        int
 	Version   int
@@ -20,6 +30,7 @@ type version struct {
 
 	up      rel.Schema
 	down    rel.Schema
+	downErr error
 	applied bool
 }
 
@@ -64,6 +75,23 @@ func (m *Migrator) Register(v int, up func(schema *rel.Schema), down func(schema
 	m.versions = append(m.versions, version{Version: v, up: upSchema, down: downSchema})
 }
 
+// Change registers a reversible migration, the down migration is derived by reverting the migrations in reverse order.
+// Rollback of the version panics with rel.IrreversibleError when any of the migration can't be reverted,
+// an explicit down migration can be passed to be used instead.
+func (m *Migrator) Change(v int, change func(schema *rel.Schema), down ...func(schema *rel.Schema)) {
+	if len(down) > 0 {
+		m.Register(v, change, down[0])
+		return
+	}
+
+	var upSchema rel.Schema
+
+	change(&upSchema)
+	downSchema, err := upSchema.Reverse()
+
+	m.versions = append(m.versions, version{Version: v, up: upSchema, down: downSchema, downErr: err})
+}
+
 func (m Migrator) buildVersionTableDefinition() rel.Table {
 	var schema rel.Schema
 	schema.CreateTableIfNotExists(versionTable, func(t *rel.Table) {
@@ -76,7 +104,7 @@ func (m Migrator) buildVersionTableDefinition() rel.Table {
 	return schema.Migrations[0].(rel.Table)
 }
 
-func (m *Migrator) sync(ctx context.Context) {
+func (m *Migrator) sync(ctx context.Context) error {
 	var (
 		versions versions
 		vi       int
@@ -84,86 +112,201 @@ func (m *Migrator) sync(ctx context.Context) {
 	)
 
 	if !m.versionTableExists {
-		check(adapter.Apply(ctx, m.buildVersionTableDefinition()))
+		if err := adapter.Apply(ctx, m.buildVersionTableDefinition()); err != nil {
+			return err
+		}
+
 		m.versionTableExists = true
 	}
 
-	m.repo.MustFindAll(ctx, &versions, rel.NewSortAsc("version"))
+	if err := m.repo.FindAll(ctx, &versions, rel.NewSortAsc("version")); err != nil {
+		return err
+	}
+
 	sort.Sort(m.versions)
 
 	for i := range m.versions {
 		if vi < len(versions) && m.versions[i].Version == versions[vi].Version {
 			m.versions[i].ID = versions[vi].ID
+			m.versions[i].CreatedAt = versions[vi].CreatedAt
+			m.versions[i].UpdatedAt = versions[vi].UpdatedAt
 			m.versions[i].applied = true
 			vi++
 		} else {
+			m.versions[i].ID = 0
+			m.versions[i].CreatedAt = time.Time{}
+			m.versions[i].UpdatedAt = time.Time{}
 			m.versions[i].applied = false
 		}
 	}
 
 	if vi != len(versions) {
-		panic(fmt.Sprint("rel: missing local migration: ", versions[vi].Version))
+		return fmt.Errorf("rel: missing local migration: %d", versions[vi].Version)
 	}
+
+	return nil
 }
 
-// Migrate to the latest schema version.
+// Status of registered migrations sorted by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.sync(ctx); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.versions))
+	for i, v := range m.versions {
+		statuses[i] = Status{Version: v.Version, Applied: v.applied, AppliedAt: v.CreatedAt}
+	}
+
+	return statuses, nil
+}
+
+// Migrate to the latest schema version, it panics on error.
 func (m *Migrator) Migrate(ctx context.Context) {
-	m.sync(ctx)
+	check(m.MigrateTo(ctx, Latest))
+}
+
+// MigrateTo applies all pending migrations up to and including the target version.
+// Use Latest as target to apply all pending migrations.
+func (m *Migrator) MigrateTo(ctx context.Context, target int) error {
+	if err := m.sync(ctx); err != nil {
+		return err
+	}
 
 	for _, v := range m.versions {
+		if v.Version > target {
+			break
+		}
+
 		if v.applied {
 			continue
 		}
 
-		finish := m.instrumenter.Observe(ctx, "migrate", strconv.Itoa(v.Version)+" "+v.up.String())
+		if err := m.up(ctx, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-		err := m.repo.Transaction(ctx, func(ctx context.Context) error {
-			m.repo.MustInsert(ctx, &version{Version: v.Version})
-			m.run(ctx, v.up.Migrations)
-			return nil
-		})
+// Rollback migration 1 step, it panics on error.
+func (m *Migrator) Rollback(ctx context.Context) {
+	check(m.RollbackSteps(ctx, 1))
+}
 
-		finish(err)
-		check(err)
+// RollbackSteps rolls back the given number of last applied migrations.
+func (m *Migrator) RollbackSteps(ctx context.Context, steps int) error {
+	if err := m.sync(ctx); err != nil {
+		return err
 	}
+
+	for i := len(m.versions) - 1; i >= 0 && steps > 0; i-- {
+		if !m.versions[i].applied {
+			continue
+		}
+
+		if err := m.down(ctx, m.versions[i]); err != nil {
+			return err
+		}
+
+		steps--
+	}
+
+	return nil
 }
 
-// Rollback migration 1 step.
-func (m *Migrator) Rollback(ctx context.Context) {
-	m.sync(ctx)
+// RollbackTo rolls back all applied migrations newer than the target version, the target version itself stays applied.
+// Use 0 as target to rollback all migrations.
+func (m *Migrator) RollbackTo(ctx context.Context, target int) error {
+	if err := m.sync(ctx); err != nil {
+		return err
+	}
 
-	for i := range m.versions {
-		v := m.versions[len(m.versions)-i-1]
-		if !v.applied {
+	for i := len(m.versions) - 1; i >= 0 && m.versions[i].Version > target; i-- {
+		if !m.versions[i].applied {
 			continue
 		}
 
-		finish := m.instrumenter.Observe(ctx, "rollback", strconv.Itoa(v.Version)+" "+v.down.String())
+		if err := m.down(ctx, m.versions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-		err := m.repo.Transaction(ctx, func(ctx context.Context) error {
-			m.repo.MustDelete(ctx, &v)
-			m.run(ctx, v.down.Migrations)
-			return nil
-		})
+// Redo rolls back the last applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) error {
+	if err := m.sync(ctx); err != nil {
+		return err
+	}
 
-		finish(err)
-		check(err)
+	for i := len(m.versions) - 1; i >= 0; i-- {
+		if !m.versions[i].applied {
+			continue
+		}
 
-		// only rollback one version.
-		return
+		if err := m.down(ctx, m.versions[i]); err != nil {
+			return err
+		}
+
+		return m.up(ctx, m.versions[i])
+	}
+
+	return nil
+}
+
+func (m *Migrator) up(ctx context.Context, v version) error {
+	finish := m.instrumenter.Observe(ctx, "migrate", strconv.Itoa(v.Version)+" "+v.up.String())
+
+	err := m.repo.Transaction(rel.WithMigration(ctx), func(ctx context.Context) error {
+		if err := m.repo.Insert(ctx, &version{Version: v.Version}); err != nil {
+			return err
+		}
+
+		return m.run(ctx, v.up.Migrations)
+	})
+
+	finish(err)
+	return err
+}
+
+func (m *Migrator) down(ctx context.Context, v version) error {
+	if v.downErr != nil {
+		return v.downErr
 	}
+
+	finish := m.instrumenter.Observe(ctx, "rollback", strconv.Itoa(v.Version)+" "+v.down.String())
+
+	err := m.repo.Transaction(rel.WithMigration(ctx), func(ctx context.Context) error {
+		if err := m.repo.Delete(ctx, &v); err != nil {
+			return err
+		}
+
+		return m.run(ctx, v.down.Migrations)
+	})
+
+	finish(err)
+	return err
 }
 
-func (m *Migrator) run(ctx context.Context, migrations []rel.Migration) {
+func (m *Migrator) run(ctx context.Context, migrations []rel.Migration) error {
 	adapter := m.repo.Adapter(ctx).(rel.Adapter)
 	for _, migration := range migrations {
+		var err error
 		if fn, ok := migration.(rel.Do); ok {
-			check(fn(m.repo))
+			err = fn(m.repo)
 		} else {
-			check(adapter.Apply(ctx, migration))
+			err = adapter.Apply(ctx, migration)
+		}
+
+		if err != nil {
+			return err
 		}
 	}
 
+	return nil
 }
 
 // New migrationr.
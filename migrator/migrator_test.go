@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-rel/rel"
 	"github.com/go-rel/rel/reltest"
@@ -94,6 +95,62 @@ func TestMigrator(t *testing.T) {
 	})
 }
 
+func TestMigrator_Change(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		repo     = reltest.New()
+		migrator = New(repo)
+	)
+
+	migrator.Change(1, func(schema *rel.Schema) {
+		schema.CreateTable("users", func(t *rel.Table) {
+			t.ID("id")
+		})
+		schema.CreateIndex("users", "id_idx", []string{"id"})
+	})
+
+	migrator.Change(2, func(schema *rel.Schema) {
+		schema.Exec("UPDATE users SET id=id;")
+	})
+
+	migrator.Change(3,
+		func(schema *rel.Schema) {
+			schema.DropTable("tags")
+		},
+		func(schema *rel.Schema) {
+			schema.CreateTable("tags", func(t *rel.Table) {
+				t.ID("id")
+			})
+		},
+	)
+
+	assert.Equal(t, "drop index id_idx on users, drop table users", migrator.versions[0].down.String())
+	assert.Nil(t, migrator.versions[0].downErr)
+	assert.Equal(t, rel.IrreversibleError{Migration: "execute raw command"}, migrator.versions[1].downErr)
+	assert.Equal(t, "create table tags", migrator.versions[2].down.String())
+	assert.Nil(t, migrator.versions[2].downErr)
+
+	t.Run("Rollback", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}, {ID: 2, Version: 2}, {ID: 3, Version: 3}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&migrator.versions[2])
+		})
+
+		migrator.Rollback(ctx)
+	})
+
+	t.Run("Rollback irreversible", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}, {ID: 2, Version: 2}})
+
+		assert.PanicsWithValue(t, rel.IrreversibleError{Migration: "execute raw command"}, func() {
+			migrator.Rollback(ctx)
+		})
+	})
+}
+
 func TestMigrator_Sync(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -105,7 +162,7 @@ func TestMigrator_Sync(t *testing.T) {
 		name    string
 		applied versions
 		synced  versions
-		isPanic bool
+		err     error
 	}{
 		{
 			name: "all migrated",
@@ -178,7 +235,7 @@ func TestMigrator_Sync(t *testing.T) {
 				{ID: 2, Version: 2, applied: true},
 				{ID: 3, Version: 3, applied: true},
 			},
-			isPanic: true,
+			err: errors.New("rel: missing local migration: 4"),
 		},
 	}
 
@@ -191,21 +248,238 @@ func TestMigrator_Sync(t *testing.T) {
 
 			repo.ExpectFindAll(rel.NewSortAsc("version")).Result(test.applied)
 
-			if test.isPanic {
-				assert.Panics(t, func() {
-					migrator.sync(ctx)
-				})
-			} else {
-				assert.NotPanics(t, func() {
-					migrator.sync(ctx)
-				})
-
+			assert.Equal(t, test.err, migrator.sync(ctx))
+			if test.err == nil {
 				assert.Equal(t, test.synced, migrator.versions)
 			}
 		})
 	}
 }
 
+func TestMigrator_Status(t *testing.T) {
+	var (
+		ctx       = context.TODO()
+		repo      = reltest.New()
+		migrator  = New(repo)
+		nfn       = func(schema *rel.Schema) {}
+		appliedAt = time.Date(2020, 8, 29, 8, 40, 0, 0, time.UTC)
+	)
+
+	migrator.Register(2, nfn, nfn)
+	migrator.Register(1, nfn, nfn)
+
+	repo.ExpectFindAll(rel.NewSortAsc("version")).
+		Result(versions{{ID: 1, Version: 1, CreatedAt: appliedAt}})
+
+	statuses, err := migrator.Status(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []Status{
+		{Version: 1, Applied: true, AppliedAt: appliedAt},
+		{Version: 2},
+	}, statuses)
+
+	t.Run("error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).ConnectionClosed()
+
+		statuses, err := migrator.Status(ctx)
+		assert.Equal(t, reltest.ErrConnectionClosed, err)
+		assert.Nil(t, statuses)
+	})
+}
+
+func TestMigrator_MigrateTo(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		repo     = reltest.New()
+		migrator = New(repo)
+		nfn      = func(schema *rel.Schema) {}
+	)
+
+	migrator.Register(1, nfn, nfn)
+	migrator.Register(2, nfn, nfn)
+	migrator.Register(3, nfn, nfn)
+
+	t.Run("target", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).Result(versions{})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectInsert().For(&version{Version: 1})
+		})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectInsert().For(&version{Version: 2})
+		})
+
+		assert.Nil(t, migrator.MigrateTo(ctx, 2))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}, {ID: 2, Version: 2}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectInsert().For(&version{Version: 3}).ConnectionClosed()
+		})
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.MigrateTo(ctx, Latest))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("sync error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).ConnectionClosed()
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.MigrateTo(ctx, Latest))
+		assert.Panics(t, func() {
+			repo.ExpectFindAll(rel.NewSortAsc("version")).ConnectionClosed()
+			migrator.Migrate(ctx)
+		})
+	})
+}
+
+func TestMigrator_RollbackSteps(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		repo     = reltest.New()
+		migrator = New(repo)
+		nfn      = func(schema *rel.Schema) {}
+	)
+
+	migrator.Register(1, nfn, nfn)
+	migrator.Register(2, nfn, nfn)
+	migrator.Register(3, nfn, nfn)
+
+	t.Run("steps", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}, {ID: 2, Version: 2}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 2, Version: 2, applied: true})
+		})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 1, Version: 1, applied: true})
+		})
+
+		assert.Nil(t, migrator.RollbackSteps(ctx, 5))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}, {ID: 2, Version: 2}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 2, Version: 2, applied: true}).ConnectionClosed()
+		})
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.RollbackSteps(ctx, 2))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("sync error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).ConnectionClosed()
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.RollbackSteps(ctx, 1))
+	})
+}
+
+func TestMigrator_RollbackTo(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		repo     = reltest.New()
+		migrator = New(repo)
+		nfn      = func(schema *rel.Schema) {}
+	)
+
+	migrator.Register(1, nfn, nfn)
+	migrator.Register(2, nfn, nfn)
+	migrator.Register(3, nfn, nfn)
+
+	t.Run("target", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}, {ID: 3, Version: 3}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 3, Version: 3, applied: true})
+		})
+
+		assert.Nil(t, migrator.RollbackTo(ctx, 1))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 1, Version: 1, applied: true}).ConnectionClosed()
+		})
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.RollbackTo(ctx, 0))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("sync error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).ConnectionClosed()
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.RollbackTo(ctx, 0))
+	})
+}
+
+func TestMigrator_Redo(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		repo     = reltest.New()
+		migrator = New(repo)
+		nfn      = func(schema *rel.Schema) {}
+	)
+
+	migrator.Register(1, nfn, nfn)
+	migrator.Register(2, nfn, nfn)
+
+	t.Run("redo", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 1, Version: 1, applied: true})
+		})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectInsert().For(&version{Version: 1})
+		})
+
+		assert.Nil(t, migrator.Redo(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("nothing applied", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).Result(versions{})
+
+		assert.Nil(t, migrator.Redo(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).
+			Result(versions{{ID: 1, Version: 1}})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 1, Version: 1, applied: true}).ConnectionClosed()
+		})
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.Redo(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("sync error", func(t *testing.T) {
+		repo.ExpectFindAll(rel.NewSortAsc("version")).ConnectionClosed()
+
+		assert.Equal(t, reltest.ErrConnectionClosed, migrator.Redo(ctx))
+	})
+}
+
 func TestMigrator_Instrumentation(t *testing.T) {
 	var (
 		ctx  = context.TODO()
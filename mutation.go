@@ -23,7 +23,7 @@ func Apply(doc *Document, mutators ...Mutator) Mutation {
 
 	for i := range mutators {
 		switch mut := mutators[i].(type) {
-		case Unscoped, Reload, Cascade:
+		case Unscoped, Reload, Cascade, OnConflict, Validation:
 			optionsCount++
 			mut.Apply(doc, &mutation)
 		default:
@@ -42,18 +42,20 @@ func Apply(doc *Document, mutators ...Mutator) Mutation {
 // AssocMutation represents mutation for association.
 type AssocMutation struct {
 	Mutations  []Mutation
-	DeletedIDs []interface{} // This is array of single id, and doesn't support composite primary key.
+	DeletedIDs []interface{} // This is array of single id, and doesn't support composite primary key. For many to many, it's the referenced foreign values.
 }
 
 // Mutation represents value to be inserted or updated to database.
 // It's not safe to be used multiple time. some operation my alter mutation data.
 type Mutation struct {
-	Mutates   map[string]Mutate
-	Assoc     map[string]AssocMutation
-	Unscoped  Unscoped
-	Reload    Reload
-	Cascade   Cascade
-	ErrorFunc ErrorFunc
+	Mutates     map[string]Mutate
+	Assoc       map[string]AssocMutation
+	Unscoped    Unscoped
+	Reload      Reload
+	Cascade     Cascade
+	OnConflict  OnConflict
+	ErrorFunc   ErrorFunc
+	Validations []Validation
 }
 
 func (m *Mutation) initMutates() {
@@ -237,6 +239,72 @@ func (c Cascade) Apply(doc *Document, mutation *Mutation) {
 	mutation.Cascade = c
 }
 
+// OnConflict mutator.
+// It defines how insertion that conflicts with existing record should be resolved (upsert).
+// Keys or Constraint is used as conflict target, mysql ignores conflict target and uses any unique key instead.
+// Other databases requires conflict target to update conflicting record, otherwise insertion returns error.
+// Only applies to Insert and InsertAll.
+type OnConflict struct {
+	Keys       []string
+	Constraint string
+	Ignore     bool
+	Replace    bool
+	Fields     []string
+	Mutates    []Mutate
+}
+
+// Apply mutation.
+func (oc OnConflict) Apply(doc *Document, mutation *Mutation) {
+	mutation.OnConflict = oc
+}
+
+// IsZero returns true if no conflict resolution is defined.
+func (oc OnConflict) IsZero() bool {
+	return !oc.Ignore && !oc.Replace && len(oc.Mutates) == 0
+}
+
+// DoNothing ignores the insertion when conflict happens.
+func (oc OnConflict) DoNothing() OnConflict {
+	oc.Ignore = true
+	return oc
+}
+
+// DoReplace replaces existing record with inserted values when conflict happens.
+// If fields is empty, all inserted fields will be replaced.
+func (oc OnConflict) DoReplace(fields ...string) OnConflict {
+	oc.Replace = true
+	oc.Fields = fields
+	return oc
+}
+
+// DoUpdate applies mutates to existing record when conflict happens.
+// Value of Inc and Dec is applied relative to existing record.
+func (oc OnConflict) DoUpdate(mutates ...Mutate) OnConflict {
+	oc.Mutates = append(oc.Mutates, mutates...)
+	return oc
+}
+
+// OnConflictKeys create on conflict mutator using given columns as conflict target.
+func OnConflictKeys(keys ...string) OnConflict {
+	return OnConflict{Keys: keys}
+}
+
+// OnConflictConstraint create on conflict mutator using given constraint name as conflict target.
+// Only supported by postgres.
+func OnConflictConstraint(name string) OnConflict {
+	return OnConflict{Constraint: name}
+}
+
+// OnConflictIgnore ignores the insertion when conflict happens on any unique key.
+func OnConflictIgnore() OnConflict {
+	return OnConflict{Ignore: true}
+}
+
+// OnConflictReplace replaces all inserted fields when conflict happens on given keys.
+func OnConflictReplace(keys ...string) OnConflict {
+	return OnConflict{Keys: keys, Replace: true}
+}
+
 // ErrorFunc allows conversion REL's error to Application custom errors.
 type ErrorFunc func(error) error
 
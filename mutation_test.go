@@ -126,3 +126,73 @@ func TestApplyMutation_Cascade(t *testing.T) {
 	assert.Equal(t, mutation, Apply(doc, mutators...))
 	assert.Equal(t, "string", record.Field1)
 }
+
+func TestApplyMutation_OnConflict(t *testing.T) {
+	var (
+		record     = TestRecord{}
+		doc        = NewDocument(&record)
+		onConflict = OnConflictKeys("field1").DoReplace()
+		mutators   = []Mutator{
+			Set("field1", "string"),
+			onConflict,
+		}
+		mutation = Mutation{
+			Mutates: map[string]Mutate{
+				"field1": Set("field1", "string"),
+			},
+			Cascade:    true,
+			OnConflict: onConflict,
+		}
+	)
+
+	assert.Equal(t, mutation, Apply(doc, mutators...))
+	assert.Equal(t, "string", record.Field1)
+}
+
+func TestOnConflict(t *testing.T) {
+	tests := []struct {
+		name       string
+		onConflict OnConflict
+		result     OnConflict
+		zero       bool
+	}{
+		{
+			name:       "zero",
+			onConflict: OnConflictKeys("email"),
+			result:     OnConflict{Keys: []string{"email"}},
+			zero:       true,
+		},
+		{
+			name:       "OnConflictIgnore",
+			onConflict: OnConflictIgnore(),
+			result:     OnConflict{Ignore: true},
+		},
+		{
+			name:       "OnConflictReplace",
+			onConflict: OnConflictReplace("email"),
+			result:     OnConflict{Keys: []string{"email"}, Replace: true},
+		},
+		{
+			name:       "DoNothing",
+			onConflict: OnConflictConstraint("users_email_key").DoNothing(),
+			result:     OnConflict{Constraint: "users_email_key", Ignore: true},
+		},
+		{
+			name:       "DoReplace",
+			onConflict: OnConflictKeys("email").DoReplace("name"),
+			result:     OnConflict{Keys: []string{"email"}, Replace: true, Fields: []string{"name"}},
+		},
+		{
+			name:       "DoUpdate",
+			onConflict: OnConflictKeys("email").DoUpdate(Inc("count")),
+			result:     OnConflict{Keys: []string{"email"}, Mutates: []Mutate{Inc("count")}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.result, test.onConflict)
+			assert.Equal(t, test.zero, test.onConflict.IsZero())
+		})
+	}
+}
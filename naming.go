@@ -0,0 +1,45 @@
+package rel
+
+import (
+	"github.com/jinzhu/inflection"
+	"github.com/serenize/snaker"
+)
+
+// NamingStrategy defines how table, column and key names are inferred from struct and field names.
+// It's only used when the name is not defined explicitly using Table method or db tag.
+// Implementation must be comparable, since it's used as part of the document cache key.
+type NamingStrategy interface {
+	// Table name of a struct type.
+	Table(typeName string) string
+	// Column name of a struct field.
+	Column(fieldName string) string
+	// ForeignKey column that references a struct type.
+	// It's used to guess foreign key of association and the columns of many to many join table.
+	ForeignKey(typeName string) string
+	// JoinTable name of many to many association between two struct types.
+	// It's used when many2many tag is declared without a value.
+	JoinTable(typeName string, otherTypeName string) string
+}
+
+// DefaultNamingStrategy uses pluralized snake case table name, snake case column name, and type name suffixed with id as foreign key.
+type DefaultNamingStrategy struct{}
+
+// Table name of a struct type, for example UserRole becomes user_roles.
+func (DefaultNamingStrategy) Table(typeName string) string {
+	return snaker.CamelToSnake(inflection.Plural(typeName))
+}
+
+// Column name of a struct field, for example CreatedAt becomes created_at.
+func (DefaultNamingStrategy) Column(fieldName string) string {
+	return snaker.CamelToSnake(fieldName)
+}
+
+// ForeignKey column that references a struct type, for example User becomes user_id.
+func (DefaultNamingStrategy) ForeignKey(typeName string) string {
+	return snaker.CamelToSnake(typeName) + "_id"
+}
+
+// JoinTable name of many to many association, for example Article and Tag becomes article_tags.
+func (DefaultNamingStrategy) JoinTable(typeName string, otherTypeName string) string {
+	return snaker.CamelToSnake(typeName) + "_" + snaker.CamelToSnake(inflection.Plural(otherTypeName))
+}
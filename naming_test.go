@@ -0,0 +1,311 @@
+package rel
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type legacyNaming struct{}
+
+func (legacyNaming) Table(typeName string) string {
+	return "tbl_" + typeName
+}
+
+func (legacyNaming) Column(fieldName string) string {
+	return fieldName
+}
+
+func (legacyNaming) ForeignKey(typeName string) string {
+	return typeName + "ID"
+}
+
+func (legacyNaming) JoinTable(typeName string, otherTypeName string) string {
+	return "tbl_" + typeName + otherTypeName
+}
+
+type LegacyOwner struct {
+	ID   int
+	Name string
+	Pets []LegacyPet
+	Tags []LegacyTag `many2many:""`
+}
+
+type LegacyPet struct {
+	ID            int
+	Name          string
+	LegacyOwnerID int
+	LegacyOwner   *LegacyOwner
+}
+
+type LegacyTag struct {
+	ID   int
+	Name string
+}
+
+func TestDefaultNamingStrategy(t *testing.T) {
+	var (
+		naming = DefaultNamingStrategy{}
+	)
+
+	assert.Equal(t, "user_roles", naming.Table("UserRole"))
+	assert.Equal(t, "created_at", naming.Column("CreatedAt"))
+	assert.Equal(t, "user_role_id", naming.ForeignKey("UserRole"))
+	assert.Equal(t, "article_tags", naming.JoinTable("Article", "Tag"))
+}
+
+func TestNamingStrategy_document(t *testing.T) {
+	var (
+		owner  LegacyOwner
+		doc    = documentWithNaming(&owner, legacyNaming{}, false)
+		defDoc = NewDocument(&owner)
+	)
+
+	assert.Equal(t, "tbl_LegacyOwner", doc.Table())
+	assert.Equal(t, []string{"ID", "Name"}, doc.Fields())
+	assert.Equal(t, "ID", doc.PrimaryField())
+	assert.Equal(t, []string{"Pets", "Tags"}, doc.HasMany())
+
+	// cached separately from default naming strategy.
+	assert.Equal(t, "legacy_owners", defDoc.Table())
+	assert.Equal(t, []string{"id", "name"}, defDoc.Fields())
+}
+
+func TestNamingStrategy_association(t *testing.T) {
+	var (
+		pet   LegacyPet
+		owner LegacyOwner
+		pets  = documentWithNaming(&owner, legacyNaming{}, false).Association("Pets")
+		tags  = documentWithNaming(&owner, legacyNaming{}, false).Association("Tags")
+		assoc = documentWithNaming(&pet, legacyNaming{}, false).Association("LegacyOwner")
+	)
+
+	assert.Equal(t, HasMany, int(pets.Type()))
+	assert.Equal(t, "ID", pets.ReferenceField())
+	assert.Equal(t, "LegacyOwnerID", pets.ForeignField())
+
+	assert.Equal(t, BelongsTo, int(assoc.Type()))
+	assert.Equal(t, "LegacyOwnerID", assoc.ReferenceField())
+	assert.Equal(t, "ID", assoc.ForeignField())
+
+	assert.Equal(t, ManyToMany, int(tags.Type()))
+	assert.Equal(t, "tbl_LegacyOwnerLegacyTag", tags.JoinTable())
+	assert.Equal(t, "LegacyOwnerID", tags.JoinReferenceField())
+	assert.Equal(t, "LegacyTagID", tags.JoinForeignField())
+
+	col, _ := pets.Collection()
+	assert.Equal(t, "tbl_LegacyPet", col.Table())
+	assert.Equal(t, []string{"ID", "Name", "LegacyOwnerID"}, col.Add().Fields())
+}
+
+func TestRepository_Naming(t *testing.T) {
+	var (
+		owners  []LegacyOwner
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("tbl_LegacyOwner").Where(Eq("Name", "owner"))
+		cur     = &testCursor{}
+	)
+
+	repo.Naming(legacyNaming{})
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+
+	cur.On("Fields").Return([]string{"ID", "Name"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, "owner").Once()
+	cur.On("Next").Return(false).Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &owners, Eq("Name", "owner")))
+	assert.Equal(t, []LegacyOwner{{ID: 1, Name: "owner"}}, owners)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+type LegacyMember struct {
+	ID          int
+	Memberships []LegacyMembership
+	Badges      []LegacyBadge `through:"Memberships" join_fk:"BadgeID"`
+}
+
+type LegacyMembership struct {
+	ID             int
+	LegacyMemberID int
+	BadgeID        int
+}
+
+type LegacyBadge struct {
+	ID   int
+	Name string
+}
+
+func TestNamingStrategy_joinThrough(t *testing.T) {
+	query, err := resolveJoinAssoc(reflect.TypeOf(LegacyMember{}), legacyNaming{}, From("tbl_LegacyMember").JoinAssoc("Badges"))
+	assert.Nil(t, err)
+	assert.Equal(t, []JoinQuery{
+		NewJoinOn("tbl_LegacyMembership", "tbl_LegacyMembership.LegacyMemberID", "tbl_LegacyMember.ID"),
+		NewJoinOn("tbl_LegacyBadge", "tbl_LegacyBadge.ID", "tbl_LegacyMembership.BadgeID"),
+	}, query.JoinQuery)
+}
+
+func TestRepository_Naming_changeset(t *testing.T) {
+	var (
+		owner     = LegacyOwner{ID: 1, Name: "owner"}
+		changeset = NewChangeset(&owner)
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+	)
+
+	repo.Naming(legacyNaming{})
+	owner.Name = "updated"
+
+	adapter.On("Update", From("tbl_LegacyOwner").Where(Eq("ID", 1)), map[string]Mutate{
+		"Name": Set("Name", "updated"),
+	}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &owner, changeset))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Naming_document(t *testing.T) {
+	var (
+		owner   = LegacyOwner{ID: 1, Name: "owner"}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	repo.Naming(legacyNaming{})
+
+	adapter.On("Delete", From("tbl_LegacyOwner").Where(Eq("ID", 1))).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), NewDocument(&owner)))
+
+	adapter.AssertExpectations(t)
+}
+
+type LegacyPost struct {
+	ID            int
+	Title         string
+	LegacyOwnerID int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     *time.Time
+}
+
+type LegacyBlog struct {
+	ID    int
+	Posts []LegacyPost `ref:"ID" fk:"LegacyOwnerID"`
+}
+
+func TestNamingStrategy_flags(t *testing.T) {
+	var (
+		doc = newDocument(&LegacyPost{}, reflect.ValueOf(&LegacyPost{}), legacyNaming{}, false)
+	)
+
+	assert.True(t, doc.Flag(HasCreatedAt))
+	assert.True(t, doc.Flag(HasUpdatedAt))
+	assert.True(t, doc.Flag(HasDeletedAt))
+	assert.Equal(t, "CreatedAt", doc.data.flagFieldName(HasCreatedAt))
+	assert.Equal(t, "UpdatedAt", doc.data.flagFieldName(HasUpdatedAt))
+	assert.Equal(t, "DeletedAt", doc.data.flagFieldName(HasDeletedAt))
+
+	query, err := resolveJoinAssoc(reflect.TypeOf(LegacyBlog{}), legacyNaming{}, From("tbl_LegacyBlog").JoinAssoc("Posts"))
+	assert.Nil(t, err)
+	assert.Equal(t, []JoinQuery{
+		{Mode: "JOIN", Table: "tbl_LegacyPost", From: "tbl_LegacyPost.LegacyOwnerID", To: "tbl_LegacyBlog.ID", Filter: Nil("tbl_LegacyPost.DeletedAt")},
+	}, query.JoinQuery)
+}
+
+func TestRepository_Naming_timestamps(t *testing.T) {
+	var (
+		post      = LegacyPost{Title: "post"}
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		timestamp = now().Truncate(time.Second)
+	)
+
+	repo.Naming(legacyNaming{})
+
+	adapter.On("Insert", From("tbl_LegacyPost"), map[string]Mutate{
+		"Title":         Set("Title", "post"),
+		"LegacyOwnerID": Set("LegacyOwnerID", 0),
+		"CreatedAt":     Set("CreatedAt", timestamp),
+		"UpdatedAt":     Set("UpdatedAt", timestamp),
+		"DeletedAt":     Set("DeletedAt", nil),
+	}, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &post))
+	assert.Equal(t, timestamp, post.CreatedAt)
+	assert.Equal(t, timestamp, post.UpdatedAt)
+
+	changeset := NewChangeset(&post)
+	post.Title = "updated"
+
+	adapter.On("Update", From("tbl_LegacyPost").Where(Eq("ID", 1).AndNil("DeletedAt")), map[string]Mutate{
+		"Title":     Set("Title", "updated"),
+		"UpdatedAt": Set("UpdatedAt", timestamp),
+	}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &post, changeset))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Naming_softDelete(t *testing.T) {
+	var (
+		posts   []LegacyPost
+		post    = LegacyPost{ID: 1}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = createCursor(0)
+	)
+
+	repo.Naming(legacyNaming{})
+
+	adapter.On("Query", From("tbl_LegacyPost").Where(Nil("DeletedAt"))).Return(cur, nil).Once()
+	adapter.On("Update", From("tbl_LegacyPost").Where(Eq("ID", 1)), map[string]Mutate{
+		"DeletedAt": Set("DeletedAt", now()),
+	}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &posts))
+	assert.Nil(t, repo.Delete(context.TODO(), &post))
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+type LegacyAccount struct {
+	ID          int
+	Balance     int
+	LockVersion int
+}
+
+func TestRepository_Naming_lockVersion(t *testing.T) {
+	var (
+		account = LegacyAccount{ID: 1, Balance: 10, LockVersion: 2}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	repo.Naming(legacyNaming{})
+
+	adapter.On("Update", From("tbl_LegacyAccount").Where(Eq("ID", 1).AndEq("LockVersion", 2)), map[string]Mutate{
+		"Balance":     Set("Balance", 20),
+		"LockVersion": Set("LockVersion", 3),
+	}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &account, Set("Balance", 20)))
+	assert.Equal(t, 3, account.LockVersion)
+
+	adapter.On("Delete", From("tbl_LegacyAccount").Where(Eq("ID", 1).AndEq("LockVersion", 3))).Return(0, nil).Once()
+
+	assert.Equal(t, StaleRecordError{}, repo.Delete(context.TODO(), &account))
+
+	adapter.AssertExpectations(t)
+}
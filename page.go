@@ -0,0 +1,205 @@
+package rel
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidPageCursor returned by FindPage when the given cursor is malformed or doesn't match the query's sort.
+var ErrInvalidPageCursor = errors.New("rel: invalid page cursor")
+
+// ErrInvalidPageSize returned by FindPage when the given page size is not positive.
+var ErrInvalidPageSize = errors.New("rel: invalid page size")
+
+// pageKey is a single column used to position a record in keyset pagination.
+type pageKey struct {
+	field    string
+	name     string
+	typ      reflect.Type
+	desc     bool
+	nullable bool
+}
+
+// pageKeys resolves keyset columns from query's sort followed by primary fields as tie-breaker.
+// It returns error when a sort field is not exists in the record.
+func pageKeys(col *Collection, sorts []SortQuery) ([]pageKey, error) {
+	var (
+		rt      = col.rt.Elem()
+		pFields = col.PrimaryFields()
+		keys    = make([]pageKey, 0, len(sorts)+len(pFields))
+		exists  = make(map[string]bool, len(sorts)+len(pFields))
+	)
+
+	add := func(field string, desc bool) error {
+		var (
+			name = field
+		)
+
+		if i := strings.LastIndexByte(field, '.'); i >= 0 {
+			name = field[i+1:]
+		}
+
+		if exists[name] {
+			return nil
+		}
+
+		index, ok := col.data.fieldIndex[name]
+		if !ok {
+			return errors.New("rel: cannot paginate using field " + field + ", field is not exists in " + rt.String())
+		}
+
+		var (
+			ft       = rt.FieldByIndex(index).Type
+			nullable = ft.Kind() == reflect.Ptr
+		)
+
+		if nullable {
+			ft = ft.Elem()
+		}
+
+		exists[name] = true
+		keys = append(keys, pageKey{
+			field:    field,
+			name:     name,
+			typ:      ft,
+			desc:     desc,
+			nullable: nullable,
+		})
+
+		return nil
+	}
+
+	for i := range sorts {
+		if err := add(sorts[i].Field, sorts[i].Desc()); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range pFields {
+		if err := add(pFields[i], false); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// pageSort returns sort of keyset, null values of nullable field is always sorted last regardless of its direction.
+func pageSort(keys []pageKey) []SortQuery {
+	var (
+		sorts = make([]SortQuery, 0, len(keys))
+	)
+
+	for _, key := range keys {
+		if key.nullable {
+			sorts = append(sorts, NewSortAsc("^"+key.field+" IS NULL"))
+		}
+
+		if key.desc {
+			sorts = append(sorts, NewSortDesc(key.field))
+		} else {
+			sorts = append(sorts, NewSortAsc(key.field))
+		}
+	}
+
+	return sorts
+}
+
+// filterPage builds filter that matches records positioned after given keyset values.
+// it's expanded as: (a > x) OR (a = x AND b > y) OR ...
+func filterPage(keys []pageKey, values []interface{}) FilterQuery {
+	var (
+		filters = make([]FilterQuery, 0, len(keys))
+	)
+
+	for i, key := range keys {
+		// null is sorted last, no record positioned after it on this column.
+		if values[i] == nil {
+			continue
+		}
+
+		var (
+			after FilterQuery
+			inner = make([]FilterQuery, 0, i+1)
+		)
+
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				inner = append(inner, Nil(keys[j].field))
+			} else {
+				inner = append(inner, Eq(keys[j].field, values[j]))
+			}
+		}
+
+		if key.desc {
+			after = Lt(key.field, values[i])
+		} else {
+			after = Gt(key.field, values[i])
+		}
+
+		if key.nullable {
+			after = Or(after, Nil(key.field))
+		}
+
+		filters = append(filters, And(append(inner, after)...))
+	}
+
+	return Or(filters...)
+}
+
+func encodePageCursor(keys []pageKey, doc *Document) (string, error) {
+	var (
+		values = make([]interface{}, len(keys))
+	)
+
+	for i, key := range keys {
+		values[i], _ = doc.Value(key.name)
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodePageCursor(keys []pageKey, cursor string) ([]interface{}, error) {
+	var (
+		raws []json.RawMessage
+	)
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidPageCursor
+	}
+
+	if err := json.Unmarshal(b, &raws); err != nil || len(raws) != len(keys) {
+		return nil, ErrInvalidPageCursor
+	}
+
+	var (
+		values = make([]interface{}, len(keys))
+	)
+
+	for i, key := range keys {
+		if string(raws[i]) == "null" {
+			continue
+		}
+
+		var (
+			rv = reflect.New(key.typ)
+		)
+
+		if err := json.Unmarshal(raws[i], rv.Interface()); err != nil {
+			return nil, ErrInvalidPageCursor
+		}
+
+		values[i] = rv.Elem().Interface()
+	}
+
+	return values, nil
+}
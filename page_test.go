@@ -0,0 +1,132 @@
+package rel
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageKeys(t *testing.T) {
+	var (
+		profiles []Profile
+		col      = NewCollection(&profiles)
+		keys, _  = pageKeys(col, []SortQuery{NewSortDesc("profiles.user_id"), NewSortAsc("name"), NewSortAsc("user_id")})
+	)
+
+	assert.Equal(t, []pageKey{
+		{field: "profiles.user_id", name: "user_id", typ: reflect.TypeOf(0), desc: true, nullable: true},
+		{field: "name", name: "name", typ: reflect.TypeOf(""), desc: false},
+		{field: "id", name: "id", typ: reflect.TypeOf(0), desc: false},
+	}, keys)
+}
+
+func TestPageKeys_unknownField(t *testing.T) {
+	var (
+		profiles []Profile
+		col      = NewCollection(&profiles)
+	)
+
+	keys, err := pageKeys(col, []SortQuery{NewSortAsc("unknown")})
+	assert.Nil(t, keys)
+	assert.Equal(t, "rel: cannot paginate using field unknown, field is not exists in rel.Profile", err.Error())
+}
+
+func TestPageSort(t *testing.T) {
+	var (
+		profiles []Profile
+		col      = NewCollection(&profiles)
+		keys, _  = pageKeys(col, []SortQuery{NewSortDesc("user_id"), NewSortAsc("name")})
+	)
+
+	assert.Equal(t, []SortQuery{
+		NewSortAsc("^user_id IS NULL"),
+		NewSortDesc("user_id"),
+		NewSortAsc("name"),
+		NewSortAsc("id"),
+	}, pageSort(keys))
+}
+
+func TestFilterPage(t *testing.T) {
+	var (
+		profiles []Profile
+		col      = NewCollection(&profiles)
+		keys, _  = pageKeys(col, []SortQuery{NewSortDesc("user_id"), NewSortAsc("name")})
+	)
+
+	tests := []struct {
+		name   string
+		values []interface{}
+		result FilterQuery
+	}{
+		{
+			name:   "non null",
+			values: []interface{}{1, "name", 2},
+			result: Or(
+				Or(Lt("user_id", 1), Nil("user_id")),
+				And(Eq("user_id", 1), Gt("name", "name")),
+				And(Eq("user_id", 1), Eq("name", "name"), Gt("id", 2)),
+			),
+		},
+		{
+			name:   "null",
+			values: []interface{}{nil, "name", 2},
+			result: Or(
+				And(Nil("user_id"), Gt("name", "name")),
+				And(Nil("user_id"), Eq("name", "name"), Gt("id", 2)),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.result, filterPage(keys, test.values))
+		})
+	}
+}
+
+func TestPageCursor(t *testing.T) {
+	var (
+		userID   = 1
+		profiles = []Profile{{ID: 2, Name: "name", UserID: &userID}, {ID: 3, Name: "name"}}
+		col      = NewCollection(&profiles)
+		keys, _  = pageKeys(col, []SortQuery{NewSortDesc("user_id"), NewSortAsc("name")})
+	)
+
+	cursor, err := encodePageCursor(keys, col.Get(0))
+	assert.Nil(t, err)
+
+	values, err := decodePageCursor(keys, cursor)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, "name", 2}, values)
+
+	cursor, err = encodePageCursor(keys, col.Get(1))
+	assert.Nil(t, err)
+
+	values, err = decodePageCursor(keys, cursor)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{nil, "name", 3}, values)
+}
+
+func TestDecodePageCursor_invalid(t *testing.T) {
+	var (
+		profiles []Profile
+		col      = NewCollection(&profiles)
+		keys, _  = pageKeys(col, []SortQuery{NewSortAsc("name")})
+	)
+
+	tests := []string{
+		"%",
+		"bm90IGpzb24", // not json
+		"WzFd",        // [1]
+		"WzEsIjIiXQ",  // [1,"2"]
+		"WyJuYW1lIl0", // ["name"]
+	}
+
+	for _, cursor := range tests {
+		t.Run(cursor, func(t *testing.T) {
+			_, err := decodePageCursor(keys, cursor)
+			assert.Equal(t, ErrInvalidPageCursor, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package rel
+
+// NestedPreload defines association to be preloaded after its parent association is loaded.
+// It's used as querier of the parent preload to build a preload tree, field is relative to the parent association.
+// Each level of the tree is loaded using a single query regardless number of the parent records.
+type NestedPreload struct {
+	Field    string
+	Queriers []Querier
+}
+
+// Build query.
+// Nested preload is resolved by repository when preloading, it doesn't modify the query.
+func (np NestedPreload) Build(query *Query) {}
+
+// NewNestedPreload defines nested association to be preloaded using given queriers.
+func NewNestedPreload(field string, queriers ...Querier) NestedPreload {
+	return NestedPreload{
+		Field:    field,
+		Queriers: queriers,
+	}
+}
+
+// PreloadLimit limits number of preloaded records for each parent record, for example to load latest five comments of each post.
+// Records of each parent are numbered using ROW_NUMBER window function ordered by the sort query of the preload.
+type PreloadLimit int
+
+// Build query.
+// Preload limit is resolved by repository when preloading, it doesn't modify the query.
+func (pl PreloadLimit) Build(query *Query) {}
+
+// preloadQueriers splits nested preload and preload limit from the rest of queriers.
+func preloadQueriers(queriers []Querier) ([]Querier, []NestedPreload, PreloadLimit) {
+	var (
+		limit  PreloadLimit
+		nested []NestedPreload
+		result = make([]Querier, 0, len(queriers))
+	)
+
+	for _, querier := range queriers {
+		switch q := querier.(type) {
+		case NestedPreload:
+			nested = append(nested, q)
+		case PreloadLimit:
+			limit = q
+		default:
+			result = append(result, q)
+		}
+	}
+
+	return result, nested, limit
+}
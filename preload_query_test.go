@@ -0,0 +1,20 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNestedPreload(t *testing.T) {
+	assert.Equal(t, rel.NestedPreload{
+		Field:    "product",
+		Queriers: []rel.Querier{where.Eq("active", true), rel.PreloadLimit(5)},
+	}, rel.NewNestedPreload("product", where.Eq("active", true), rel.PreloadLimit(5)))
+}
+
+func TestNestedPreload_Build(t *testing.T) {
+	assert.Equal(t, rel.From("orders"), rel.Build("", rel.From("orders"), rel.NewNestedPreload("items"), rel.PreloadLimit(5)))
+}
@@ -44,6 +44,10 @@ func Build(table string, queriers ...Querier) Query {
 			q.Build(&query)
 		case Cascade:
 			q.Build(&query)
+		case WithQuery:
+			q.Build(&query)
+		case CombinationQuery:
+			q.Build(&query)
 		}
 	}
 
@@ -56,21 +60,25 @@ func Build(table string, queriers ...Querier) Query {
 
 // Query defines information about query generated by query builder.
 type Query struct {
-	empty         bool // TODO: use bitmask to mark what is updated and use it when merging two queries
-	Table         string
-	SelectQuery   SelectQuery
-	JoinQuery     []JoinQuery
-	WhereQuery    FilterQuery
-	GroupQuery    GroupQuery
-	SortQuery     []SortQuery
-	OffsetQuery   Offset
-	LimitQuery    Limit
-	LockQuery     Lock
-	SQLQuery      SQLQuery
-	UnscopedQuery Unscoped
-	ReloadQuery   Reload
-	CascadeQuery  Cascade
-	PreloadQuery  []string
+	empty            bool // TODO: use bitmask to mark what is updated and use it when merging two queries
+	WithQuery        []WithQuery
+	Table            string
+	TableQuery       *Query
+	Alias            string
+	SelectQuery      SelectQuery
+	JoinQuery        []JoinQuery
+	WhereQuery       FilterQuery
+	GroupQuery       GroupQuery
+	CombinationQuery []CombinationQuery
+	SortQuery        []SortQuery
+	OffsetQuery      Offset
+	LimitQuery       Limit
+	LockQuery        Lock
+	SQLQuery         SQLQuery
+	UnscopedQuery    Unscoped
+	ReloadQuery      Reload
+	CascadeQuery     Cascade
+	PreloadQuery     []string
 }
 
 // Build query.
@@ -79,11 +87,21 @@ func (q Query) Build(query *Query) {
 		*query = q
 	} else {
 		// manual merge
+		query.WithQuery = append(query.WithQuery, q.WithQuery...)
+
 		if q.Table != "" {
 			query.Table = q.Table
 		}
 
-		if q.SelectQuery.Fields != nil {
+		if q.TableQuery != nil {
+			query.TableQuery = q.TableQuery
+		}
+
+		if q.Alias != "" {
+			query.Alias = q.Alias
+		}
+
+		if q.SelectQuery.Fields != nil || q.SelectQuery.Exprs != nil {
 			query.SelectQuery = q.SelectQuery
 		}
 
@@ -97,6 +115,8 @@ func (q Query) Build(query *Query) {
 			query.GroupQuery = q.GroupQuery
 		}
 
+		query.CombinationQuery = append(query.CombinationQuery, q.CombinationQuery...)
+
 		q.SortQuery = append(q.SortQuery, query.SortQuery...)
 
 		if q.OffsetQuery != 0 {
@@ -122,9 +142,48 @@ func (q Query) Select(fields ...string) Query {
 	return q
 }
 
-// From set the table to be used for query.
-func (q Query) From(table string) Query {
-	q.Table = table
+// SelectExpr adds expressions such as aggregate and window function to be selected after fields.
+// Select resets the expressions, so it should be called before SelectExpr.
+func (q Query) SelectExpr(exprs ...Expr) Query {
+	q.SelectQuery = q.SelectQuery.Expr(exprs...)
+	return q
+}
+
+// From set the table or subquery to be used for query.
+// Source is either a table name or a Query, use As to set alias of the subquery,
+// otherwise the table of the query or the subquery is used as alias since derived table must be aliased.
+func (q Query) From(source interface{}) Query {
+	q.from(source)
+	return q
+}
+
+func (q *Query) from(source interface{}) {
+	switch s := source.(type) {
+	case string:
+		q.Table = s
+		q.TableQuery = nil
+	case Query:
+		q.TableQuery = &s
+	default:
+		panic("rel: query source must be a table name or a query")
+	}
+}
+
+// As set alias for the table or subquery used by the query.
+func (q Query) As(alias string) Query {
+	q.Alias = alias
+	return q
+}
+
+// With adds common table expression that can be referenced by name in the query.
+func (q Query) With(name string, query Query) Query {
+	NewWith(name, query).Build(&q)
+	return q
+}
+
+// WithRecursive adds recursive common table expression that can be referenced by name in the query.
+func (q Query) WithRecursive(name string, query Query) Query {
+	NewWithRecursive(name, query).Build(&q)
 	return q
 }
 
@@ -158,6 +217,34 @@ func (q Query) Joinf(expr string, args ...interface{}) Query {
 	return q
 }
 
+// JoinAssoc current table with its association.
+// Association is a dot separated path of association name relative to the queried record, for example "buyer.address".
+// Through association is joined using association of the intermediate record with the same name,
+// or the intermediate field declared using join_fk tag.
+func (q Query) JoinAssoc(assoc string) Query {
+	return q.JoinAssocWith("JOIN", assoc)
+}
+
+// JoinAssocWith current table with its association with custom join mode.
+func (q Query) JoinAssocWith(mode string, assoc string) Query {
+	NewJoinAssocWith(mode, assoc).Build(&q)
+
+	return q
+}
+
+// JoinPopulate current table with its association and scan joined columns into the associated struct.
+// Only belongs to and has one association can be populated.
+func (q Query) JoinPopulate(assoc string) Query {
+	return q.JoinPopulateWith("JOIN", assoc)
+}
+
+// JoinPopulateWith current table with its association with custom join mode and scan joined columns into the associated struct.
+func (q Query) JoinPopulateWith(mode string, assoc string) Query {
+	NewJoinPopulateWith(mode, assoc).Build(&q)
+
+	return q
+}
+
 // Where query.
 func (q Query) Where(filters ...FilterQuery) Query {
 	q.WhereQuery = q.WhereQuery.And(filters...)
@@ -212,6 +299,36 @@ func (q Query) OrHavingf(expr string, args ...interface{}) Query {
 	return q
 }
 
+// Union combines result of the query with other query, removing duplicate rows.
+// Sort, offset and limit of the query are applied to the combined result.
+func (q Query) Union(query Query) Query {
+	NewUnion(query).Build(&q)
+	return q
+}
+
+// UnionAll combines result of the query with other query, including duplicate rows.
+// Sort, offset and limit of the query are applied to the combined result.
+func (q Query) UnionAll(query Query) Query {
+	NewUnionAll(query).Build(&q)
+	return q
+}
+
+// Intersect returns rows that exists in both result of the query and other query.
+// Sort, offset and limit of the query are applied to the combined result.
+// It requires MySQL 8.0.31 or later.
+func (q Query) Intersect(query Query) Query {
+	NewIntersect(query).Build(&q)
+	return q
+}
+
+// Except returns rows of the query that doesn't exists in result of other query.
+// Sort, offset and limit of the query are applied to the combined result.
+// It requires MySQL 8.0.31 or later.
+func (q Query) Except(query Query) Query {
+	NewExcept(query).Build(&q)
+	return q
+}
+
 // Sort query.
 func (q Query) Sort(fields ...string) Query {
 	return q.SortAsc(fields...)
@@ -300,13 +417,35 @@ func Select(fields ...string) Query {
 	return query
 }
 
+// SelectExpr create a query with chainable syntax, using select expressions as the starting point.
+func SelectExpr(exprs ...Expr) Query {
+	query := newQuery()
+	query.SelectQuery.Exprs = exprs
+	return query
+}
+
 // From create a query with chainable syntax, using from as the starting point.
-func From(table string) Query {
+// Source is either a table name or a subquery, see Query.From.
+func From(source interface{}) Query {
 	query := newQuery()
-	query.Table = table
+	query.from(source)
 	return query
 }
 
+// With create a query with chainable syntax, using common table expression as the starting point.
+func With(name string, query Query) Query {
+	q := newQuery()
+	q.WithQuery = []WithQuery{NewWith(name, query)}
+	return q
+}
+
+// WithRecursive create a query with chainable syntax, using recursive common table expression as the starting point.
+func WithRecursive(name string, query Query) Query {
+	q := newQuery()
+	q.WithQuery = []WithQuery{NewWithRecursive(name, query)}
+	return q
+}
+
 // Join create a query with chainable syntax, using join as the starting point.
 func Join(table string) Query {
 	return JoinOn(table, "", "")
@@ -335,6 +474,34 @@ func Joinf(expr string, args ...interface{}) Query {
 	return query
 }
 
+// JoinAssoc create a query with chainable syntax, using association join as the starting point.
+func JoinAssoc(assoc string) Query {
+	return JoinAssocWith("JOIN", assoc)
+}
+
+// JoinAssocWith create a query with chainable syntax, using association join as the starting point.
+func JoinAssocWith(mode string, assoc string) Query {
+	query := newQuery()
+	query.JoinQuery = []JoinQuery{
+		NewJoinAssocWith(mode, assoc),
+	}
+	return query
+}
+
+// JoinPopulate create a query with chainable syntax, using populated association join as the starting point.
+func JoinPopulate(assoc string) Query {
+	return JoinPopulateWith("JOIN", assoc)
+}
+
+// JoinPopulateWith create a query with chainable syntax, using populated association join as the starting point.
+func JoinPopulateWith(mode string, assoc string) Query {
+	query := newQuery()
+	query.JoinQuery = []JoinQuery{
+		NewJoinPopulateWith(mode, assoc),
+	}
+	return query
+}
+
 // Where create a query with chainable syntax, using where as the starting point.
 func Where(filters ...FilterQuery) Query {
 	query := newQuery()
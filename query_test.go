@@ -222,6 +222,28 @@ func TestQuery_Distinct(t *testing.T) {
 	}, rel.From("users").Select("*").Distinct())
 }
 
+func TestQuery_From_subquery(t *testing.T) {
+	var (
+		sub    = rel.Select("user_id").From("transactions").Where(where.Eq("status", "paid"))
+		result = rel.Query{
+			Table:        "users",
+			TableQuery:   &sub,
+			Alias:        "t",
+			CascadeQuery: true,
+		}
+	)
+
+	assert.Equal(t, result, rel.Build("users", rel.From(sub).As("t")))
+	assert.Equal(t, result, rel.Build("", rel.From("users").From(sub).As("t")))
+	assert.Equal(t, result, rel.Build("users", rel.Where(), rel.From(sub).As("t")))
+}
+
+func TestQuery_From_invalidSource(t *testing.T) {
+	assert.Panics(t, func() {
+		rel.From(1)
+	})
+}
+
 func TestQuery_Join(t *testing.T) {
 	result := rel.Query{
 		Table: "users",
@@ -273,6 +295,28 @@ func TestQuery_Joinf(t *testing.T) {
 	assert.Equal(t, result, rel.Joinf("JOIN transactions ON transacations.id=?", 1).From("users"))
 }
 
+func TestQuery_JoinAssoc(t *testing.T) {
+	result := rel.Query{
+		Table: "transactions",
+		JoinQuery: []rel.JoinQuery{
+			{
+				Mode:  "JOIN",
+				Assoc: "buyer.address",
+			},
+			{
+				Mode:     "LEFT JOIN",
+				Assoc:    "address",
+				Populate: true,
+			},
+		},
+		CascadeQuery: true,
+	}
+
+	assert.Equal(t, result, rel.From("transactions").JoinAssoc("buyer.address").JoinPopulateWith("LEFT JOIN", "address"))
+	assert.Equal(t, result, rel.JoinAssoc("buyer.address").JoinPopulateWith("LEFT JOIN", "address").From("transactions"))
+	assert.Equal(t, result, rel.Build("transactions", join.Assoc("buyer.address"), join.PopulateWith("LEFT JOIN", "address")))
+}
+
 func TestQuery_Where(t *testing.T) {
 	tests := []struct {
 		Case     string
@@ -18,15 +18,15 @@ type User struct {
 
 	// many to many
 	// user:id <- user_id:user_roles:role_id -> role:id
-	Roles []Role `through:"user_roles"`
+	Roles []Role `through:"user_roles" join_fk:"role_id"`
 
 	// self-referencing needs two intermediate reference to be set up.
 	Follows   []Follow `ref:"id" fk:"following_id"`
 	Followeds []Follow `ref:"id" fk:"follower_id"`
 
 	// association through
-	Followings []User `through:"follows"`
-	Followers  []User `through:"followeds"`
+	Followings []User `through:"follows" join_fk:"following_id"`
+	Followers  []User `through:"followeds" join_fk:"follower_id"`
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -93,10 +93,58 @@ type Role struct {
 
 	// explicit many to many declaration:
 	// role:id <- role_id:user_roles:user_id -> user:id
-	Users []User `through:"user_roles"`
+	Users []User `through:"user_roles" join_fk:"user_id"`
 }
 
 type UserRole struct {
 	UserID int `db:",primary"`
 	RoleID int `db:",primary"`
 }
+
+type Article struct {
+	ID    int
+	Title string
+	// article:id <- article_id:article_tags:tag_id -> tag:id
+	Tags []Tag `many2many:"article_tags" autosave:"true"`
+}
+
+type Tag struct {
+	ID       int
+	Name     string
+	Articles []Article `many2many:"article_tags" join_ref:"tag_id" join_fk:"article_id"`
+}
+
+type Post struct {
+	ID    int
+	Title string
+	// post:id <- owner_id:comment (owner_type = posts)
+	Comments []Comment `polymorphic:"owner" autosave:"true"`
+	Cover    *Image    `polymorphic:"owner" autosave:"true"`
+}
+
+type Video struct {
+	ID       int
+	Comments []Comment `polymorphic:"owner" polymorphic_value:"video"`
+}
+
+type Comment struct {
+	ID        int
+	Body      string
+	OwnerType string
+	OwnerID   int
+	Post      *Post  `polymorphic:"owner" autosave:"true"`
+	Video     *Video `polymorphic_type:"owner_type" polymorphic_value:"video"`
+}
+
+type Image struct {
+	ID        int
+	URL       string
+	OwnerType string
+	OwnerID   int
+}
+
+type Invoice struct {
+	ID          int
+	Title       string
+	LockVersion int
+}
@@ -1,6 +1,12 @@
 package reltest
 
-import "github.com/go-rel/rel"
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/mock"
+)
 
 // Aggregate asserts and simulate aggregate function for test.
 type Aggregate struct {
@@ -41,3 +47,27 @@ func ExpectCount(r *Repository, collection string, queriers []rel.Querier) *Aggr
 		),
 	}
 }
+
+// AggregateAll asserts and simulate aggregate all function for test.
+type AggregateAll struct {
+	*Expect
+}
+
+// Result sets the result of this query.
+func (aa *AggregateAll) Result(records interface{}) {
+	aa.Arguments[1] = mock.AnythingOfType(fmt.Sprintf("*%T", records))
+
+	aa.Run(func(args mock.Arguments) {
+		reflect.ValueOf(args[1]).Elem().Set(reflect.ValueOf(records))
+	})
+}
+
+// ExpectAggregateAll to be called with given queries.
+func ExpectAggregateAll(r *Repository, queriers []rel.Querier) *AggregateAll {
+	return &AggregateAll{
+		Expect: newExpect(r, "AggregateAll",
+			[]interface{}{r.ctxData, mock.Anything, queriers},
+			[]interface{}{nil},
+		),
+	}
+}
@@ -84,3 +84,61 @@ func TestAggregate_Count_error(t *testing.T) {
 	})
 	repo.AssertExpectations(t)
 }
+
+func TestAggregateAll(t *testing.T) {
+	type report struct {
+		Title string
+		Total float64
+	}
+
+	var (
+		repo    = New()
+		result  []report
+		reports = []report{{Title: "a", Total: 1.5}, {Title: "b", Total: 2}}
+		query   = rel.Select("title").SelectExpr(rel.Sum("price").As("total")).From("books").Group("title")
+	)
+
+	repo.ExpectAggregateAll(query).Result(reports)
+	assert.Nil(t, repo.AggregateAll(context.TODO(), &result, query))
+	assert.Equal(t, reports, result)
+	repo.AssertExpectations(t)
+
+	repo.ExpectAggregateAll(query).Result(reports)
+	assert.NotPanics(t, func() {
+		repo.MustAggregateAll(context.TODO(), &result, query)
+		assert.Equal(t, reports, result)
+	})
+	repo.AssertExpectations(t)
+}
+
+func TestAggregateAll_map(t *testing.T) {
+	var (
+		repo   = New()
+		result []map[string]interface{}
+		rows   = []map[string]interface{}{{"title": "a", "total": 1.5}}
+		query  = rel.Select("title").SelectExpr(rel.Sum("price").As("total")).From("books").Group("title")
+	)
+
+	repo.ExpectAggregateAll(query).Result(rows)
+	assert.Nil(t, repo.AggregateAll(context.TODO(), &result, query))
+	assert.Equal(t, rows, result)
+	repo.AssertExpectations(t)
+}
+
+func TestAggregateAll_error(t *testing.T) {
+	var (
+		repo   = New()
+		result []map[string]interface{}
+		query  = rel.SelectExpr(rel.Sum("price").As("total")).From("books")
+	)
+
+	repo.ExpectAggregateAll(query).ConnectionClosed()
+	assert.Equal(t, sql.ErrConnDone, repo.AggregateAll(context.TODO(), &result, query))
+	repo.AssertExpectations(t)
+
+	repo.ExpectAggregateAll(query).ConnectionClosed()
+	assert.Panics(t, func() {
+		repo.MustAggregateAll(context.TODO(), &result, query)
+	})
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,43 @@
+package reltest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/mock"
+)
+
+// FindPage asserts and simulate find page function for test.
+type FindPage struct {
+	*Expect
+}
+
+// Result sets the result of this query and the cursor of next page.
+func (fp *FindPage) Result(records interface{}, next string) {
+	fp.Arguments[1] = mock.AnythingOfType(fmt.Sprintf("*%T", records))
+
+	fp.Run(func(args mock.Arguments) {
+		reflect.ValueOf(args[1]).Elem().Set(reflect.ValueOf(records))
+	}).Return(next, nil)
+}
+
+// Error sets error to be returned.
+func (fp *FindPage) Error(err error) {
+	fp.Return("", err)
+}
+
+// ConnectionClosed sets this error to be returned.
+func (fp *FindPage) ConnectionClosed() {
+	fp.Error(ErrConnectionClosed)
+}
+
+// ExpectFindPage to be called with given cursor, size and queries.
+func ExpectFindPage(r *Repository, cursor string, size int, queriers []rel.Querier) *FindPage {
+	return &FindPage{
+		Expect: newExpect(r, "FindPage",
+			[]interface{}{r.ctxData, mock.Anything, cursor, size, queriers},
+			[]interface{}{"", nil},
+		),
+	}
+}
@@ -0,0 +1,60 @@
+package reltest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPage(t *testing.T) {
+	var (
+		repo   = New()
+		result []Book
+		books  = []Book{
+			{ID: 1, Title: "Golang for dummies"},
+			{ID: 2, Title: "Rel for dummies"},
+		}
+		query = rel.Where(where.Like("title", "%dummies%")).SortDesc("title")
+	)
+
+	repo.ExpectFindPage("", 2, query).Result(books, "next")
+
+	next, err := repo.FindPage(context.TODO(), &result, "", 2, query)
+	assert.Nil(t, err)
+	assert.Equal(t, "next", next)
+	assert.Equal(t, books, result)
+	repo.AssertExpectations(t)
+
+	repo.ExpectFindPage("next", 2, query).Result(books, "")
+	assert.NotPanics(t, func() {
+		next := repo.MustFindPage(context.TODO(), &result, "next", 2, query)
+		assert.Equal(t, books, result)
+		assert.Equal(t, "", next)
+	})
+	repo.AssertExpectations(t)
+}
+
+func TestFindPage_error(t *testing.T) {
+	var (
+		repo   = New()
+		result []Book
+		query  = rel.Where(where.Like("title", "%dummies%"))
+	)
+
+	repo.ExpectFindPage("", 10, query).ConnectionClosed()
+
+	next, err := repo.FindPage(context.TODO(), &result, "", 10, query)
+	assert.Equal(t, sql.ErrConnDone, err)
+	assert.Equal(t, "", next)
+	repo.AssertExpectations(t)
+
+	repo.ExpectFindPage("", 10, query).ConnectionClosed()
+	assert.Panics(t, func() {
+		repo.MustFindPage(context.TODO(), &result, "", 10, query)
+	})
+	repo.AssertExpectations(t)
+}
@@ -60,12 +60,10 @@ func ExpectUpdate(r *Repository, mutators []rel.Mutator) *Mutate {
 
 // ExpectInsertAll to be called.
 func ExpectInsertAll(r *Repository) *Mutate {
-	em := &Mutate{
-		Expect: newExpect(r, "InsertAll",
-			[]interface{}{r.ctxData, mock.Anything},
-			[]interface{}{nil},
-		),
-	}
+	return expectMutate(r, "InsertAll", nil)
+}
 
-	return em
+// ExpectInsertAllWith to be called with given mutators.
+func ExpectInsertAllWith(r *Repository, mutators []rel.Mutator) *Mutate {
+	return expectMutate(r, "InsertAll", mutators)
 }
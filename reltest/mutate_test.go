@@ -200,6 +200,26 @@ func TestMutate_InsertAll(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestMutate_InsertAllWith(t *testing.T) {
+	var (
+		repo    = New()
+		results = []Book{
+			{Title: "Golang for dummies"},
+		}
+	)
+
+	repo.ExpectInsertAllWith(rel.OnConflictIgnore())
+	assert.Nil(t, repo.InsertAll(context.TODO(), &results, rel.OnConflictIgnore()))
+	repo.AssertExpectations(t)
+
+	repo.ExpectInsertAllWith(rel.OnConflictIgnore()).NotUnique("title")
+	assert.Equal(t,
+		rel.ConstraintError{Key: "title", Type: rel.UniqueConstraint},
+		repo.InsertAll(context.TODO(), &results, rel.OnConflictIgnore()),
+	)
+	repo.AssertExpectations(t)
+}
+
 func TestMutate_Update(t *testing.T) {
 	var (
 		repo   = New()
@@ -107,7 +107,7 @@ func preload(target slice, result slice, path []string) {
 				fField = assocs.ForeignField()
 			)
 
-			if rValue == nil {
+			if rValue == nil || !polymorphicMatched(top.doc, assocs) {
 				continue
 			}
 
@@ -155,6 +155,16 @@ func preload(target slice, result slice, path []string) {
 	}
 }
 
+// polymorphicMatched returns false if type stored in the document is referencing other polymorphic belongs to association.
+func polymorphicMatched(doc *rel.Document, assoc rel.Association) bool {
+	if !assoc.Polymorphic() || assoc.Type() != rel.BelongsTo {
+		return true
+	}
+
+	value, _ := doc.Value(assoc.PolymorphicField())
+	return value == assoc.PolymorphicValue()
+}
+
 func mapResult(result slice, fField string, hasMany bool) map[interface{}]reflect.Value {
 	var (
 		mapResult = make(map[interface{}]reflect.Value)
@@ -28,6 +28,11 @@ func (r *Repository) Instrumentation(instrumenter rel.Instrumenter) {
 	r.repo.Instrumentation(instrumenter)
 }
 
+// Naming provides a mock function with given fields: naming
+func (r *Repository) Naming(naming rel.NamingStrategy) {
+	r.repo.Naming(naming)
+}
+
 // Ping database.
 func (r *Repository) Ping(ctx context.Context) error {
 	return r.repo.Ping(ctx)
@@ -65,6 +70,22 @@ func (r *Repository) ExpectAggregate(query rel.Query, aggregate string, field st
 	return ExpectAggregate(r, query, aggregate, field)
 }
 
+// AggregateAll provides a mock function with given fields: records, queriers
+func (r *Repository) AggregateAll(ctx context.Context, records interface{}, queriers ...rel.Querier) error {
+	r.repo.AggregateAll(ctx, records, queriers...)
+	return r.mock.Called(fetchContext(ctx), records, queriers).Error(0)
+}
+
+// MustAggregateAll provides a mock function with given fields: records, queriers
+func (r *Repository) MustAggregateAll(ctx context.Context, records interface{}, queriers ...rel.Querier) {
+	must(r.AggregateAll(ctx, records, queriers...))
+}
+
+// ExpectAggregateAll apply mocks and expectations for AggregateAll
+func (r *Repository) ExpectAggregateAll(queriers ...rel.Querier) *AggregateAll {
+	return ExpectAggregateAll(r, queriers)
+}
+
 // Count provides a mock function with given fields: collection, queriers
 func (r *Repository) Count(ctx context.Context, collection string, queriers ...rel.Querier) (int, error) {
 	r.repo.Count(ctx, collection, queriers...)
@@ -135,6 +156,25 @@ func (r *Repository) ExpectFindAndCountAll(queriers ...rel.Querier) *FindAndCoun
 	return ExpectFindAndCountAll(r, queriers)
 }
 
+// FindPage provides a mock function with given fields: records, cursor, size, queriers
+func (r *Repository) FindPage(ctx context.Context, records interface{}, cursor string, size int, queriers ...rel.Querier) (string, error) {
+	r.repo.FindPage(ctx, records, cursor, size, queriers...)
+	ret := r.mock.Called(fetchContext(ctx), records, cursor, size, queriers)
+	return ret.String(0), ret.Error(1)
+}
+
+// MustFindPage provides a mock function with given fields: records, cursor, size, queriers
+func (r *Repository) MustFindPage(ctx context.Context, records interface{}, cursor string, size int, queriers ...rel.Querier) string {
+	next, err := r.FindPage(ctx, records, cursor, size, queriers...)
+	must(err)
+	return next
+}
+
+// ExpectFindPage apply mocks and expectations for FindPage
+func (r *Repository) ExpectFindPage(cursor string, size int, queriers ...rel.Querier) *FindPage {
+	return ExpectFindPage(r, cursor, size, queriers)
+}
+
 // Insert provides a mock function with given fields: record, mutators
 func (r *Repository) Insert(ctx context.Context, record interface{}, mutators ...rel.Mutator) error {
 	ret := r.mock.Called(fetchContext(ctx), record, mutators)
@@ -154,16 +194,16 @@ func (r *Repository) ExpectInsert(mutators ...rel.Mutator) *Mutate {
 }
 
 // InsertAll records.
-func (r *Repository) InsertAll(ctx context.Context, records interface{}) error {
-	ret := r.mock.Called(fetchContext(ctx), records)
+func (r *Repository) InsertAll(ctx context.Context, records interface{}, mutators ...rel.Mutator) error {
+	ret := r.mock.Called(fetchContext(ctx), records, mutators)
 
-	r.repo.InsertAll(ctx, records)
+	r.repo.InsertAll(ctx, records, mutators...)
 	return ret.Error(0)
 }
 
 // MustInsertAll records.
-func (r *Repository) MustInsertAll(ctx context.Context, records interface{}) {
-	must(r.InsertAll(ctx, records))
+func (r *Repository) MustInsertAll(ctx context.Context, records interface{}, mutators ...rel.Mutator) {
+	must(r.InsertAll(ctx, records, mutators...))
 }
 
 // ExpectInsertAll records.
@@ -171,6 +211,11 @@ func (r *Repository) ExpectInsertAll() *Mutate {
 	return ExpectInsertAll(r)
 }
 
+// ExpectInsertAllWith records and mutators.
+func (r *Repository) ExpectInsertAllWith(mutators ...rel.Mutator) *Mutate {
+	return ExpectInsertAllWith(r, mutators)
+}
+
 // Update provides a mock function with given fields: record, mutators
 func (r *Repository) Update(ctx context.Context, record interface{}, mutators ...rel.Mutator) error {
 	ret := r.mock.Called(fetchContext(ctx), record, mutators)
@@ -16,27 +16,46 @@ type Repository interface {
 	// Instrumentation defines callback to be used as instrumenter.
 	Instrumentation(instrumenter Instrumenter)
 
+	// Naming defines strategy to infer table and column names of records passed to this repository.
+	// Records that are wrapped outside repository, such as using NewDocument or NewChangeset, are applied using this strategy as well.
+	Naming(naming NamingStrategy)
+
 	// Ping database.
 	Ping(ctx context.Context) error
 
 	// Iterate through a collection of records from database in batches.
 	// This function returns iterator that can be used to loop all records.
-	// Limit, Offset and Sort query is automatically ignored.
+	// Limit and Offset query is automatically ignored, while Sort query is kept with primary fields as tie-breaker.
+	// Records are paginated using primary values of the last fetched record,
+	// sorted query or OffsetStrategy option paginates using offset instead.
 	Iterate(ctx context.Context, query Query, option ...IteratorOption) Iterator
 
 	// Aggregate over the given field.
 	// Supported aggregate: count, sum, avg, max, min.
 	// Any select, group, offset, limit and sort query will be ignored automatically.
-	// If complex aggregation is needed, consider using All instead.
+	// If complex aggregation is needed, consider using AggregateAll instead.
 	Aggregate(ctx context.Context, query Query, aggregate string, field string) (int, error)
 
 	// MustAggregate over the given field.
 	// Supported aggregate: count, sum, avg, max, min.
 	// Any select, group, offset, limit and sort query will be ignored automatically.
-	// If complex aggregation is needed, consider using All instead.
+	// If complex aggregation is needed, consider using AggregateAll instead.
 	// It'll panic if any error eccured.
 	MustAggregate(ctx context.Context, query Query, aggregate string, field string) int
 
+	// AggregateAll scans every row of aggregation query into records.
+	// Unlike Aggregate, select, group and having query are respected, use SelectExpr to select multiple aggregates.
+	// Records can be a pointer to slice of struct, or a pointer to []map[string]interface{}.
+	// Soft deleted rows are excluded when struct has soft delete field, unless the query is unscoped.
+	AggregateAll(ctx context.Context, records interface{}, queriers ...Querier) error
+
+	// MustAggregateAll scans every row of aggregation query into records.
+	// Unlike Aggregate, select, group and having query are respected, use SelectExpr to select multiple aggregates.
+	// Records can be a pointer to slice of struct, or a pointer to []map[string]interface{}.
+	// Soft deleted rows are excluded when struct has soft delete field, unless the query is unscoped.
+	// It'll panic if any error eccured.
+	MustAggregateAll(ctx context.Context, records interface{}, queriers ...Querier)
+
 	// Count records that match the query.
 	Count(ctx context.Context, collection string, queriers ...Querier) (int, error)
 
@@ -70,7 +89,19 @@ type Repository interface {
 	// It'll panic if any error eccured.
 	MustFindAndCountAll(ctx context.Context, records interface{}, queriers ...Querier) int
 
+	// FindPage records that match the query using keyset pagination.
+	// Records are sorted by query's sort followed by primary fields, null values are sorted last.
+	// Cursor is an opaque token returned by previous call, use empty cursor to fetch the first page.
+	// Returned cursor is empty when there's no more page. Limit and Offset property will be ignored.
+	// It returns ErrInvalidPageSize when size is not positive, and error when sorted using field that doesn't exist in the record.
+	FindPage(ctx context.Context, records interface{}, cursor string, size int, queriers ...Querier) (string, error)
+
+	// MustFindPage records that match the query using keyset pagination.
+	// It'll panic if any error eccured.
+	MustFindPage(ctx context.Context, records interface{}, cursor string, size int, queriers ...Querier) string
+
 	// Insert a record to database.
+	// Use OnConflict mutator to resolve conflict with existing record (upsert).
 	Insert(ctx context.Context, record interface{}, mutators ...Mutator) error
 
 	// MustInsert an record to database.
@@ -78,11 +109,12 @@ type Repository interface {
 	MustInsert(ctx context.Context, record interface{}, mutators ...Mutator)
 
 	// InsertAll records.
-	InsertAll(ctx context.Context, records interface{}) error
+	// Mutators is applied to every records, it's intended to be used with options such as OnConflict.
+	InsertAll(ctx context.Context, records interface{}, mutators ...Mutator) error
 
 	// MustInsertAll records.
 	// It'll panic if any error occurred.
-	MustInsertAll(ctx context.Context, records interface{})
+	MustInsertAll(ctx context.Context, records interface{}, mutators ...Mutator)
 
 	// Update a record in database.
 	// It'll panic if any error occurred.
@@ -116,6 +148,8 @@ type Repository interface {
 	// Preload association with given query.
 	// If association is already loaded, this will do nothing.
 	// To force preloading even though association is already loaeded, add `Reload(true)` as query.
+	// Nested field such as "items.product.vendor" loads each level that is not loaded yet, one query per level.
+	// Use NestedPreload querier to customize nested level and PreloadLimit to limit preloaded records of each parent.
 	Preload(ctx context.Context, records interface{}, field string, queriers ...Querier) error
 
 	// MustPreload association with given query.
@@ -130,6 +164,7 @@ type Repository interface {
 type repository struct {
 	rootAdapter  Adapter
 	instrumenter Instrumenter
+	naming       NamingStrategy
 }
 
 func (r repository) Adapter(ctx context.Context) Adapter {
@@ -141,6 +176,10 @@ func (r *repository) Instrumentation(instrumenter Instrumenter) {
 	r.rootAdapter.Instrumentation(instrumenter)
 }
 
+func (r *repository) Naming(naming NamingStrategy) {
+	r.naming = naming
+}
+
 func (r *repository) Ping(ctx context.Context) error {
 	return r.rootAdapter.Ping(ctx)
 }
@@ -150,7 +189,7 @@ func (r repository) Iterate(ctx context.Context, query Query, options ...Iterato
 		cw = fetchContext(ctx, r.rootAdapter)
 	)
 
-	return newIterator(cw.ctx, cw.adapter, query, options)
+	return newIterator(cw.ctx, cw.adapter, query, r.naming, options)
 }
 
 func (r repository) Aggregate(ctx context.Context, query Query, aggregate string, field string) (int, error) {
@@ -179,6 +218,42 @@ func (r repository) MustAggregate(ctx context.Context, query Query, aggregate st
 	return result
 }
 
+func (r repository) AggregateAll(ctx context.Context, records interface{}, queriers ...Querier) error {
+	finish := r.instrumenter.Observe(ctx, "rel-aggregate-all", "aggregating records")
+	defer finish(nil)
+
+	var (
+		cw = fetchContext(ctx, r.rootAdapter)
+	)
+
+	if rows, ok := records.(*[]map[string]interface{}); ok {
+		cur, err := cw.adapter.Query(cw.ctx, Build("", queriers...))
+		if err != nil {
+			return err
+		}
+
+		return scanMaps(cur, rows)
+	}
+
+	var (
+		col   = collectionWithNaming(records, r.naming, false)
+		query = r.withDefaultScope(col.data, Build(col.Table(), queriers...), false)
+	)
+
+	col.Reset()
+
+	cur, err := cw.adapter.Query(cw.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return scanAll(cur, col)
+}
+
+func (r repository) MustAggregateAll(ctx context.Context, records interface{}, queriers ...Querier) {
+	must(r.AggregateAll(ctx, records, queriers...))
+}
+
 func (r repository) Count(ctx context.Context, collection string, queriers ...Querier) (int, error) {
 	finish := r.instrumenter.Observe(ctx, "rel-count", "aggregating records")
 	defer finish(nil)
@@ -202,7 +277,7 @@ func (r repository) Find(ctx context.Context, record interface{}, queriers ...Qu
 
 	var (
 		cw    = fetchContext(ctx, r.rootAdapter)
-		doc   = NewDocument(record)
+		doc   = documentWithNaming(record, r.naming, false)
 		query = Build(doc.Table(), queriers...)
 	)
 
@@ -214,6 +289,11 @@ func (r repository) MustFind(ctx context.Context, record interface{}, queriers .
 }
 
 func (r repository) find(cw contextWrapper, doc *Document, query Query) error {
+	query, err := resolveJoinAssoc(doc.rt, doc.data.naming, query)
+	if err != nil {
+		return err
+	}
+
 	query = r.withDefaultScope(doc.data, query, true)
 	cur, err := cw.adapter.Query(cw.ctx, query.Limit(1))
 	if err != nil {
@@ -233,7 +313,7 @@ func (r repository) find(cw contextWrapper, doc *Document, query Query) error {
 		}
 	}
 
-	return nil
+	return afterFind(cw, doc)
 }
 
 func (r repository) FindAll(ctx context.Context, records interface{}, queriers ...Querier) error {
@@ -242,7 +322,7 @@ func (r repository) FindAll(ctx context.Context, records interface{}, queriers .
 
 	var (
 		cw    = fetchContext(ctx, r.rootAdapter)
-		col   = NewCollection(records)
+		col   = collectionWithNaming(records, r.naming, false)
 		query = Build(col.Table(), queriers...)
 	)
 
@@ -256,6 +336,11 @@ func (r repository) MustFindAll(ctx context.Context, records interface{}, querie
 }
 
 func (r repository) findAll(cw contextWrapper, col *Collection, query Query) error {
+	query, err := resolveJoinAssoc(col.rt.Elem(), col.data.naming, query)
+	if err != nil {
+		return err
+	}
+
 	query = r.withDefaultScope(col.data, query, true)
 	cur, err := cw.adapter.Query(cw.ctx, query)
 	if err != nil {
@@ -275,7 +360,7 @@ func (r repository) findAll(cw contextWrapper, col *Collection, query Query) err
 		}
 	}
 
-	return nil
+	return afterFindAll(cw, col)
 }
 
 func (r repository) FindAndCountAll(ctx context.Context, records interface{}, queriers ...Querier) (int, error) {
@@ -283,11 +368,15 @@ func (r repository) FindAndCountAll(ctx context.Context, records interface{}, qu
 	defer finish(nil)
 
 	var (
-		cw    = fetchContext(ctx, r.rootAdapter)
-		col   = NewCollection(records)
-		query = Build(col.Table(), queriers...)
+		cw  = fetchContext(ctx, r.rootAdapter)
+		col = collectionWithNaming(records, r.naming, false)
 	)
 
+	query, err := resolveJoinAssoc(col.rt.Elem(), col.data.naming, Build(col.Table(), queriers...))
+	if err != nil {
+		return 0, err
+	}
+
 	col.Reset()
 
 	if err := r.findAll(cw, col, query); err != nil {
@@ -304,6 +393,61 @@ func (r repository) MustFindAndCountAll(ctx context.Context, records interface{}
 	return count
 }
 
+func (r repository) FindPage(ctx context.Context, records interface{}, cursor string, size int, queriers ...Querier) (string, error) {
+	finish := r.instrumenter.Observe(ctx, "rel-find-page", "finding a page of records")
+	defer finish(nil)
+
+	if size <= 0 {
+		return "", ErrInvalidPageSize
+	}
+
+	var (
+		cw    = fetchContext(ctx, r.rootAdapter)
+		col   = collectionWithNaming(records, r.naming, false)
+		query = Build(col.Table(), queriers...)
+	)
+
+	keys, err := pageKeys(col, query.SortQuery)
+	if err != nil {
+		return "", err
+	}
+
+	col.Reset()
+
+	if cursor != "" {
+		values, err := decodePageCursor(keys, cursor)
+		if err != nil {
+			return "", err
+		}
+
+		query = query.Where(filterPage(keys, values))
+	}
+
+	// fetch one more record to determine whether next page exists.
+	query.SortQuery = pageSort(keys)
+	query.OffsetQuery = 0
+	query.LimitQuery = Limit(size + 1)
+
+	if err := r.findAll(cw, col, query); err != nil {
+		return "", err
+	}
+
+	if col.Len() <= size {
+		return "", nil
+	}
+
+	col.rv.Set(col.rv.Slice(0, size))
+
+	return encodePageCursor(keys, col.Get(size-1))
+}
+
+func (r repository) MustFindPage(ctx context.Context, records interface{}, cursor string, size int, queriers ...Querier) string {
+	next, err := r.FindPage(ctx, records, cursor, size, queriers...)
+	must(err)
+
+	return next
+}
+
 func (r repository) Insert(ctx context.Context, record interface{}, mutators ...Mutator) error {
 	finish := r.instrumenter.Observe(ctx, "rel-insert", "inserting a record")
 	defer finish(nil)
@@ -314,11 +458,13 @@ func (r repository) Insert(ctx context.Context, record interface{}, mutators ...
 
 	var (
 		cw       = fetchContext(ctx, r.rootAdapter)
-		doc      = NewDocument(record)
+		doc      = documentWithNaming(record, r.naming, false)
 		mutation = Apply(doc, mutators...)
+		_, hook  = doc.v.(AfterInsertHook)
 	)
 
-	if !mutation.IsAssocEmpty() && mutation.Cascade == true {
+	// after hook runs inside transaction, so it can abort the insertion.
+	if (!mutation.IsAssocEmpty() && mutation.Cascade == true) || hook {
 		return r.transaction(cw, func(cw contextWrapper) error {
 			return r.insert(cw, doc, mutation)
 		})
@@ -334,6 +480,14 @@ func (r repository) insert(cw contextWrapper, doc *Document, mutation Mutation)
 		queriers = Build(doc.Table())
 	)
 
+	if err := beforeInsert(cw, doc, &mutation); err != nil {
+		return err
+	}
+
+	if err := r.validate(cw, doc, mutation); err != nil {
+		return err
+	}
+
 	if mutation.Cascade {
 		if err := r.saveBelongsTo(cw, doc, &mutation); err != nil {
 			return err
@@ -344,14 +498,39 @@ func (r repository) insert(cw contextWrapper, doc *Document, mutation Mutation)
 		pField = pFields[0]
 	}
 
-	pValue, err := cw.adapter.Insert(cw.ctx, queriers, pField, mutation.Mutates)
-	if err != nil {
-		return mutation.ErrorFunc.transform(err)
+	var (
+		cur Cursor
+		err = ErrNotSupported
+	)
+
+	if adapter, ok := cw.adapter.(ReturningAdapter); ok && bool(mutation.Reload) && !mutation.OnConflict.Ignore {
+		cur, err = adapter.InsertReturning(cw.ctx, queriers, mutation.Mutates, mutation.OnConflict)
 	}
 
-	// update primary value
-	if pField != "" {
-		doc.SetValue(pField, pValue)
+	if err == nil {
+		if err := scanOne(cur, doc); err != nil {
+			return err
+		}
+	} else if err != ErrNotSupported {
+		return mutation.ErrorFunc.transform(err)
+	} else {
+		pValue, err := cw.adapter.Insert(cw.ctx, queriers, pField, mutation.Mutates, mutation.OnConflict)
+		if err != nil {
+			return mutation.ErrorFunc.transform(err)
+		}
+
+		// update primary value
+		// primary value might be unknown when the insertion is ignored because of conflict.
+		if pField != "" && pValue != nil {
+			doc.SetValue(pField, pValue)
+		}
+
+		// re-select inserted record when adapter is not able to return it.
+		if bool(mutation.Reload) && doc.Persisted() {
+			if err := r.find(cw.withPrimary(), doc, Build(doc.Table(), filterDocument(doc), Unscoped(true), Cascade(false))); err != nil {
+				return err
+			}
+		}
 	}
 
 	if mutation.Cascade {
@@ -364,14 +543,14 @@ func (r repository) insert(cw contextWrapper, doc *Document, mutation Mutation)
 		}
 	}
 
-	return nil
+	return afterInsert(cw, doc, &mutation)
 }
 
 func (r repository) MustInsert(ctx context.Context, record interface{}, mutators ...Mutator) {
 	must(r.Insert(ctx, record, mutators...))
 }
 
-func (r repository) InsertAll(ctx context.Context, records interface{}) error {
+func (r repository) InsertAll(ctx context.Context, records interface{}, mutators ...Mutator) error {
 	finish := r.instrumenter.Observe(ctx, "rel-insert-all", "inserting multiple records")
 	defer finish(nil)
 
@@ -381,20 +560,26 @@ func (r repository) InsertAll(ctx context.Context, records interface{}) error {
 
 	var (
 		cw   = fetchContext(ctx, r.rootAdapter)
-		col  = NewCollection(records)
+		col  = collectionWithNaming(records, r.naming, false)
 		muts = make([]Mutation, col.Len())
 	)
 
 	for i := range muts {
 		doc := col.Get(i)
-		muts[i] = Apply(doc, newStructset(doc, false))
+		muts[i] = Apply(doc, append([]Mutator{newStructset(doc, false)}, mutators...)...)
+	}
+
+	if collectionImplements(col, afterInsertHookType) {
+		return r.transaction(cw, func(cw contextWrapper) error {
+			return r.insertAll(cw, col, muts)
+		})
 	}
 
 	return r.insertAll(cw, col, muts)
 }
 
-func (r repository) MustInsertAll(ctx context.Context, records interface{}) {
-	must(r.InsertAll(ctx, records))
+func (r repository) MustInsertAll(ctx context.Context, records interface{}, mutators ...Mutator) {
+	must(r.InsertAll(ctx, records, mutators...))
 }
 
 // TODO: support assocs
@@ -403,6 +588,16 @@ func (r repository) insertAll(cw contextWrapper, col *Collection, mutation []Mut
 		return nil
 	}
 
+	for i := range mutation {
+		if err := beforeInsert(cw, col.Get(i), &mutation[i]); err != nil {
+			return err
+		}
+
+		if err := r.validate(cw, col.Get(i), mutation[i]); err != nil {
+			return err
+		}
+	}
+
 	var (
 		pField      string
 		pFields     = col.PrimaryFields()
@@ -427,21 +622,168 @@ func (r repository) insertAll(cw contextWrapper, col *Collection, mutation []Mut
 		pField = pFields[0]
 	}
 
-	ids, err := cw.adapter.InsertAll(cw.ctx, queriers, pField, fields, bulkMutates)
-	if err != nil {
+	var (
+		cur Cursor
+		err = ErrNotSupported
+	)
+
+	if adapter, ok := cw.adapter.(ReturningAdapter); ok && bool(mutation[0].Reload) && !mutation[0].OnConflict.Ignore {
+		cur, err = adapter.InsertAllReturning(cw.ctx, queriers, fields, bulkMutates, mutation[0].OnConflict)
+	}
+
+	if err == nil {
+		if keys := returningKeys(col, mutation[0].OnConflict); len(keys) > 0 {
+			if err := scanMatch(cur, col, keys); err != nil {
+				return err
+			}
+		} else if err := scanEach(cur, col); err != nil {
+			return err
+		}
+	} else if err != ErrNotSupported {
 		return mutation[0].ErrorFunc.transform(err)
+	} else {
+		ids, err := cw.adapter.InsertAll(cw.ctx, queriers, pField, fields, bulkMutates, mutation[0].OnConflict)
+		if err != nil {
+			return mutation[0].ErrorFunc.transform(err)
+		}
+
+		// apply ids
+		if pField != "" {
+			for i, id := range ids {
+				if id != nil {
+					col.Get(i).SetValue(pField, id)
+				}
+			}
+		}
+
+		if mutation[0].Reload {
+			if err := r.reloadAll(cw, col); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range mutation {
+		if err := afterInsert(cw, col.Get(i), &mutation[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reloadAll re-select inserted records and copy its fields back to collection, associations are left untouched.
+func (r repository) reloadAll(cw contextWrapper, col *Collection) error {
+	var (
+		pFields = col.PrimaryFields()
+		result  = collectionWithNaming(reflect.New(col.rt).Interface(), col.data.naming, false)
+		query   = Build(col.Table(), filterCollection(col), Unscoped(true), Cascade(false))
+	)
+
+	if len(pFields) == 0 || col.Len() == 0 {
+		return nil
+	}
+
+	if err := r.findAll(cw.withPrimary(), result, query); err != nil {
+		return err
 	}
 
-	// apply ids
-	if pField != "" {
-		for i, id := range ids {
-			col.Get(i).SetValue(pField, id)
+	var (
+		index = indexCollection(col, pFields[0])
+	)
+
+	for i := 0; i < result.Len(); i++ {
+		source := result.Get(i)
+		if doc := matchDocument(col, index, source, pFields); doc != nil {
+			copyFields(doc, source, doc.Fields())
 		}
 	}
 
 	return nil
 }
 
+// returningKeys returns fields used to match returned rows of bulk insertion with the inserted records.
+// Rows are returned in insertion order unless conflict is resolved, in that case it's matched using primary values when
+// all records has it, otherwise using conflict keys.
+func returningKeys(col *Collection, onConflict OnConflict) []string {
+	if onConflict.IsZero() {
+		return nil
+	}
+
+	pFields := col.PrimaryFields()
+	if len(pFields) == 0 {
+		return onConflict.Keys
+	}
+
+	for i := 0; i < col.Len(); i++ {
+		for _, pValue := range col.Get(i).PrimaryValues() {
+			if isZero(pValue) {
+				return onConflict.Keys
+			}
+		}
+	}
+
+	return pFields
+}
+
+// indexCollection indexes records in collection by typed value of the field, value that's not comparable is grouped under nil key.
+func indexCollection(col *Collection, field string) map[interface{}][]int {
+	var (
+		index = make(map[interface{}][]int, col.Len())
+	)
+
+	for i := 0; i < col.Len(); i++ {
+		key := indexKey(col.Get(i), field)
+		index[key] = append(index[key], i)
+	}
+
+	return index
+}
+
+func indexKey(doc *Document, field string) interface{} {
+	value, _ := doc.Value(field)
+	if value == nil || !reflect.TypeOf(value).Comparable() {
+		return nil
+	}
+
+	return value
+}
+
+// matchDocument returns record in indexed collection that has the same keys value as the given document.
+func matchDocument(col *Collection, index map[interface{}][]int, doc *Document, keys []string) *Document {
+	for _, i := range index[indexKey(doc, keys[0])] {
+		if match := col.Get(i); equalValues(match, doc, keys) {
+			return match
+		}
+	}
+
+	return nil
+}
+
+// copyFields copies fields value from source into document, associations are left untouched.
+func copyFields(doc *Document, source *Document, fields []string) {
+	for _, field := range fields {
+		if fi, ok := doc.data.fieldIndex[field]; ok {
+			fieldByIndex(doc.rv, fi, true).Set(fieldByIndex(source.rv, fi, true))
+		}
+	}
+}
+
+func equalValues(doc *Document, other *Document, fields []string) bool {
+	for _, field := range fields {
+		var (
+			value, _      = doc.Value(field)
+			otherValue, _ = other.Value(field)
+		)
+
+		if !reflect.DeepEqual(value, otherValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r repository) Update(ctx context.Context, record interface{}, mutators ...Mutator) error {
 	finish := r.instrumenter.Observe(ctx, "rel-update", "updating a record")
 	defer finish(nil)
@@ -452,12 +794,14 @@ func (r repository) Update(ctx context.Context, record interface{}, mutators ...
 
 	var (
 		cw       = fetchContext(ctx, r.rootAdapter)
-		doc      = NewDocument(record)
+		doc      = documentWithNaming(record, r.naming, false)
 		filter   = filterDocument(doc)
 		mutation = Apply(doc, mutators...)
+		_, hook  = doc.v.(AfterUpdateHook)
 	)
 
-	if !mutation.IsAssocEmpty() && mutation.Cascade == true {
+	// after hook runs inside transaction, so it can abort the update.
+	if (!mutation.IsAssocEmpty() && mutation.Cascade == true) || hook {
 		return r.transaction(cw, func(cw contextWrapper) error {
 			return r.update(cw, doc, mutation, filter)
 		})
@@ -467,6 +811,14 @@ func (r repository) Update(ctx context.Context, record interface{}, mutators ...
 }
 
 func (r repository) update(cw contextWrapper, doc *Document, mutation Mutation, filter FilterQuery) error {
+	if err := beforeUpdate(cw, doc, &mutation); err != nil {
+		return err
+	}
+
+	if err := r.validate(cw, doc, mutation); err != nil {
+		return err
+	}
+
 	if mutation.Cascade {
 		if err := r.saveBelongsTo(cw, doc, &mutation); err != nil {
 			return err
@@ -474,20 +826,67 @@ func (r repository) update(cw contextWrapper, doc *Document, mutation Mutation,
 	}
 
 	if !mutation.IsMutatesEmpty() {
+		var (
+			lockVersion interface{}
+			locked      = doc.Flag(HasLockVersion)
+			notFound    = error(NotFoundError{})
+		)
+
+		// optimistic locking, only updates record when it's still in the same version as loaded.
+		if locked {
+			var (
+				lockFilter FilterQuery
+			)
+
+			lockFilter, lockVersion = filterLockVersion(doc)
+			filter = filter.And(lockFilter)
+			notFound = StaleRecordError{}
+			mutation.Add(Set(doc.data.flagFieldName(HasLockVersion), lockVersion))
+		}
+
 		var (
 			query = r.withDefaultScope(doc.data, Build(doc.Table(), filter, mutation.Unscoped, mutation.Cascade), false)
+			cur   Cursor
+			err   = ErrNotSupported
 		)
 
-		if updatedCount, err := cw.adapter.Update(cw.ctx, query, mutation.Mutates); err != nil {
-			return mutation.ErrorFunc.transform(err)
-		} else if updatedCount == 0 {
-			return NotFoundError{}
+		if adapter, ok := cw.adapter.(ReturningAdapter); ok && bool(mutation.Reload) {
+			cur, err = adapter.UpdateReturning(cw.ctx, query, mutation.Mutates)
 		}
 
-		if mutation.Reload {
-			if err := r.find(cw, doc, query); err != nil {
+		if err == nil {
+			if err := scanOne(cur, doc); err != nil {
+				if _, ok := err.(NotFoundError); ok {
+					return notFound
+				}
+
 				return err
 			}
+
+			query = r.withDefaultScope(doc.data, query, true)
+			for i := range query.PreloadQuery {
+				if err := r.preload(cw, doc, query.PreloadQuery[i], nil); err != nil {
+					return err
+				}
+			}
+		} else if err != ErrNotSupported {
+			return mutation.ErrorFunc.transform(err)
+		} else {
+			if updatedCount, err := cw.adapter.Update(cw.ctx, query, mutation.Mutates); err != nil {
+				return mutation.ErrorFunc.transform(err)
+			} else if updatedCount == 0 {
+				return notFound
+			}
+
+			if locked {
+				doc.SetValue(doc.data.flagFieldName(HasLockVersion), lockVersion)
+			}
+
+			if mutation.Reload {
+				if err := r.find(cw.withPrimary(), doc, query); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -501,6 +900,29 @@ func (r repository) update(cw contextWrapper, doc *Document, mutation Mutation,
 		}
 	}
 
+	return afterUpdate(cw, doc, &mutation)
+}
+
+// validate document using validations defined in mutation, all field errors are collected into single validation error.
+func (r repository) validate(cw contextWrapper, doc *Document, mutation Mutation) error {
+	if len(mutation.Validations) == 0 {
+		return nil
+	}
+
+	var (
+		errs = ValidationError{}
+	)
+
+	for _, validation := range mutation.Validations {
+		if err := validation.validate(cw.ctx, &r, doc, errs); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
 
@@ -546,6 +968,16 @@ func (r repository) saveBelongsTo(cw contextWrapper, doc *Document, mutation *Mu
 
 			mutation.Add(Set(rField, fValue))
 			doc.SetValue(rField, fValue)
+
+			if assoc.Polymorphic() {
+				var (
+					pField = assoc.PolymorphicField()
+					pValue = assoc.PolymorphicValue()
+				)
+
+				mutation.Add(Set(pField, pValue))
+				doc.SetValue(pField, pValue)
+			}
 		}
 	}
 
@@ -587,6 +1019,16 @@ func (r repository) saveHasOne(cw contextWrapper, doc *Document, mutation *Mutat
 			assocMut.Add(Set(fField, rValue))
 			assocDoc.SetValue(fField, rValue)
 
+			if assoc.Polymorphic() {
+				var (
+					pField = assoc.PolymorphicField()
+					pValue = assoc.PolymorphicValue()
+				)
+
+				assocMut.Add(Set(pField, pValue))
+				assocDoc.SetValue(pField, pValue)
+			}
+
 			if err := r.insert(cw, assocDoc, assocMut); err != nil {
 				return err
 			}
@@ -608,6 +1050,14 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 			continue
 		}
 
+		if assoc.Type() == ManyToMany {
+			if err := r.saveManyToMany(cw, assoc, assocMuts, insertion); err != nil {
+				return err
+			}
+
+			continue
+		}
+
 		var (
 			col, _     = assoc.Collection()
 			table      = col.Table()
@@ -624,17 +1074,17 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 
 		if !insertion {
 			var (
-				filter = Eq(fField, rValue)
+				filter = filterHasMany(assoc)
 			)
 
 			if deletedIDs == nil {
 				// if it's nil, then clear old association (used by structset).
-				if _, err := r.deleteAll(cw, col.data.flag, Build(table, filter)); err != nil {
+				if _, err := r.deleteAll(cw, col.data.flagFieldName(HasDeletedAt), Build(table, filter)); err != nil {
 					return err
 				}
 			} else if len(deletedIDs) > 0 {
 				filter = filter.AndIn(col.PrimaryField(), deletedIDs...)
-				if _, err := r.deleteAll(cw, col.data.flag, Build(table, filter)); err != nil {
+				if _, err := r.deleteAll(cw, col.data.flagFieldName(HasDeletedAt), Build(table, filter)); err != nil {
 					return err
 				}
 			}
@@ -655,6 +1105,10 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 					filter    = filterDocument(assocDoc).AndEq(fField, rValue)
 				)
 
+				if assoc.Polymorphic() {
+					filter = filter.AndEq(assoc.PolymorphicField(), assoc.PolymorphicValue())
+				}
+
 				if rValue != fValue {
 					return ConstraintError{
 						Key:  fField,
@@ -676,6 +1130,11 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 			} else {
 				muts[i].Add(Set(fField, rValue))
 				assocDoc.SetValue(fField, rValue)
+
+				if assoc.Polymorphic() {
+					muts[i].Add(Set(assoc.PolymorphicField(), assoc.PolymorphicValue()))
+					assocDoc.SetValue(assoc.PolymorphicField(), assoc.PolymorphicValue())
+				}
 			}
 		}
 
@@ -700,6 +1159,146 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 	return nil
 }
 
+// saveManyToMany saves records of many to many association, and then links them to parent using join table.
+// persisted records are only updated when deleted IDs is not nil, otherwise it's assumed to be an existing record to be linked.
+func (r repository) saveManyToMany(cw contextWrapper, assoc Association, assocMuts AssocMutation, insertion bool) error {
+	var (
+		col, _     = assoc.Collection()
+		joinTable  = assoc.JoinTable()
+		jRefField  = assoc.JoinReferenceField()
+		jFkField   = assoc.JoinForeignField()
+		fField     = assoc.ForeignField()
+		rValue     = assoc.ReferenceValue()
+		muts       = assocMuts.Mutations
+		deletedIDs = assocMuts.DeletedIDs
+		linked     = make(map[interface{}]struct{})
+	)
+
+	// this shouldn't happen unless there's bug in the mutator.
+	if len(muts) != col.Len() {
+		panic("rel: invalid mutator")
+	}
+
+	if !insertion {
+		var (
+			filter = Eq(jRefField, rValue)
+		)
+
+		if deletedIDs == nil {
+			// if it's nil, then unlink old association (used by structset).
+			if _, err := r.deleteAll(cw, "", Build(joinTable, filter)); err != nil {
+				return err
+			}
+		} else {
+			if len(deletedIDs) > 0 {
+				filter = filter.AndIn(jFkField, deletedIDs...)
+				if _, err := r.deleteAll(cw, "", Build(joinTable, filter)); err != nil {
+					return err
+				}
+			}
+
+			if col.Len() > 0 {
+				var (
+					typ, _ = col.Get(0).Type(fField)
+					err    error
+				)
+
+				if linked, err = r.joinedValues(cw, assoc, typ); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// update and filter for bulk insertion.
+	updateCount := 0
+	for i := range muts {
+		var (
+			assocDoc = col.Get(i)
+		)
+
+		if !assocDoc.Persisted() {
+			continue
+		}
+
+		if updateCount < i {
+			col.Swap(updateCount, i)
+			muts[i], muts[updateCount] = muts[updateCount], muts[i]
+		}
+
+		if deletedIDs != nil {
+			if err := r.update(cw, assocDoc, muts[updateCount], filterDocument(assocDoc)); err != nil {
+				return err
+			}
+		}
+
+		updateCount++
+	}
+
+	if len(muts)-updateCount > 0 {
+		if err := r.insertAll(cw, col.Slice(updateCount, len(muts)), muts[updateCount:]); err != nil {
+			return err
+		}
+	}
+
+	var (
+		bulkMutates = make([]map[string]Mutate, 0, col.Len())
+	)
+
+	for i := 0; i < col.Len(); i++ {
+		var (
+			fValue, _ = col.Get(i).Value(fField)
+		)
+
+		if _, ok := linked[fValue]; ok {
+			continue
+		}
+
+		linked[fValue] = struct{}{}
+		bulkMutates = append(bulkMutates, map[string]Mutate{
+			jRefField: Set(jRefField, rValue),
+			jFkField:  Set(jFkField, fValue),
+		})
+	}
+
+	if len(bulkMutates) > 0 {
+		if _, err := cw.adapter.InsertAll(cw.ctx, Build(joinTable), "", []string{jRefField, jFkField}, bulkMutates, OnConflict{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// joinedValues returns foreign values that's currently linked to the parent in join table.
+func (r repository) joinedValues(cw contextWrapper, assoc Association, typ reflect.Type) (map[interface{}]struct{}, error) {
+	var (
+		values = make(map[interface{}]struct{})
+		query  = Build(assoc.JoinTable(), Select(assoc.JoinForeignField()), Eq(assoc.JoinReferenceField(), assoc.ReferenceValue()))
+	)
+
+	cur, err := cw.adapter.Query(cw.ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close()
+
+	for cur.Next() {
+		var (
+			value = reflect.New(typ)
+		)
+
+		if err := cur.Scan(value.Interface()); err != nil {
+			return nil, err
+		}
+
+		values[value.Elem().Interface()] = struct{}{}
+	}
+
+	return values, nil
+}
+
 func (r repository) UpdateAll(ctx context.Context, query Query, mutates ...Mutate) error {
 	finish := r.instrumenter.Observe(ctx, "rel-update-all", "updating multiple records")
 	defer finish(nil)
@@ -731,7 +1330,7 @@ func (r repository) Delete(ctx context.Context, record interface{}, options ...C
 
 	var (
 		cw      = fetchContext(ctx, r.rootAdapter)
-		doc     = NewDocument(record)
+		doc     = documentWithNaming(record, r.naming, false)
 		cascade = Cascade(false)
 	)
 
@@ -739,7 +1338,8 @@ func (r repository) Delete(ctx context.Context, record interface{}, options ...C
 		cascade = options[0]
 	}
 
-	if cascade {
+	// after hook runs inside transaction, so it can abort the deletion.
+	if _, hook := doc.v.(AfterDeleteHook); bool(cascade) || hook {
 		return r.transaction(cw, func(cw contextWrapper) error {
 			return r.delete(cw, doc, filterDocument(doc), cascade)
 		})
@@ -750,8 +1350,22 @@ func (r repository) Delete(ctx context.Context, record interface{}, options ...C
 
 func (r repository) delete(cw contextWrapper, doc *Document, filter FilterQuery, cascade Cascade) error {
 	var (
-		table = doc.Table()
-		query = Build(table, filter)
+		notFound = error(NotFoundError{})
+	)
+
+	if err := beforeDelete(cw, doc); err != nil {
+		return err
+	}
+
+	// optimistic locking, only deletes record when it's still in the same version as loaded.
+	if doc.Flag(HasLockVersion) {
+		lockFilter, _ := filterLockVersion(doc)
+		filter = filter.And(lockFilter)
+		notFound = StaleRecordError{}
+	}
+
+	var (
+		query = Build(doc.Table(), filter)
 	)
 
 	if cascade {
@@ -764,18 +1378,22 @@ func (r repository) delete(cw contextWrapper, doc *Document, filter FilterQuery,
 		}
 	}
 
-	deletedCount, err := r.deleteAll(cw, doc.data.flag, query)
+	deletedCount, err := r.deleteAll(cw, doc.data.flagFieldName(HasDeletedAt), query)
 	if err == nil && deletedCount == 0 {
-		err = NotFoundError{}
+		err = notFound
 	}
 
-	if err == nil && cascade {
+	if err != nil {
+		return err
+	}
+
+	if cascade {
 		if err := r.deleteBelongsTo(cw, doc, cascade); err != nil {
 			return err
 		}
 	}
 
-	return err
+	return afterDelete(cw, doc)
 }
 
 func (r repository) deleteBelongsTo(cw contextWrapper, doc *Document, cascade Cascade) error {
@@ -838,17 +1456,40 @@ func (r repository) deleteHasMany(cw contextWrapper, doc *Document) error {
 			continue
 		}
 
+		// only unlinks records of many to many association, the records itself might be linked to other parent.
+		if assoc.Type() == ManyToMany {
+			var (
+				filter = Eq(assoc.JoinReferenceField(), assoc.ReferenceValue())
+			)
+
+			if _, err := r.deleteAll(cw, "", Build(assoc.JoinTable(), filter)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
 		if col, loaded := assoc.Collection(); loaded {
 			var (
 				table  = col.Table()
-				fField = assoc.ForeignField()
-				rValue = assoc.ReferenceValue()
-				filter = Eq(fField, rValue).And(filterCollection(col))
+				filter = filterHasMany(assoc).And(filterCollection(col))
 			)
 
-			if _, err := r.deleteAll(cw, col.data.flag, Build(table, filter)); err != nil {
+			for i := 0; i < col.Len(); i++ {
+				if err := beforeDelete(cw, col.Get(i)); err != nil {
+					return err
+				}
+			}
+
+			if _, err := r.deleteAll(cw, col.data.flagFieldName(HasDeletedAt), Build(table, filter)); err != nil {
 				return err
 			}
+
+			for i := 0; i < col.Len(); i++ {
+				if err := afterDelete(cw, col.Get(i)); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -865,7 +1506,7 @@ func (r repository) DeleteAll(ctx context.Context, query Query) error {
 
 	var (
 		cw     = fetchContext(ctx, r.rootAdapter)
-		_, err = r.deleteAll(cw, Invalid, query)
+		_, err = r.deleteAll(cw, "", query)
 	)
 
 	return err
@@ -875,9 +1516,10 @@ func (r repository) MustDeleteAll(ctx context.Context, query Query) {
 	must(r.DeleteAll(ctx, query))
 }
 
-func (r repository) deleteAll(cw contextWrapper, flag DocumentFlag, query Query) (int, error) {
-	if flag.Is(HasDeletedAt) {
-		mutates := map[string]Mutate{"deleted_at": Set("deleted_at", now())}
+// deleteAll deletes records matching the query, records are soft deleted when deletedAt field is given.
+func (r repository) deleteAll(cw contextWrapper, deletedAt string, query Query) (int, error) {
+	if deletedAt != "" {
+		mutates := map[string]Mutate{deletedAt: Set(deletedAt, now())}
 		return cw.adapter.Update(cw.ctx, query, mutates)
 	}
 
@@ -900,9 +1542,9 @@ func (r repository) Preload(ctx context.Context, records interface{}, field stri
 
 	rt = rt.Elem()
 	if rt.Kind() == reflect.Slice {
-		sl = NewCollection(records)
+		sl = collectionWithNaming(records, r.naming, false)
 	} else {
-		sl = NewDocument(records)
+		sl = documentWithNaming(records, r.naming, false)
 	}
 
 	return r.preload(cw, sl, field, queriers)
@@ -910,18 +1552,73 @@ func (r repository) Preload(ctx context.Context, records interface{}, field stri
 
 func (r repository) preload(cw contextWrapper, records slice, field string, queriers []Querier) error {
 	var (
-		path                                             = strings.Split(field, ".")
-		targets, table, keyField, keyType, ddata, loaded = r.mapPreloadTargets(records, path)
-		ids                                              = r.targetIDs(targets)
-		query                                            = Build(table, append(queriers, In(keyField, ids...))...)
+		path = strings.Split(field, ".")
+	)
+
+	// intermediate associations that are not loaded yet are loaded first, so nested association can be preloaded in a single call.
+	for i := 1; i < len(path); i++ {
+		if err := r.preloadPath(cw, records, path[:i], true, nil, 0); err != nil {
+			return err
+		}
+	}
+
+	var (
+		rest, nested, limit = preloadQueriers(queriers)
+	)
+
+	if err := r.preloadPath(cw, records, path, false, rest, limit); err != nil {
+		return err
+	}
+
+	for i := range nested {
+		if err := r.preload(cw, records, field+"."+nested[i].Field, nested[i].Queriers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r repository) preloadPath(cw contextWrapper, records slice, path []string, skipLoaded bool, queriers []Querier, limit PreloadLimit) error {
+	var (
+		targets, table, assoc, keyType, ddata, loaded = r.mapPreloadTargets(records, path, skipLoaded)
+		ids                                           = r.targetIDs(targets)
+		keyField                                      = assoc.ForeignField()
+		partition                                     = table + "." + keyField
+		query                                         = Build(table, queriers...)
 	)
 
 	if len(targets) == 0 || loaded && !bool(query.ReloadQuery) {
 		return nil
 	}
 
+	for _, slices := range targets {
+		for i := range slices {
+			slices[i].Reset()
+		}
+	}
+
+	if assoc.Type() == ManyToMany {
+		keyField = preloadJoinKey
+		partition = assoc.JoinTable() + "." + assoc.JoinReferenceField()
+		query = preloadManyToManyQuery(query, assoc, ids)
+	} else {
+		query = query.Where(In(keyField, ids...))
+	}
+
+	// target of polymorphic belongs to is already grouped by type when mapping preload targets.
+	if assoc.Polymorphic() && assoc.Type() != BelongsTo {
+		query = query.Where(Eq(assoc.PolymorphicField(), assoc.PolymorphicValue()))
+	}
+
+	query = r.withDefaultScope(ddata, query, false)
+
+	if limit > 0 {
+		query = preloadLimitQuery(query, partition, int(limit))
+	}
+
 	var (
-		cur, err = cw.adapter.Query(cw.ctx, r.withDefaultScope(ddata, query, false))
+		cur, err = cw.adapter.Query(cw.ctx, query)
 	)
 
 	if err != nil {
@@ -931,14 +1628,66 @@ func (r repository) preload(cw contextWrapper, records slice, field string, quer
 	scanFinish := r.instrumenter.Observe(cw.ctx, "rel-scan-multi", "scanning all records to multiple targets")
 	defer scanFinish(nil)
 
-	return scanMulti(cur, keyField, keyType, targets)
+	if err := scanMulti(cur, keyField, keyType, targets); err != nil {
+		return err
+	}
+
+	return afterFindTargets(cw, keyField, targets)
 }
 
 func (r repository) MustPreload(ctx context.Context, records interface{}, field string, queriers ...Querier) {
 	must(r.Preload(ctx, records, field, queriers...))
 }
 
-func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}][]slice, string, string, reflect.Type, documentData, bool) {
+// preloadJoinKey is an alias of join table's reference field when preloading many to many association.
+const preloadJoinKey = "rel_join_key"
+
+// preloadManyToManyQuery joins target table with join table, join table's reference field is selected as preload key.
+func preloadManyToManyQuery(query Query, assoc Association, ids []interface{}) Query {
+	var (
+		joinTable = assoc.JoinTable()
+		fields    = make([]string, 0, len(query.SelectQuery.Fields)+1)
+	)
+
+	if len(query.SelectQuery.Fields) == 0 {
+		fields = append(fields, query.Table+".*")
+	} else {
+		fields = append(fields, query.SelectQuery.Fields...)
+	}
+
+	query.SelectQuery.Fields = append(fields, joinTable+"."+assoc.JoinReferenceField()+" AS "+preloadJoinKey)
+
+	return query.
+		JoinOn(joinTable, joinTable+"."+assoc.JoinForeignField(), query.Table+"."+assoc.ForeignField()).
+		Where(In(joinTable+"."+assoc.JoinReferenceField(), ids...))
+}
+
+// preloadRankField is an alias of row number used to limit preloaded records of each parent.
+const preloadRankField = "rel_preload_rank"
+
+// preloadLimitQuery numbers records of each parent using row number window function partitioned by the preload key,
+// and wraps the query as subquery to select only the first n records of each parent.
+func preloadLimitQuery(query Query, partition string, n int) Query {
+	var (
+		sorts  = query.SortQuery
+		window = PartitionBy(partition)
+	)
+
+	window.Sort = sorts
+	if len(query.SelectQuery.Fields) == 0 {
+		query.SelectQuery.Fields = []string{query.Table + ".*"}
+	}
+
+	query.SortQuery = nil
+	query.SelectQuery = query.SelectQuery.Expr(RowNumber().Over(window).As(preloadRankField))
+
+	outer := From(query).As(query.Table).Where(Lte(preloadRankField, n))
+	outer.SortQuery = sorts
+
+	return outer
+}
+
+func (r repository) mapPreloadTargets(sl slice, path []string, skipLoaded bool) (map[interface{}][]slice, string, Association, reflect.Type, documentData, bool) {
 	type frame struct {
 		index int
 		doc   *Document
@@ -946,7 +1695,7 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 
 	var (
 		table     string
-		keyField  string
+		assoc     Association
 		keyType   reflect.Type
 		ddata     documentData
 		loaded    = true
@@ -975,23 +1724,26 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 				ref          = assocs.ReferenceValue()
 			)
 
-			if ref == nil {
+			if ref == nil || !assocs.polymorphicMatched() {
 				continue
 			}
 
-			if assocs.Type() == HasMany {
+			if assocs.Type() == HasMany || assocs.Type() == ManyToMany {
 				target, targetLoaded = assocs.Collection()
 			} else {
 				target, targetLoaded = assocs.Document()
 			}
 
-			target.Reset()
+			if skipLoaded && targetLoaded {
+				continue
+			}
+
 			mapTarget[ref] = append(mapTarget[ref], target)
 			loaded = loaded && targetLoaded
 
 			if table == "" {
 				table = target.Table()
-				keyField = assocs.ForeignField()
+				assoc = assocs
 				keyType = reflect.TypeOf(ref)
 
 				if doc, ok := target.(*Document); ok {
@@ -1003,7 +1755,7 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 				}
 			}
 		} else {
-			if assocs.Type() == HasMany {
+			if assocs.Type() == HasMany || assocs.Type() == ManyToMany {
 				var (
 					col, loaded = assocs.Collection()
 				)
@@ -1031,7 +1783,7 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 
 	}
 
-	return mapTarget, table, keyField, keyType, ddata, loaded
+	return mapTarget, table, assoc, keyType, ddata, loaded
 }
 
 func (r repository) targetIDs(targets map[interface{}][]slice) []interface{} {
@@ -1053,8 +1805,8 @@ func (r repository) withDefaultScope(ddata documentData, query Query, preload bo
 		return query
 	}
 
-	if ddata.flag.Is(HasDeletedAt) {
-		query = query.Where(Nil("deleted_at"))
+	if deletedAt := ddata.flagFieldName(HasDeletedAt); deletedAt != "" {
+		query = softDeleteScope(query, deletedAt)
 	}
 
 	if preload && bool(query.CascadeQuery) {
@@ -1064,6 +1816,33 @@ func (r repository) withDefaultScope(ddata documentData, query Query, preload bo
 	return query
 }
 
+// softDeleteScope excludes soft deleted records from the query and every combined query of the same table.
+// Combined query of other table is left as is, since the soft delete column might not exists in that table.
+func softDeleteScope(query Query, deletedAt string) Query {
+	if len(query.JoinQuery) == 0 {
+		query = query.Where(Nil(deletedAt))
+	} else if query.Alias != "" {
+		query = query.Where(Nil(query.Alias + "." + deletedAt))
+	} else {
+		query = query.Where(Nil(query.Table + "." + deletedAt))
+	}
+
+	if len(query.CombinationQuery) > 0 {
+		combinations := make([]CombinationQuery, len(query.CombinationQuery))
+		copy(combinations, query.CombinationQuery)
+
+		for i := range combinations {
+			if operand := combinations[i].Query; operand.Table == query.Table && !operand.UnscopedQuery {
+				combinations[i].Query = softDeleteScope(operand, deletedAt)
+			}
+		}
+
+		query.CombinationQuery = combinations
+	}
+
+	return query
+}
+
 func (r repository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	finish := r.instrumenter.Observe(ctx, "rel-transaction", "transaction")
 	defer finish(nil)
@@ -1117,6 +1896,7 @@ func New(adapter Adapter) Repository {
 	repo := &repository{
 		rootAdapter:  adapter,
 		instrumenter: DefaultLogger,
+		naming:       DefaultNamingStrategy{},
 	}
 
 	repo.Instrumentation(DefaultLogger)
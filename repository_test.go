@@ -137,6 +137,121 @@ func TestRepository_MustAggregate(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_AggregateAll(t *testing.T) {
+	type report struct {
+		Gender string
+		Count  int
+		Total  float64
+	}
+
+	var (
+		reports []report
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("users").Select("gender").SelectExpr(Count("*").As("count"), Sum("balance").As("total")).Group("gender").Having(Gt("count", 1))
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+	cur.On("Fields").Return([]string{"gender", "count", "total"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan("female", 2, 10.5).Once()
+	cur.MockScan("male", 3, 20.25).Once()
+	cur.On("Next").Return(false).Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Nil(t, repo.AggregateAll(context.TODO(), &reports, query))
+	assert.Equal(t, []report{
+		{Gender: "female", Count: 2, Total: 10.5},
+		{Gender: "male", Count: 3, Total: 20.25},
+	}, reports)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateAll_softDelete(t *testing.T) {
+	var (
+		addresses []Address
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		query     = From("addresses").Select("street").SelectExpr(Count("*").As("id")).Group("street")
+		cur       = createCursor(1)
+	)
+
+	adapter.On("Query", query.Where(Nil("deleted_at"))).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.AggregateAll(context.TODO(), &addresses, query))
+	assert.Equal(t, []Address{{ID: 10}}, addresses)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateAll_map(t *testing.T) {
+	var (
+		rows    []map[string]interface{}
+		now     = time.Now()
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("users").Select("gender").SelectExpr(Max("created_at").As("latest"), Avg("balance").As("average")).Group("gender")
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+	cur.On("Fields").Return([]string{"gender", "latest", "average"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan([]byte("female"), now, 10.5).Once()
+	cur.On("Next").Return(false).Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Nil(t, repo.AggregateAll(context.TODO(), &rows, query))
+	assert.Equal(t, []map[string]interface{}{
+		{"gender": "female", "latest": now, "average": 10.5},
+	}, rows)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateAll_queryError(t *testing.T) {
+	var (
+		reports []User
+		rows    []map[string]interface{}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("users").SelectExpr(Count("*").As("id"))
+		err     = errors.New("error")
+	)
+
+	adapter.On("Query", query).Return(&testCursor{}, err).Twice()
+
+	assert.Equal(t, err, repo.AggregateAll(context.TODO(), &reports, query))
+	assert.Equal(t, err, repo.AggregateAll(context.TODO(), &rows, query))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_MustAggregateAll(t *testing.T) {
+	var (
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("users").SelectExpr(Count("*").As("id"))
+		cur     = createCursor(1)
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+
+	assert.NotPanics(t, func() {
+		repo.MustAggregateAll(context.TODO(), &users, query)
+	})
+	assert.Equal(t, []User{{ID: 10}}, users)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
 func TestRepository_Count(t *testing.T) {
 	var (
 		adapter = &testAdapter{}
@@ -227,6 +342,30 @@ func TestRepository_Find_softDeleteUnscoped(t *testing.T) {
 	cur.AssertExpectations(t)
 }
 
+func TestRepository_FindAll_softDeleteCombination(t *testing.T) {
+	var (
+		addresses []Address
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		archived  = From("archived_addresses")
+		unscoped  = From("addresses").Where(Eq("city", "c")).Unscoped()
+		query     = From("addresses").Where(Eq("city", "a")).Union(From("addresses").Where(Eq("city", "b")))
+		cur       = createCursor(0)
+	)
+
+	query = query.Union(unscoped).Union(archived)
+
+	adapter.On("Query", From("addresses").Where(Eq("city", "a"), Nil("deleted_at")).
+		Union(From("addresses").Where(Eq("city", "b"), Nil("deleted_at"))).
+		Union(unscoped).Union(archived)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &addresses, query))
+	assert.Len(t, addresses, 0)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
 func TestRepository_Find_withCascade(t *testing.T) {
 	var (
 		trx        Transaction
@@ -519,6 +658,247 @@ func TestRepository_FindAll_withPreloadError(t *testing.T) {
 	curPreload.AssertExpectations(t)
 }
 
+func TestRepository_FindAll_joinAssoc(t *testing.T) {
+	tests := []struct {
+		name     string
+		records  interface{}
+		query    Query
+		expected Query
+		joins    []JoinQuery
+	}{
+		{
+			name:     "belongs to and has one",
+			records:  &[]Transaction{},
+			query:    From("transactions").JoinAssoc("buyer.address"),
+			expected: From("transactions").Select("transactions.*").Preload("buyer"),
+			joins: []JoinQuery{
+				NewJoinOn("users", "users.id", "transactions.user_id"),
+				{Mode: "JOIN", Table: "addresses", From: "addresses.user_id", To: "users.id", Filter: Nil("addresses.deleted_at")},
+			},
+		},
+		{
+			name:     "shared path",
+			records:  &[]Transaction{},
+			query:    From("transactions").JoinAssoc("buyer").JoinAssocWith("LEFT JOIN", "buyer.address").Unscoped(),
+			expected: From("transactions").Select("transactions.*").Unscoped(),
+			joins: []JoinQuery{
+				NewJoinOn("users", "users.id", "transactions.user_id"),
+				NewLeftJoinOn("addresses", "addresses.user_id", "users.id"),
+			},
+		},
+		{
+			name:     "soft delete root",
+			records:  &[]Address{},
+			query:    From("addresses").JoinAssoc("user"),
+			expected: From("addresses").Select("addresses.*").Where(Nil("addresses.deleted_at")),
+			joins: []JoinQuery{
+				NewJoinOn("users", "users.id", "addresses.user_id"),
+			},
+		},
+		{
+			name:     "many to many",
+			records:  &[]Article{},
+			query:    From("articles").JoinAssoc("tags"),
+			expected: From("articles").Select("articles.*"),
+			joins: []JoinQuery{
+				NewJoinOn("article_tags", "article_tags.article_id", "articles.id"),
+				NewJoinOn("tags", "tags.id", "article_tags.tag_id"),
+			},
+		},
+		{
+			name:     "through",
+			records:  &[]User{},
+			query:    From("users").JoinAssoc("roles"),
+			expected: From("users").Select("users.*"),
+			joins: []JoinQuery{
+				NewJoinOn("user_roles", "user_roles.user_id", "users.id"),
+				NewJoinOn("roles", "roles.id", "user_roles.role_id"),
+			},
+		},
+		{
+			name:     "through self referencing",
+			records:  &[]User{},
+			query:    From("users").JoinAssoc("followings"),
+			expected: From("users").Select("users.*"),
+			joins: []JoinQuery{
+				NewJoinOn("follows", "follows.following_id", "users.id"),
+				NewJoinOn("users AS followings", "followings.id", "follows.following_id"),
+			},
+		},
+		{
+			name:     "polymorphic",
+			records:  &[]Post{},
+			query:    From("posts").Select("posts.id").JoinAssoc("comments"),
+			expected: From("posts").Select("posts.id"),
+			joins: []JoinQuery{
+				{Mode: "JOIN", Table: "comments", From: "comments.owner_id", To: "posts.id", Filter: Eq("comments.owner_type", "posts")},
+			},
+		},
+		{
+			name:     "mixed with join",
+			records:  &[]Transaction{},
+			query:    From("transactions").Join("users").JoinAssoc("buyer"),
+			expected: From("transactions").Select("transactions.*").Preload("buyer"),
+			joins: []JoinQuery{
+				NewJoin("users"),
+				NewJoinOn("users AS buyer", "buyer.id", "transactions.user_id"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				adapter = &testAdapter{}
+				repo    = New(adapter)
+				cur     = createCursor(0)
+			)
+
+			test.expected.JoinQuery = test.joins
+			adapter.On("Query", test.expected).Return(cur, nil).Once()
+
+			assert.Nil(t, repo.FindAll(context.TODO(), test.records, test.query))
+
+			adapter.AssertExpectations(t)
+			cur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepository_FindAll_joinAssocPopulateHasMany(t *testing.T) {
+	var (
+		users []User
+		repo  = New(&testAdapter{})
+	)
+
+	assert.Equal(t, errors.New("rel: populate is only supported for belongs to and has one association"),
+		repo.FindAll(context.TODO(), &users, JoinPopulate("transactions")))
+}
+
+func TestRepository_FindAll_joinAssocUnknown(t *testing.T) {
+	var (
+		users []User
+		user  User
+		repo  = New(&testAdapter{})
+		err   = errors.New("rel: no association named (unknown) in type rel.User found")
+	)
+
+	assert.Equal(t, err, repo.FindAll(context.TODO(), &users, JoinAssoc("unknown")))
+	assert.Equal(t, err, repo.Find(context.TODO(), &user, JoinAssoc("unknown")))
+	assert.Equal(t, errors.New("rel: no association named (name) in type rel.User found"),
+		repo.FindAll(context.TODO(), &users, JoinAssoc("name")))
+
+	_, ferr := repo.FindAndCountAll(context.TODO(), &users, JoinAssoc("transactions.unknown"))
+	assert.Equal(t, errors.New("rel: no association named (unknown) in type rel.Transaction found"), ferr)
+}
+
+func TestRepository_FindAll_joinAssocThroughUndeclared(t *testing.T) {
+	type Badge struct {
+		ID int
+	}
+
+	type Membership struct {
+		ID      int
+		OwnerID int
+		BadgeID int
+	}
+
+	type Owner struct {
+		ID          int
+		Memberships []Membership
+		Badges      []Badge `through:"memberships"`
+	}
+
+	var (
+		owners []Owner
+		repo   = New(&testAdapter{})
+	)
+
+	assert.Equal(t, errors.New("rel: join_fk of through association (badges) is not declared in type rel.Membership"),
+		repo.FindAll(context.TODO(), &owners, JoinAssoc("badges")))
+}
+
+func TestRepository_Find_joinPopulate(t *testing.T) {
+	var (
+		transaction Transaction
+		adapter     = &testAdapter{}
+		repo        = New(adapter)
+		cur         = &testCursor{}
+		query       = From("transactions").Select(
+			"transactions.*",
+			"users.id AS buyer.id",
+			"users.name AS buyer.name",
+			"users.age AS buyer.age",
+			"users.created_at AS buyer.created_at",
+			"users.updated_at AS buyer.updated_at",
+		).Cascade(false).Limit(1)
+	)
+
+	query.JoinQuery = []JoinQuery{NewJoinOn("users", "users.id", "transactions.user_id")}
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+	cur.On("Fields").Return([]string{"id", "user_id", "buyer.id", "buyer.name", "buyer.address"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, 2, 2, "name", "unknown").Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Nil(t, repo.Find(context.TODO(), &transaction, JoinPopulate("buyer").Cascade(false)))
+	assert.Equal(t, Transaction{ID: 1, BuyerID: 2, Buyer: User{ID: 2, Name: "name"}}, transaction)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_FindAll_joinPopulateLeftJoin(t *testing.T) {
+	var (
+		addresses []Address
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		cur       = &testCursor{}
+		userID    = 2
+	)
+
+	adapter.On("Query", mock.Anything).Return(cur, nil).Once()
+	cur.On("Fields").Return([]string{"id", "user_id", "user.id", "user.name"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(1, 2, 2, "name").Once()
+	cur.MockScan(2, nil, nil, nil).Once()
+	cur.On("Next").Return(false).Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &addresses, JoinPopulateWith("LEFT JOIN", "user")))
+	assert.Equal(t, []Address{
+		{ID: 1, UserID: &userID, User: &User{ID: 2, Name: "name"}},
+		{ID: 2},
+	}, addresses)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_FindAndCountAll_joinAssoc(t *testing.T) {
+	var (
+		transactions []Transaction
+		adapter      = &testAdapter{}
+		repo         = New(adapter)
+		query        = From("transactions").Select("transactions.*").Cascade(false)
+		cur          = createCursor(2)
+	)
+
+	query.JoinQuery = []JoinQuery{NewJoinOn("users", "users.id", "transactions.user_id")}
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+	adapter.On("Aggregate", query, "count", "*").Return(2, nil).Once()
+
+	count, err := repo.FindAndCountAll(context.TODO(), &transactions, JoinAssoc("buyer").Cascade(false))
+	assert.Nil(t, err)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, 2, count)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
 func TestRepository_FindAll_scanError(t *testing.T) {
 	var (
 		users   []User
@@ -645,90 +1025,429 @@ func TestRepository_MustFindAndCountAll(t *testing.T) {
 	cur.AssertExpectations(t)
 }
 
-func TestRepository_Insert(t *testing.T) {
+func TestRepository_FindPage(t *testing.T) {
 	var (
+		users   []User
 		adapter = &testAdapter{}
 		repo    = New(adapter)
-		user    = User{
-			Name: "name",
-		}
-		mutates = map[string]Mutate{
-			"name":       Set("name", "name"),
-			"age":        Set("age", 0),
-			"created_at": Set("created_at", now()),
-			"updated_at": Set("updated_at", now()),
-		}
+		query   = From("users").Where(Eq("age", 10)).SortDesc("name").Limit(100).Offset(10)
+		cur     = createCursor(3)
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(1, nil).Once()
+	adapter.On("Query", From("users").Where(Eq("age", 10)).SortDesc("name").SortAsc("id").Limit(3)).Return(cur, nil).Once()
 
-	assert.Nil(t, repo.Insert(context.TODO(), &user))
-	assert.Equal(t, User{
-		ID:        1,
-		Name:      "name",
-		CreatedAt: now(),
-		UpdatedAt: now(),
-	}, user)
+	next, err := repo.FindPage(context.TODO(), &users, "", 2, query)
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, 10, users[0].ID)
+	assert.Equal(t, 10, users[1].ID)
+
+	keys, _ := pageKeys(NewCollection(&users), query.SortQuery)
+	values, err := decodePageCursor(keys, next)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"", 10}, values)
 
 	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
 }
 
-func TestRepository_Insert_compositePrimaryFields(t *testing.T) {
+func TestRepository_FindPage_lastPage(t *testing.T) {
 	var (
-		adapter  = &testAdapter{}
-		repo     = New(adapter)
-		userRole = UserRole{
-			UserID: 1,
-			RoleID: 2,
-		}
-		mutates = map[string]Mutate{
-			"user_id": Set("user_id", 1),
-			"role_id": Set("role_id", 2),
-		}
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("users").SortDesc("name")
+		cur     = createCursor(1)
+		keys, _ = pageKeys(NewCollection(&users), query.SortQuery)
+		doc     = NewDocument(&User{ID: 10, Name: "name"})
 	)
 
-	adapter.On("Insert", From("user_roles"), mutates).Return(0, nil).Once()
+	cursor, _ := encodePageCursor(keys, doc)
 
-	assert.Nil(t, repo.Insert(context.TODO(), &userRole))
-	assert.Equal(t, UserRole{
-		UserID: 1,
-		RoleID: 2,
-	}, userRole)
+	adapter.On("Query", query.Where(Or(
+		Lt("name", "name"),
+		And(Eq("name", "name"), Gt("id", 10)),
+	)).SortAsc("id").Limit(3)).Return(cur, nil).Once()
+
+	assert.NotPanics(t, func() {
+		next := repo.MustFindPage(context.TODO(), &users, cursor, 2, query)
+		assert.Equal(t, "", next)
+		assert.Len(t, users, 1)
+	})
 
 	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
 }
 
-func TestRepository_Insert_sets(t *testing.T) {
+func TestRepository_FindPage_invalidCursor(t *testing.T) {
 	var (
-		user     User
-		adapter  = &testAdapter{}
-		repo     = New(adapter)
-		mutators = []Mutator{
-			Set("name", "name"),
-			Set("created_at", now()),
-			Set("updated_at", now()),
-		}
-		mutates = map[string]Mutate{
-			"name":       Set("name", "name"),
-			"created_at": Set("created_at", now()),
-			"updated_at": Set("updated_at", now()),
-		}
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(1, nil).Once()
-
-	assert.Nil(t, repo.Insert(context.TODO(), &user, mutators...))
-	assert.Equal(t, User{
-		ID:        1,
-		Name:      "name",
-		CreatedAt: now(),
-		UpdatedAt: now(),
-	}, user)
+	next, err := repo.FindPage(context.TODO(), &users, "invalid", 2)
+	assert.Equal(t, ErrInvalidPageCursor, err)
+	assert.Equal(t, "", next)
 
 	adapter.AssertExpectations(t)
 }
 
-func TestRepository_Insert_saveBelongsTo(t *testing.T) {
+func TestRepository_FindPage_invalidSize(t *testing.T) {
+	var (
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	for _, size := range []int{0, -1} {
+		next, err := repo.FindPage(context.TODO(), &users, "", size)
+		assert.Equal(t, ErrInvalidPageSize, err)
+		assert.Equal(t, "", next)
+	}
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_FindPage_unknownSortField(t *testing.T) {
+	var (
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	next, err := repo.FindPage(context.TODO(), &users, "", 2, NewSortAsc("unknown"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "", next)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_FindPage_error(t *testing.T) {
+	var (
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		err     = errors.New("error")
+	)
+
+	adapter.On("Query", From("users").SortAsc("id").Limit(11)).Return(&testCursor{}, err).Once()
+
+	next, ferr := repo.FindPage(context.TODO(), &users, "", 10)
+	assert.Equal(t, err, ferr)
+	assert.Equal(t, "", next)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user))
+	assert.Equal(t, User{
+		ID:        1,
+		Name:      "name",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}, user)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_embedded(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		place   = Place{
+			Name:    "name",
+			Address: Location{City: "city"},
+		}
+		mutates = map[string]Mutate{
+			"name":           Set("name", "name"),
+			"street":         Set("street", ""),
+			"location":       Set("location", Location{}),
+			"created_at":     Set("created_at", now()),
+			"updated_at":     Set("updated_at", now()),
+			"created_by":     Set("created_by", nil),
+			"deleted_at":     Set("deleted_at", nil),
+			"address_street": Set("address_street", ""),
+			"address_city":   Set("address_city", "city"),
+		}
+	)
+
+	adapter.On("Insert", From("places"), mutates, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &place))
+	assert.Equal(t, 1, place.ID)
+	assert.Equal(t, now(), place.CreatedAt)
+	assert.Equal(t, now(), place.UpdatedAt)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Find_embedded(t *testing.T) {
+	var (
+		place   Place
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("places").Where(Eq("id", 1)).Limit(1)
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query.Where(Nil("deleted_at"))).Return(cur, nil).Once()
+
+	cur.On("Fields").Return([]string{"id", "name", "created_at", "created_by", "address_city"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, "name", now(), "admin", "city").Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Nil(t, repo.Find(context.TODO(), &place, Eq("id", 1)))
+	assert.Equal(t, Place{
+		BaseModel: BaseModel{ID: 1, Timestamps: Timestamps{CreatedAt: now()}},
+		Audit:     &Audit{CreatedBy: "admin"},
+		Name:      "name",
+		Address:   Location{City: "city"},
+	}, place)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Insert_onConflict(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			ID:   1,
+			Name: "name",
+		}
+		onConflict = OnConflictKeys("name").DoReplace()
+		mutates    = map[string]Mutate{
+			"id":         Set("id", 1),
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("users"), mutates, onConflict).Return(2, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, onConflict))
+	assert.Equal(t, User{
+		ID:        2,
+		Name:      "name",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}, user)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_reload(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+		cur = createCursor(1)
+	)
+
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Query", From("users").Where(Eq("id", 1)).Unscoped().Cascade(false).Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, NewStructset(&user, false), Reload(true)))
+	assert.Equal(t, 10, user.ID)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Insert_reloadError(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		cur = &testCursor{}
+		err = errors.New("error")
+	)
+
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Query", From("users").Where(Eq("id", 1)).Unscoped().Cascade(false).Limit(1)).Return(cur, err).Once()
+
+	assert.Equal(t, err, repo.Insert(context.TODO(), &user, NewStructset(&user, false), Reload(true)))
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Insert_reloadReturning(t *testing.T) {
+	var (
+		adapter = &testReturningAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+		cur = createCursor(1)
+	)
+
+	adapter.On("InsertReturning", From("users"), mutates, OnConflict{}).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, NewStructset(&user, false), Reload(true)))
+	assert.Equal(t, 10, user.ID)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Insert_reloadReturningError(t *testing.T) {
+	var (
+		adapter = &testReturningAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		cur = &testCursor{}
+		err = errors.New("error")
+	)
+
+	adapter.On("InsertReturning", From("users"), mock.Anything, OnConflict{}).Return(cur, err).Once()
+
+	assert.Equal(t, err, repo.Insert(context.TODO(), &user, NewStructset(&user, false), Reload(true)))
+	assert.Equal(t, 0, user.ID)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Insert_reloadReturningNotSupported(t *testing.T) {
+	var (
+		adapter = &testReturningAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		cur = createCursor(1)
+	)
+
+	adapter.On("InsertReturning", From("users"), mock.Anything, OnConflict{}).Return((*testCursor)(nil), ErrNotSupported).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Query", From("users").Where(Eq("id", 1)).Unscoped().Cascade(false).Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, NewStructset(&user, false), Reload(true)))
+	assert.Equal(t, 10, user.ID)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Insert_onConflictIgnored(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			ID:   1,
+			Name: "name",
+		}
+		onConflict = OnConflictIgnore()
+	)
+
+	adapter.On("Insert", From("users"), mock.Anything, onConflict).Return(nil, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, onConflict))
+	assert.Equal(t, 1, user.ID)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_compositePrimaryFields(t *testing.T) {
+	var (
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		userRole = UserRole{
+			UserID: 1,
+			RoleID: 2,
+		}
+		mutates = map[string]Mutate{
+			"user_id": Set("user_id", 1),
+			"role_id": Set("role_id", 2),
+		}
+	)
+
+	adapter.On("Insert", From("user_roles"), mutates, OnConflict{}).Return(0, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &userRole))
+	assert.Equal(t, UserRole{
+		UserID: 1,
+		RoleID: 2,
+	}, userRole)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_sets(t *testing.T) {
+	var (
+		user     User
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		mutators = []Mutator{
+			Set("name", "name"),
+			Set("created_at", now()),
+			Set("updated_at", now()),
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, mutators...))
+	assert.Equal(t, User{
+		ID:        1,
+		Name:      "name",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}, user)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_saveBelongsTo(t *testing.T) {
 	var (
 		userID    = 1
 		profileID = 2
@@ -741,8 +1460,8 @@ func TestRepository_Insert_saveBelongsTo(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
-	adapter.On("Insert", From("profiles"), mock.Anything).Return(profileID, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
+	adapter.On("Insert", From("profiles"), mock.Anything, OnConflict{}).Return(profileID, nil).Once()
 	adapter.On("Commit").Return(nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &profile))
@@ -772,7 +1491,7 @@ func TestRepository_Insert_saveBelongsToCascadeDisabled(t *testing.T) {
 		addressID = 2
 	)
 
-	adapter.On("Insert", From("profiles"), mock.Anything).Return(addressID, nil).Once()
+	adapter.On("Insert", From("profiles"), mock.Anything, OnConflict{}).Return(addressID, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &profile, Cascade(false)))
 	assert.Equal(t, Profile{
@@ -796,7 +1515,7 @@ func TestRepository_Insert_saveBelongsToError(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(0, err).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(0, err).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
 	assert.Equal(t, err, repo.Insert(context.TODO(), &profile))
@@ -804,6 +1523,62 @@ func TestRepository_Insert_saveBelongsToError(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Insert_validation(t *testing.T) {
+	var (
+		user    = User{Name: "name"}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		valid   = ValidatorFunc(func(ctx context.Context, repo Repository, doc *Document, field string) (string, error) {
+			return "", nil
+		})
+		invalid = ValidatorFunc(func(ctx context.Context, repo Repository, doc *Document, field string) (string, error) {
+			return "is invalid", nil
+		})
+	)
+
+	assert.Equal(t, ValidationError{"age": {"is invalid"}}, repo.Insert(context.TODO(), &user,
+		Validate("name", valid),
+		Validate("age", valid, invalid),
+	))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_validationError(t *testing.T) {
+	var (
+		user    = User{Name: "name"}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		err     = errors.New("error")
+		failing = ValidatorFunc(func(ctx context.Context, repo Repository, doc *Document, field string) (string, error) {
+			return "", err
+		})
+	)
+
+	assert.Equal(t, err, repo.Insert(context.TODO(), &user, Validate("name", failing)))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_InsertAll_validation(t *testing.T) {
+	var (
+		users   = []User{{Name: "name"}, {}}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		present = ValidatorFunc(func(ctx context.Context, repo Repository, doc *Document, field string) (string, error) {
+			if value, _ := doc.Value(field); value == "" {
+				return "is required", nil
+			}
+
+			return "", nil
+		})
+	)
+
+	assert.Equal(t, ValidationError{"name": {"is required"}}, repo.InsertAll(context.TODO(), &users, Validate("name", present)))
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Insert_saveHasOne(t *testing.T) {
 	var (
 		userID    = 1
@@ -819,8 +1594,8 @@ func TestRepository_Insert_saveHasOne(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
-	adapter.On("Insert", From("addresses"), mock.Anything).Return(addressID, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
+	adapter.On("Insert", From("addresses"), mock.Anything, OnConflict{}).Return(addressID, nil).Once()
 	adapter.On("Commit").Return(nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user))
@@ -852,7 +1627,7 @@ func TestRepository_Insert_saveHasOneCascadeDisabled(t *testing.T) {
 		repo    = New(adapter)
 	)
 
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user, Cascade(false)))
 	assert.Equal(t, User{
@@ -883,42 +1658,179 @@ func TestRepository_Insert_saveHasOneError(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
-	adapter.On("Insert", From("addresses"), mock.Anything).Return(0, err).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
+	adapter.On("Insert", From("addresses"), mock.Anything, OnConflict{}).Return(0, err).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
-	assert.Equal(t, err, repo.Insert(context.TODO(), &user))
+	assert.Equal(t, err, repo.Insert(context.TODO(), &user))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_saveHasMany(t *testing.T) {
+	var (
+		user = User{
+			Name: "name",
+			UserRoles: []UserRole{
+				{RoleID: 2},
+			},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("user_roles"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}(nil), nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user))
+	assert.Equal(t, User{
+		ID:        1,
+		Name:      "name",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+		UserRoles: []UserRole{
+			{UserID: 1, RoleID: 2},
+		},
+	}, user)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_savePolymorphic(t *testing.T) {
+	var (
+		post = Post{
+			Title:    "title",
+			Comments: []Comment{{Body: "comment"}},
+			Cover:    &Image{URL: "cover.png"},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("posts"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Insert", From("images"), mock.Anything, OnConflict{}).Return(2, nil).Once()
+	adapter.On("InsertAll", From("comments"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{3}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &post))
+	assert.Equal(t, Post{
+		ID:       1,
+		Title:    "title",
+		Comments: []Comment{{ID: 3, Body: "comment", OwnerID: 1, OwnerType: "posts"}},
+		Cover:    &Image{ID: 2, URL: "cover.png", OwnerID: 1, OwnerType: "posts"},
+	}, post)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_savePolymorphicBelongsTo(t *testing.T) {
+	var (
+		comment = Comment{
+			Body: "comment",
+			Post: &Post{Title: "title"},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("posts"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Insert", From("comments"), map[string]Mutate{
+		"body":       Set("body", "comment"),
+		"owner_id":   Set("owner_id", 1),
+		"owner_type": Set("owner_type", "posts"),
+	}, OnConflict{}).Return(2, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &comment))
+	assert.Equal(t, 2, comment.ID)
+	assert.Equal(t, 1, comment.OwnerID)
+	assert.Equal(t, "posts", comment.OwnerType)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_savePolymorphicBelongsToInconsistentType(t *testing.T) {
+	var (
+		comment = Comment{
+			ID:        2,
+			OwnerID:   1,
+			OwnerType: "video",
+			Post:      &Post{ID: 1},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, ConstraintError{
+		Key:  "owner_type",
+		Type: ForeignKeyConstraint,
+		Err:  errors.New("rel: inconsistent polymorphic belongs to type"),
+	}, repo.Update(context.TODO(), &comment, NewStructset(&comment, false)))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_saveManyToMany(t *testing.T) {
+	var (
+		article = Article{
+			Title: "title",
+			Tags: []Tag{
+				{Name: "new"},
+				{ID: 5, Name: "existing"},
+			},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("articles"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("tags"), mock.Anything, []map[string]Mutate{{"name": Set("name", "new")}}, OnConflict{}).Return([]interface{}{6}, nil).Once()
+	adapter.On("InsertAll", From("article_tags"), []string{"article_id", "tag_id"}, []map[string]Mutate{
+		{"article_id": Set("article_id", 1), "tag_id": Set("tag_id", 5)},
+		{"article_id": Set("article_id", 1), "tag_id": Set("tag_id", 6)},
+	}, OnConflict{}).Return([]interface{}(nil), nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &article))
+	assert.Equal(t, Article{
+		ID:    1,
+		Title: "title",
+		Tags: []Tag{
+			{ID: 5, Name: "existing"},
+			{ID: 6, Name: "new"},
+		},
+	}, article)
 
 	adapter.AssertExpectations(t)
 }
 
-func TestRepository_Insert_saveHasMany(t *testing.T) {
+func TestRepository_Insert_saveManyToManyError(t *testing.T) {
 	var (
-		user = User{
-			Name: "name",
-			UserRoles: []UserRole{
-				{RoleID: 2},
+		article = Article{
+			Title: "title",
+			Tags: []Tag{
+				{ID: 5, Name: "existing"},
 			},
 		}
 		adapter = &testAdapter{}
 		repo    = New(adapter)
+		err     = errors.New("error")
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, nil).Once()
-	adapter.On("InsertAll", From("user_roles"), mock.Anything, mock.Anything).Return([]interface{}(nil), nil).Once()
-	adapter.On("Commit").Return(nil).Once()
+	adapter.On("Insert", From("articles"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("article_tags"), []string{"article_id", "tag_id"}, mock.Anything, OnConflict{}).Return([]interface{}(nil), err).Once()
+	adapter.On("Rollback").Return(nil).Once()
 
-	assert.Nil(t, repo.Insert(context.TODO(), &user))
-	assert.Equal(t, User{
-		ID:        1,
-		Name:      "name",
-		CreatedAt: now(),
-		UpdatedAt: now(),
-		UserRoles: []UserRole{
-			{UserID: 1, RoleID: 2},
-		},
-	}, user)
+	assert.Equal(t, err, repo.Insert(context.TODO(), &article))
 
 	adapter.AssertExpectations(t)
 }
@@ -935,7 +1847,7 @@ func TestRepository_Insert_saveHasManyCascadeDisabled(t *testing.T) {
 		repo    = New(adapter)
 	)
 
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user, Cascade(false)))
 	assert.Equal(t, User{
@@ -965,8 +1877,8 @@ func TestRepository_Insert_saveHasManyError(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, nil).Once()
-	adapter.On("InsertAll", From("user_roles"), mock.Anything, mock.Anything).Return([]interface{}{}, err).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("user_roles"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{}, err).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
 	assert.Equal(t, err, repo.Insert(context.TODO(), &user))
@@ -992,7 +1904,7 @@ func TestRepository_Insert_error(t *testing.T) {
 		err = errors.New("error")
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(0, err).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(0, err).Once()
 
 	assert.Equal(t, err, repo.Insert(context.TODO(), &user, mutators...))
 	assert.Panics(t, func() { repo.MustInsert(context.TODO(), &user, mutators...) })
@@ -1014,7 +1926,7 @@ func TestRepository_Insert_customError(t *testing.T) {
 		}
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(0, errors.New("error")).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(0, errors.New("error")).Once()
 
 	assert.Equal(t, errors.New("custom error"), repo.Insert(context.TODO(), &user, mutators...))
 	assert.Panics(t, func() { repo.MustInsert(context.TODO(), &user, mutators...) })
@@ -1035,7 +1947,7 @@ func TestRepository_Insert_customErrorNested(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, errors.New("error")).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, errors.New("error")).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
 	assert.Equal(t, errors.New("error"), repo.Insert(context.TODO(), &profile,
@@ -1081,7 +1993,7 @@ func TestRepository_InsertAll(t *testing.T) {
 		}
 	)
 
-	adapter.On("InsertAll", From("users"), mock.Anything, mutates).Return([]interface{}{1, 2}, nil).Once()
+	adapter.On("InsertAll", From("users"), mock.Anything, mutates, OnConflict{}).Return([]interface{}{1, 2}, nil).Once()
 
 	assert.Nil(t, repo.InsertAll(context.TODO(), &users))
 	assert.Equal(t, []User{
@@ -1092,6 +2004,181 @@ func TestRepository_InsertAll(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_InsertAll_reload(t *testing.T) {
+	var (
+		users = []User{
+			{Name: "name1"},
+			{Name: "name2", Age: 12},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(2, "reloaded2").Once()
+	cur.MockScan(1, "reloaded1").Once()
+	cur.On("Next").Return(false).Once()
+
+	adapter.On("InsertAll", From("users"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{1, 2}, nil).Once()
+	adapter.On("Query", From("users").Where(In("id", 1, 2)).Unscoped().Cascade(false)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &users, Reload(true)))
+	assert.Equal(t, 1, users[0].ID)
+	assert.Equal(t, "reloaded1", users[0].Name)
+	assert.Equal(t, 2, users[1].ID)
+	assert.Equal(t, "reloaded2", users[1].Name)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_InsertAll_reloadReturningNotSupported(t *testing.T) {
+	var (
+		users = []User{
+			{Name: "name1"},
+			{Name: "name2", Age: 12},
+		}
+		adapter = &testReturningAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(1, "reloaded1").Once()
+	cur.MockScan(2, "reloaded2").Once()
+	cur.On("Next").Return(false).Once()
+
+	adapter.On("InsertAllReturning", From("users"), mock.Anything, mock.Anything, OnConflict{}).Return((*testCursor)(nil), ErrNotSupported).Once()
+	adapter.On("InsertAll", From("users"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{1, 2}, nil).Once()
+	adapter.On("Query", From("users").Where(In("id", 1, 2)).Unscoped().Cascade(false)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &users, Reload(true)))
+	assert.Equal(t, 1, users[0].ID)
+	assert.Equal(t, "reloaded1", users[0].Name)
+	assert.Equal(t, 2, users[1].ID)
+	assert.Equal(t, "reloaded2", users[1].Name)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_InsertAll_reloadReturning(t *testing.T) {
+	var (
+		users = []User{
+			{Name: "name1"},
+			{Name: "name2", Age: 12},
+		}
+		adapter = &testReturningAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(1, "reloaded1").Once()
+	cur.MockScan(2, "reloaded2").Once()
+
+	adapter.On("InsertAllReturning", From("users"), mock.Anything, mock.Anything, OnConflict{}).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &users, Reload(true)))
+	assert.Equal(t, []User{
+		{ID: 1, Name: "reloaded1", Age: 0, CreatedAt: now(), UpdatedAt: now()},
+		{ID: 2, Name: "reloaded2", Age: 12, CreatedAt: now(), UpdatedAt: now()},
+	}, users)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_InsertAll_reloadReturningOnConflict(t *testing.T) {
+	var (
+		users = []User{
+			{Name: "name1"},
+			{Name: "name2", Age: 12},
+		}
+		adapter    = &testReturningAdapter{}
+		repo       = New(adapter)
+		cur        = &testCursor{}
+		onConflict = OnConflictReplace("name")
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(2, "name2").Once()
+	cur.MockScan(1, "name1").Once()
+	cur.On("Next").Return(false).Once()
+
+	adapter.On("InsertAllReturning", From("users"), mock.Anything, mock.Anything, onConflict).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &users, Reload(true), onConflict))
+	assert.Equal(t, []User{
+		{ID: 1, Name: "name1", Age: 0, CreatedAt: now(), UpdatedAt: now()},
+		{ID: 2, Name: "name2", Age: 12, CreatedAt: now(), UpdatedAt: now()},
+	}, users)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_InsertAll_reloadTypedPrimary(t *testing.T) {
+	var (
+		items = []struct {
+			ID   string
+			Name string
+		}{
+			{ID: "1"},
+			{ID: "2"},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan("2", "reloaded2").Once()
+	cur.MockScan("1", "reloaded1").Once()
+	cur.On("Next").Return(false).Once()
+
+	adapter.On("InsertAll", From(""), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{nil, nil}, nil).Once()
+	adapter.On("Query", mock.Anything).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &items, Reload(true)))
+	assert.Equal(t, "reloaded1", items[0].Name)
+	assert.Equal(t, "reloaded2", items[1].Name)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_InsertAll_onConflict(t *testing.T) {
+	var (
+		users = []User{
+			{Name: "name1"},
+			{Name: "name2", Age: 12},
+		}
+		adapter    = &testAdapter{}
+		repo       = New(adapter)
+		onConflict = OnConflictKeys("name").DoUpdate(Inc("age"))
+	)
+
+	adapter.On("InsertAll", From("users"), mock.Anything, mock.Anything, onConflict).Return([]interface{}{1, nil}, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &users, onConflict))
+	assert.Equal(t, 1, users[0].ID)
+	assert.Equal(t, 0, users[1].ID)
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_InsertAll_compositePrimaryFields(t *testing.T) {
 	var (
 		userRoles = []UserRole{
@@ -1112,7 +2199,7 @@ func TestRepository_InsertAll_compositePrimaryFields(t *testing.T) {
 		}
 	)
 
-	adapter.On("InsertAll", From("user_roles"), mock.Anything, mutates).Return([]interface{}{0, 0}, nil).Once()
+	adapter.On("InsertAll", From("user_roles"), mock.Anything, mutates, OnConflict{}).Return([]interface{}{0, 0}, nil).Once()
 
 	assert.Nil(t, repo.InsertAll(context.TODO(), &userRoles))
 	assert.Equal(t, []UserRole{
@@ -1288,30 +2375,178 @@ func TestRepository_Update_softDeleteUnscoped(t *testing.T) {
 func TestRepository_Update_notFound(t *testing.T) {
 	var (
 		user     = User{ID: 1}
-		adapter  = &testAdapter{}
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		mutators = []Mutator{
+			Set("name", "name"),
+			Set("updated_at", now()),
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"updated_at": Set("updated_at", now()),
+		}
+		queries = From("users").Where(Eq("id", user.ID))
+	)
+
+	adapter.On("Update", queries, mutates).Return(0, nil).Once()
+
+	assert.Equal(t, NotFoundError{}, repo.Update(context.TODO(), &user, mutators...))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_lockVersion(t *testing.T) {
+	var (
+		invoice = Invoice{ID: 1, Title: "title", LockVersion: 2}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		mutates = map[string]Mutate{
+			"title":        Set("title", "new title"),
+			"lock_version": Set("lock_version", 3),
+		}
+		queries = From("invoices").Where(Eq("id", 1).AndEq("lock_version", 2))
+	)
+
+	adapter.On("Update", queries, mutates).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &invoice, Set("title", "new title")))
+	assert.Equal(t, Invoice{ID: 1, Title: "new title", LockVersion: 3}, invoice)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_staleRecord(t *testing.T) {
+	var (
+		invoice = Invoice{ID: 1, Title: "title", LockVersion: 2}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		queries = From("invoices").Where(Eq("id", 1).AndEq("lock_version", 2))
+	)
+
+	adapter.On("Update", queries, mock.Anything).Return(0, nil).Once()
+
+	assert.Equal(t, StaleRecordError{}, repo.Update(context.TODO(), &invoice, Set("title", "new title")))
+	assert.Equal(t, 2, invoice.LockVersion)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_staleRecordReturning(t *testing.T) {
+	var (
+		invoice = Invoice{ID: 1, Title: "title", LockVersion: 2}
+		adapter = &testReturningAdapter{}
+		repo    = New(adapter)
+		queries = From("invoices").Where(Eq("id", 1).AndEq("lock_version", 2))
+		cur     = &testCursor{}
+	)
+
+	adapter.On("UpdateReturning", queries, mock.Anything).Return(cur, nil).Once()
+	cur.On("Fields").Return([]string{"id", "title", "lock_version"}, nil).Once()
+	cur.On("Next").Return(false).Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Equal(t, StaleRecordError{}, repo.Update(context.TODO(), &invoice, Set("title", "new title"), Reload(true)))
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Update_validation(t *testing.T) {
+	var (
+		user      = User{ID: 1, Name: "name", Age: 10}
+		changeset = NewChangeset(&user)
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		invalid   = ValidatorFunc(func(ctx context.Context, repo Repository, doc *Document, field string) (string, error) {
+			return "is invalid", nil
+		})
+	)
+
+	user.Age = 200
+
+	assert.Equal(t, ValidationError{"age": {"is invalid"}}, repo.Update(context.TODO(), &user,
+		changeset,
+		changeset.Validate("name", invalid),
+		changeset.Validate("age", invalid),
+	))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_reload(t *testing.T) {
+	var (
+		user     = User{ID: 1}
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		mutators = []Mutator{
+			SetFragment("name=?", "name"),
+		}
+		mutates = map[string]Mutate{
+			"name=?": SetFragment("name=?", "name"),
+		}
+		queries = From("users").Where(Eq("id", user.ID))
+		cur     = createCursor(1)
+	)
+
+	adapter.On("Update", queries, mutates).Return(1, nil).Once()
+	adapter.On("Query", queries.Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &user, mutators...))
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Update_reloadReturning(t *testing.T) {
+	var (
+		user     = User{ID: 1}
+		adapter  = &testReturningAdapter{}
+		repo     = New(adapter)
+		mutators = []Mutator{
+			SetFragment("name=?", "name"),
+		}
+		mutates = map[string]Mutate{
+			"name=?": SetFragment("name=?", "name"),
+		}
+		queries = From("users").Where(Eq("id", user.ID))
+		cur     = createCursor(1)
+	)
+
+	adapter.On("UpdateReturning", queries, mutates).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &user, mutators...))
+	assert.Equal(t, 10, user.ID)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Update_reloadReturningNotFound(t *testing.T) {
+	var (
+		user     = User{ID: 1}
+		adapter  = &testReturningAdapter{}
 		repo     = New(adapter)
 		mutators = []Mutator{
-			Set("name", "name"),
-			Set("updated_at", now()),
-		}
-		mutates = map[string]Mutate{
-			"name":       Set("name", "name"),
-			"updated_at": Set("updated_at", now()),
+			SetFragment("name=?", "name"),
 		}
 		queries = From("users").Where(Eq("id", user.ID))
+		cur     = createCursor(0)
 	)
 
-	adapter.On("Update", queries, mutates).Return(0, nil).Once()
+	adapter.On("UpdateReturning", queries, mock.Anything).Return(cur, nil).Once()
 
 	assert.Equal(t, NotFoundError{}, repo.Update(context.TODO(), &user, mutators...))
 
 	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
 }
 
-func TestRepository_Update_reload(t *testing.T) {
+func TestRepository_Update_reloadReturningNotSupported(t *testing.T) {
 	var (
 		user     = User{ID: 1}
-		adapter  = &testAdapter{}
+		adapter  = &testReturningAdapter{}
 		repo     = New(adapter)
 		mutators = []Mutator{
 			SetFragment("name=?", "name"),
@@ -1323,6 +2558,7 @@ func TestRepository_Update_reload(t *testing.T) {
 		cur     = createCursor(1)
 	)
 
+	adapter.On("UpdateReturning", queries, mutates).Return((*testCursor)(nil), ErrNotSupported).Once()
 	adapter.On("Update", queries, mutates).Return(1, nil).Once()
 	adapter.On("Query", queries.Limit(1)).Return(cur, nil).Once()
 
@@ -1561,7 +2797,7 @@ func TestRepository_Update_saveHasMany(t *testing.T) {
 	adapter.On("Begin").Return(nil).Once()
 	adapter.On("Update", From("users").Where(Eq("id", 10)), mock.Anything).Return(1, nil).Once()
 	adapter.On("Delete", From("user_roles").Where(Eq("user_id", 10))).Return(1, nil).Once()
-	adapter.On("InsertAll", From("user_roles"), mock.Anything, mock.Anything).Return([]interface{}(nil), nil).Once()
+	adapter.On("InsertAll", From("user_roles"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}(nil), nil).Once()
 	adapter.On("Commit").Return(nil).Once()
 
 	assert.Nil(t, repo.Update(context.TODO(), &user))
@@ -1577,6 +2813,177 @@ func TestRepository_Update_saveHasMany(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Update_saveManyToMany(t *testing.T) {
+	var (
+		article = Article{
+			ID: 1,
+			Tags: []Tag{
+				{ID: 5, Name: "existing"},
+			},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Update", From("articles").Where(Eq("id", 1)), mock.Anything).Return(1, nil).Once()
+	adapter.On("Delete", From("article_tags").Where(Eq("article_id", 1))).Return(1, nil).Once()
+	adapter.On("InsertAll", From("article_tags"), []string{"article_id", "tag_id"}, []map[string]Mutate{
+		{"article_id": Set("article_id", 1), "tag_id": Set("tag_id", 5)},
+	}, OnConflict{}).Return([]interface{}(nil), nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &article))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_saveManyToManyChangeset(t *testing.T) {
+	var (
+		article = Article{
+			ID: 1,
+			Tags: []Tag{
+				{ID: 5, Name: "a"},
+				{ID: 6, Name: "b"},
+			},
+		}
+		changeset = NewChangeset(&article)
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		cur       = &testCursor{}
+	)
+
+	article.Tags[0].Name = "a2"
+	article.Tags[1] = Tag{Name: "c"}
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(5).Once()
+	cur.On("Next").Return(false).Once()
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("article_tags").Where(Eq("article_id", 1).AndIn("tag_id", 6))).Return(1, nil).Once()
+	adapter.On("Query", Build("article_tags", Select("tag_id"), Eq("article_id", 1))).Return(cur, nil).Once()
+	adapter.On("Update", From("tags").Where(Eq("id", 5)), map[string]Mutate{"name": Set("name", "a2")}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("tags"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{7}, nil).Once()
+	adapter.On("InsertAll", From("article_tags"), []string{"article_id", "tag_id"}, []map[string]Mutate{
+		{"article_id": Set("article_id", 1), "tag_id": Set("tag_id", 7)},
+	}, OnConflict{}).Return([]interface{}(nil), nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &article, changeset))
+	assert.Len(t, article.Tags, 2)
+	assert.Equal(t, 5, article.Tags[0].ID)
+	assert.Equal(t, "a2", article.Tags[0].Name)
+	assert.Equal(t, 7, article.Tags[1].ID)
+	assert.Equal(t, "c", article.Tags[1].Name)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+type CodedTag struct {
+	ID   int
+	Code string
+}
+
+type CodedArticle struct {
+	ID   int
+	Tags []CodedTag `many2many:"article_coded_tags" fk:"code" join_ref:"article_id" join_fk:"tag_code" autosave:"true"`
+}
+
+func TestRepository_Update_saveManyToManyChangesetForeignKey(t *testing.T) {
+	var (
+		article = CodedArticle{
+			ID: 1,
+			Tags: []CodedTag{
+				{ID: 5, Code: "a"},
+				{ID: 6, Code: "b"},
+			},
+		}
+		changeset = NewChangeset(&article)
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		cur       = &testCursor{}
+	)
+
+	article.Tags = article.Tags[:1]
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan("a").Once()
+	cur.On("Next").Return(false).Once()
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("article_coded_tags").Where(Eq("article_id", 1).AndIn("tag_code", "b"))).Return(1, nil).Once()
+	adapter.On("Query", Build("article_coded_tags", Select("tag_code"), Eq("article_id", 1))).Return(cur, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &article, changeset))
+	assert.Equal(t, []CodedTag{{ID: 5, Code: "a"}}, article.Tags)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Update_saveManyToManyMapForeignKey(t *testing.T) {
+	var (
+		article = CodedArticle{
+			ID: 1,
+			Tags: []CodedTag{
+				{ID: 5, Code: "a"},
+				{ID: 6, Code: "b"},
+			},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+		mutator = Map{"tags": []Map{{"id": 5, "code": "a"}}}
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan("a").Once()
+	cur.On("Next").Return(false).Once()
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("article_coded_tags").Where(Eq("article_id", 1).AndIn("tag_code", "b"))).Return(1, nil).Once()
+	adapter.On("Query", Build("article_coded_tags", Select("tag_code"), Eq("article_id", 1))).Return(cur, nil).Once()
+	adapter.On("Update", From("coded_tags").Where(Eq("id", 5)), map[string]Mutate{"code": Set("code", "a")}).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &article, mutator))
+	assert.Equal(t, []CodedTag{{ID: 5, Code: "a"}}, article.Tags)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Update_saveManyToManyQueryError(t *testing.T) {
+	var (
+		article = Article{
+			ID: 1,
+			Tags: []Tag{
+				{ID: 5, Name: "a"},
+			},
+		}
+		changeset = NewChangeset(&article)
+		adapter   = &testAdapter{}
+		repo      = New(adapter)
+		err       = errors.New("error")
+	)
+
+	article.Tags = append(article.Tags, Tag{Name: "b"})
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Query", Build("article_tags", Select("tag_id"), Eq("article_id", 1))).Return(&testCursor{}, err).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Update(context.TODO(), &article, changeset))
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Update_saveHasManyCascadeDisabled(t *testing.T) {
 	var (
 		user = User{
@@ -1785,7 +3192,7 @@ func TestRepository_saveBelongsTo_insertNew(t *testing.T) {
 		q = Build("users")
 	)
 
-	adapter.On("Insert", q, mutates).Return(1, nil).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(1, nil).Once()
 
 	assert.Nil(t, repo.(*repository).saveBelongsTo(cw, doc, &mutation))
 	assert.Equal(t, Set("user_id", 1), mutation.Mutates["user_id"])
@@ -1827,7 +3234,7 @@ func TestRepository_saveBelongsTo_insertNewError(t *testing.T) {
 		q = Build("users")
 	)
 
-	adapter.On("Insert", q, mutates).Return(0, errors.New("insert error")).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(0, errors.New("insert error")).Once()
 
 	assert.Equal(t, errors.New("insert error"), repo.(*repository).saveBelongsTo(cw, doc, &mutation))
 	assert.Zero(t, mutation.Mutates["user_id"])
@@ -1953,7 +3360,7 @@ func TestRepository_saveHasOne_insertNew(t *testing.T) {
 		q = Build("addresses")
 	)
 
-	adapter.On("Insert", q, mutates).Return(2, nil).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(2, nil).Once()
 
 	assert.Nil(t, repo.(*repository).saveHasOne(cw, doc, &mutation))
 	assert.Equal(t, User{
@@ -1989,7 +3396,7 @@ func TestRepository_saveHasOne_insertNewError(t *testing.T) {
 		q = Build("addresses")
 	)
 
-	adapter.On("Insert", q, mutates).Return(nil, errors.New("insert error")).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(nil, errors.New("insert error")).Once()
 
 	assert.Equal(t, errors.New("insert error"), repo.(*repository).saveHasOne(cw, doc, &mutation))
 
@@ -2018,8 +3425,8 @@ func TestRepository_saveHasMany_insert(t *testing.T) {
 		q = Build("emails")
 	)
 
-	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates).Return([]interface{}{2, 3}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates).Return([]interface{}{2, 3}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates, OnConflict{}).Return([]interface{}{2, 3}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates, OnConflict{}).Return([]interface{}{2, 3}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, true))
 	assert.Equal(t, User{
@@ -2056,8 +3463,8 @@ func TestRepository_saveHasMany_insertError(t *testing.T) {
 		err = errors.New("insert all error")
 	)
 
-	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates).Return([]interface{}{}, err).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates).Return([]interface{}{}, err).Maybe()
+	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates, OnConflict{}).Return([]interface{}{}, err).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates, OnConflict{}).Return([]interface{}{}, err).Maybe()
 
 	assert.Equal(t, err, repo.(*repository).saveHasMany(cw, doc, &mutation, true))
 
@@ -2206,8 +3613,8 @@ func TestRepository_saveHasMany_updateWithInsert(t *testing.T) {
 	)
 
 	adapter.On("Update", q.Where(Eq("id", 1).AndEq("user_id", 1)), mutates[0]).Return(1, nil).Once()
-	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates[1:]).Return([]interface{}{2}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates[1:]).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates[1:], OnConflict{}).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates[1:], OnConflict{}).Return([]interface{}{2}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2250,8 +3657,8 @@ func TestRepository_saveHasMany_updateWithReorderInsert(t *testing.T) {
 	mutation.SetDeletedIDs("emails", []interface{}{})
 
 	adapter.On("Update", q.Where(Eq("id", 1).AndEq("user_id", 1)), mutates[0]).Return(1, nil).Once()
-	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates[1:]).Return([]interface{}{2}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates[1:]).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates[1:], OnConflict{}).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates[1:], OnConflict{}).Return([]interface{}{2}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2296,8 +3703,8 @@ func TestRepository_saveHasMany_deleteWithInsert(t *testing.T) {
 	)
 
 	adapter.On("Delete", q.Where(Eq("user_id", 1).AndIn("id", 1, 2))).Return(1, nil).Once()
-	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates).Return([]interface{}{3, 4, 5}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates).Return([]interface{}{3, 4, 5}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"email", "user_id"}, mutates, OnConflict{}).Return([]interface{}{3, 4, 5}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "email"}, mutates, OnConflict{}).Return([]interface{}{3, 4, 5}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2369,7 +3776,7 @@ func TestRepository_saveHasMany_replace(t *testing.T) {
 	)
 
 	adapter.On("Delete", q.Where(Eq("user_id", 1))).Return(1, nil).Once()
-	adapter.On("InsertAll", q, mock.Anything, mutates).Return([]interface{}{3, 4, 5}, nil).Once()
+	adapter.On("InsertAll", q, mock.Anything, mutates, OnConflict{}).Return([]interface{}{3, 4, 5}, nil).Once()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2484,6 +3891,34 @@ func TestRepository_Delete(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Delete_lockVersion(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		invoice = Invoice{ID: 1, LockVersion: 2}
+	)
+
+	adapter.On("Delete", From("invoices").Where(Eq("id", 1).AndEq("lock_version", 2))).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &invoice))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Delete_staleRecord(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		invoice = Invoice{ID: 1, LockVersion: 2}
+	)
+
+	adapter.On("Delete", From("invoices").Where(Eq("id", 1).AndEq("lock_version", 2))).Return(0, nil).Once()
+
+	assert.Equal(t, StaleRecordError{}, repo.Delete(context.TODO(), &invoice))
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Delete_compositePrimaryKey(t *testing.T) {
 	var (
 		adapter  = &testAdapter{}
@@ -2714,6 +4149,46 @@ func TestRepository_Delete_hasMany(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Delete_polymorphic(t *testing.T) {
+	var (
+		post = Post{
+			ID:       10,
+			Comments: []Comment{{ID: 1, OwnerID: 10, OwnerType: "posts"}},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("comments").Where(Eq("owner_id", 10).AndEq("owner_type", "posts").And(In("id", 1)))).Return(1, nil).Once()
+	adapter.On("Delete", From("posts").Where(Eq("id", 10))).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &post, Cascade(true)))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Delete_manyToMany(t *testing.T) {
+	var (
+		article = Article{
+			ID:   10,
+			Tags: []Tag{{ID: 1}},
+		}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("article_tags").Where(Eq("article_id", 10))).Return(1, nil).Once()
+	adapter.On("Delete", From("articles").Where(Eq("id", 10))).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &article, Cascade(true)))
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Delete_hasManyError(t *testing.T) {
 	var (
 		user = User{
@@ -2959,6 +4434,97 @@ func TestRepository_Preload_sliceHasMany(t *testing.T) {
 	cur.AssertExpectations(t)
 }
 
+func TestRepository_Preload_sliceManyToMany(t *testing.T) {
+	var (
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		articles = []Article{{ID: 1}, {ID: 2}}
+		tags     = []Tag{{ID: 10, Name: "a"}, {ID: 11, Name: "b"}}
+		query    = From("tags").
+				Select("tags.*", "article_tags.article_id AS rel_join_key").
+				JoinOn("article_tags", "article_tags.tag_id", "tags.id")
+		cur = &testCursor{}
+	)
+
+	adapter.On("Query", query.Where(In("article_tags.article_id", 1, 2))).Return(cur, nil).Maybe()
+	adapter.On("Query", query.Where(In("article_tags.article_id", 2, 1))).Return(cur, nil).Maybe()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name", "rel_join_key"}, nil).Once()
+	cur.On("Next").Return(true).Times(3)
+	cur.MockScan(tags[0].ID, tags[0].Name, 1).Twice()
+	cur.MockScan(tags[1].ID, tags[1].Name, 1).Twice()
+	cur.MockScan(tags[0].ID, tags[0].Name, 2).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &articles, "tags"))
+	assert.Equal(t, tags, articles[0].Tags)
+	assert.Equal(t, tags[:1], articles[1].Tags)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_slicePolymorphic(t *testing.T) {
+	var (
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		posts    = []Post{{ID: 1}, {ID: 2}}
+		comments = []Comment{
+			{ID: 10, OwnerID: 1, OwnerType: "posts"},
+			{ID: 11, OwnerID: 2, OwnerType: "posts"},
+		}
+		cur = &testCursor{}
+	)
+
+	adapter.On("Query", From("comments").Where(In("owner_id", 1, 2)).Where(Eq("owner_type", "posts"))).Return(cur, nil).Maybe()
+	adapter.On("Query", From("comments").Where(In("owner_id", 2, 1)).Where(Eq("owner_type", "posts"))).Return(cur, nil).Maybe()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "owner_id", "owner_type"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(comments[0].ID, comments[0].OwnerID, comments[0].OwnerType).Twice()
+	cur.MockScan(comments[1].ID, comments[1].OwnerID, comments[1].OwnerType).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &posts, "comments"))
+	assert.Equal(t, comments[:1], posts[0].Comments)
+	assert.Equal(t, comments[1:], posts[1].Comments)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_slicePolymorphicBelongsTo(t *testing.T) {
+	var (
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		comments = []Comment{
+			{ID: 1, OwnerID: 10, OwnerType: "posts"},
+			{ID: 2, OwnerID: 20, OwnerType: "video"},
+			{ID: 3, OwnerID: 10, OwnerType: "posts"},
+		}
+		post = Post{ID: 10, Title: "title"}
+		cur  = &testCursor{}
+	)
+
+	adapter.On("Query", From("posts").Where(In("id", 10))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "title"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(post.ID, post.Title).Times(3)
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &comments, "post"))
+	assert.Equal(t, &post, comments[0].Post)
+	assert.Nil(t, comments[1].Post)
+	assert.Equal(t, &post, comments[2].Post)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
 func TestRepository_Preload_nestedHasMany(t *testing.T) {
 	var (
 		adapter      = &testAdapter{}
@@ -3213,11 +4779,18 @@ func TestRepository_Preload_sliceNestedBelongsTo(t *testing.T) {
 			{ID: 10, Name: "Del Piero", Transactions: transactions},
 			{ID: 20, Name: "Nedved"},
 		}
-		cur = &testCursor{}
+		cur    = &testCursor{}
+		curTrx = &testCursor{}
 	)
 
+	// transactions of the second user is not loaded yet, it's loaded before the address.
+	adapter.On("Query", From("transactions").Where(In("user_id", 20))).Return(curTrx, nil).Once()
 	adapter.On("Query", From("addresses").Where(In("id", 10).AndNil("deleted_at"))).Return(cur, nil).Maybe()
 
+	curTrx.On("Close").Return(nil).Once()
+	curTrx.On("Fields").Return([]string{"id", "user_id"}, nil).Once()
+	curTrx.On("Next").Return(false).Once()
+
 	cur.On("Close").Return(nil).Once()
 	cur.On("Fields").Return([]string{"id", "street"}, nil).Once()
 	cur.On("Next").Return(true).Once()
@@ -3230,6 +4803,105 @@ func TestRepository_Preload_sliceNestedBelongsTo(t *testing.T) {
 	}, users[0].Transactions)
 	assert.Equal(t, []Transaction{}, users[1].Transactions)
 
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+	curTrx.AssertExpectations(t)
+}
+
+func TestRepository_Preload_nestedPreload(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		users   = []User{{ID: 10}}
+		address = Address{ID: 5, Street: "Continassa"}
+		curTrx  = &testCursor{}
+		curAddr = &testCursor{}
+	)
+
+	adapter.On("Query", From("transactions").Where(Eq("status", "paid"), In("user_id", 10)).SortAsc("id")).Return(curTrx, nil).Once()
+	adapter.On("Query", From("addresses").Where(In("id", 5).AndNil("deleted_at"))).Return(curAddr, nil).Once()
+
+	curTrx.On("Close").Return(nil).Once()
+	curTrx.On("Fields").Return([]string{"id", "user_id", "address_id"}, nil).Once()
+	curTrx.On("Next").Return(true).Once()
+	curTrx.MockScan(1, 10, 5).Twice()
+	curTrx.On("Next").Return(false).Once()
+
+	curAddr.On("Close").Return(nil).Once()
+	curAddr.On("Fields").Return([]string{"id", "street"}, nil).Once()
+	curAddr.On("Next").Return(true).Once()
+	curAddr.MockScan(address.ID, address.Street).Twice()
+	curAddr.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &users, "transactions",
+		Eq("status", "paid"), NewSortAsc("id"), NewNestedPreload("address")))
+	assert.Equal(t, []Transaction{
+		{ID: 1, BuyerID: 10, AddressID: 5, Address: address},
+	}, users[0].Transactions)
+
+	adapter.AssertExpectations(t)
+	curTrx.AssertExpectations(t)
+	curAddr.AssertExpectations(t)
+}
+
+func TestRepository_Preload_preloadLimit(t *testing.T) {
+	var (
+		adapter      = &testAdapter{}
+		repo         = New(adapter)
+		users        = []User{{ID: 10}}
+		transactions = []Transaction{
+			{ID: 20, BuyerID: 10},
+			{ID: 15, BuyerID: 10},
+		}
+		inner = From("transactions").Select("transactions.*").
+			SelectExpr(RowNumber().Over(PartitionBy("transactions.user_id").SortDesc("id")).As("rel_preload_rank")).
+			Where(In("user_id", 10))
+		query = From(inner).As("transactions").Where(Lte("rel_preload_rank", 2)).SortDesc("id")
+		cur   = &testCursor{}
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "user_id", "rel_preload_rank"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(transactions[0].ID, transactions[0].BuyerID, 1).Twice()
+	cur.MockScan(transactions[1].ID, transactions[1].BuyerID, 2).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &users, "transactions", PreloadLimit(2), NewSortDesc("id")))
+	assert.Equal(t, transactions, users[0].Transactions)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_preloadLimitManyToMany(t *testing.T) {
+	var (
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		articles = []Article{{ID: 1}}
+		tags     = []Tag{{ID: 10, Name: "a"}}
+		inner    = From("tags").
+				Select("tags.*", "article_tags.article_id AS rel_join_key").
+				SelectExpr(RowNumber().Over(PartitionBy("article_tags.article_id")).As("rel_preload_rank")).
+				JoinOn("article_tags", "article_tags.tag_id", "tags.id").
+				Where(In("article_tags.article_id", 1))
+		query = From(inner).As("tags").Where(Lte("rel_preload_rank", 1))
+		cur   = &testCursor{}
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name", "rel_join_key"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(tags[0].ID, tags[0].Name, 1).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &articles, "tags", PreloadLimit(1)))
+	assert.Equal(t, tags, articles[0].Tags)
+
 	adapter.AssertExpectations(t)
 	cur.AssertExpectations(t)
 }
@@ -1,6 +1,9 @@
 package rel
 
-import "strings"
+import (
+	"errors"
+	"strings"
+)
 
 // SchemaOp type.
 type SchemaOp uint8
@@ -117,6 +120,98 @@ func (s *Schema) Do(fn Do) {
 	s.add(fn)
 }
 
+// Reverse returns schema that reverts the migrations in reverse order.
+// IrreversibleError is returned when any of the migration can't be reverted, such as drop table, drop column without type, raw sql and go codes.
+func (s Schema) Reverse() (Schema, error) {
+	var (
+		reversed = Schema{Migrations: make([]Migration, 0, len(s.Migrations))}
+	)
+
+	for i := len(s.Migrations) - 1; i >= 0; i-- {
+		migration, err := reverseMigration(s.Migrations[i])
+		if err != nil {
+			return Schema{}, err
+		}
+
+		reversed.add(migration)
+	}
+
+	return reversed, nil
+}
+
+func reverseMigration(migration Migration) (Migration, error) {
+	switch v := migration.(type) {
+	case Table:
+		return reverseTable(v)
+	case Index:
+		if v.Op == SchemaCreate {
+			index := dropIndex(v.Table, v.Name, nil)
+			index.Optional = v.Optional
+			return index, nil
+		}
+	}
+
+	return nil, IrreversibleError{Migration: migration.description()}
+}
+
+func reverseTable(table Table) (Migration, error) {
+	switch table.Op {
+	case SchemaCreate:
+		if table.Optional {
+			return dropTableIfExists(table.Name, nil), nil
+		}
+
+		return dropTable(table.Name, nil), nil
+	case SchemaRename:
+		return renameTable(table.Rename, table.Name, nil), nil
+	case SchemaAlter:
+		reversed := alterTable(table.Name, nil)
+		reversed.Options = table.Options
+
+		for i := len(table.Definitions) - 1; i >= 0; i-- {
+			def, err := reverseTableDefinition(table.Definitions[i])
+			if err != nil {
+				return nil, IrreversibleError{Migration: table.description() + ": " + err.Error()}
+			}
+
+			reversed.Definitions = append(reversed.Definitions, def)
+		}
+
+		return reversed.Table, nil
+	}
+
+	return nil, IrreversibleError{Migration: table.description()}
+}
+
+func reverseTableDefinition(def TableDefinition) (TableDefinition, error) {
+	switch v := def.(type) {
+	case Column:
+		switch {
+		case v.Op == SchemaCreate:
+			return dropColumn(v.Name, nil), nil
+		case v.Op == SchemaRename:
+			return renameColumn(v.Rename, v.Name, nil), nil
+		case v.Op == SchemaDrop && v.Type != "":
+			v.Op = SchemaCreate
+			return v, nil
+		case v.Op == SchemaAlter && v.Alter == AlterColumnNull:
+			return alterColumn(v.Name, AlterColumnNotNull, nil), nil
+		case v.Op == SchemaAlter && v.Alter == AlterColumnNotNull:
+			return alterColumn(v.Name, AlterColumnNull, nil), nil
+		}
+
+		return nil, errors.New(v.Op.String() + " column " + v.Name)
+	case Key:
+		if v.Op == SchemaCreate && v.Name != "" {
+			return dropKey(v.Name, v.Type, nil), nil
+		}
+
+		return nil, errors.New(strings.TrimSpace(v.Op.String() + " key " + v.Name))
+	}
+
+	return nil, errors.New("execute raw command")
+}
+
 // String returns schema operation.
 func (s Schema) String() string {
 	descs := make([]string, len(s.Migrations))
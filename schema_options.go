@@ -14,6 +14,7 @@ func applyTableOptions(table *Table, options []TableOption) {
 
 // ColumnOption interface.
 // Available options are: Nil, Unsigned, Limit, Precision, Scale, Default, Comment, Options.
+// ColumnType is also a column option, it's used to define type of dropped column to make the drop reversible.
 type ColumnOption interface {
 	applyColumn(column *Column)
 }
@@ -197,6 +197,118 @@ func TestSchema_DropIndex(t *testing.T) {
 	}, schema.Migrations[0])
 }
 
+func TestSchema_Reverse(t *testing.T) {
+	var schema Schema
+
+	schema.CreateTable("users", func(t *Table) {
+		t.ID("id")
+	})
+	schema.CreateTableIfNotExists("tags", func(t *Table) {
+		t.ID("id")
+	})
+	schema.AlterTable("users", func(t *AlterTable) {
+		t.String("name")
+		t.RenameColumn("gender", "sex")
+		t.DropColumn("age", Int, Default(0))
+		t.SetNull("sex")
+		t.SetNotNull("name")
+		t.AddUnique([]string{"name"}, Name("name_unique"))
+		t.Check("age_check", "age >= 0")
+	})
+	schema.RenameTable("users", "people")
+	schema.AddColumn("people", "verified", Bool)
+	schema.RenameColumn("people", "name", "full_name")
+	schema.CreateIndex("people", "full_name_idx", []string{"full_name"}, Optional(true))
+
+	reversed, err := schema.Reverse()
+	assert.Nil(t, err)
+	assert.Equal(t, []Migration{
+		Index{Op: SchemaDrop, Table: "people", Name: "full_name_idx", Optional: true},
+		Table{Op: SchemaAlter, Name: "people", Definitions: []TableDefinition{
+			Column{Op: SchemaRename, Name: "full_name", Rename: "name"},
+		}},
+		Table{Op: SchemaAlter, Name: "people", Definitions: []TableDefinition{
+			Column{Op: SchemaDrop, Name: "verified"},
+		}},
+		Table{Op: SchemaRename, Name: "people", Rename: "users"},
+		Table{Op: SchemaAlter, Name: "users", Definitions: []TableDefinition{
+			Key{Op: SchemaDrop, Name: "age_check", Type: CheckKey},
+			Key{Op: SchemaDrop, Name: "name_unique", Type: UniqueKey},
+			Column{Op: SchemaAlter, Alter: AlterColumnNull, Name: "name"},
+			Column{Op: SchemaAlter, Alter: AlterColumnNotNull, Name: "sex"},
+			Column{Op: SchemaCreate, Name: "age", Type: Int, Default: 0},
+			Column{Op: SchemaRename, Name: "sex", Rename: "gender"},
+			Column{Op: SchemaDrop, Name: "name"},
+		}},
+		Table{Op: SchemaDrop, Name: "tags", Optional: true},
+		Table{Op: SchemaDrop, Name: "users"},
+	}, reversed.Migrations)
+}
+
+func TestSchema_Reverse_irreversible(t *testing.T) {
+	tests := []struct {
+		err    string
+		schema func(schema *Schema)
+	}{
+		{
+			err:    "Migration is irreversible: drop table users",
+			schema: func(schema *Schema) { schema.DropTable("users") },
+		},
+		{
+			err:    "Migration is irreversible: alter table users: drop column name",
+			schema: func(schema *Schema) { schema.DropColumn("users", "name") },
+		},
+		{
+			err: "Migration is irreversible: alter table users: alter column name",
+			schema: func(schema *Schema) {
+				schema.AlterTable("users", func(t *AlterTable) { t.ChangeColumn("name", Text) })
+			},
+		},
+		{
+			err: "Migration is irreversible: alter table users: drop key name_unique",
+			schema: func(schema *Schema) {
+				schema.AlterTable("users", func(t *AlterTable) { t.DropConstraint("name_unique") })
+			},
+		},
+		{
+			err: "Migration is irreversible: alter table users: create key",
+			schema: func(schema *Schema) {
+				schema.AlterTable("users", func(t *AlterTable) { t.Unique([]string{"name"}) })
+			},
+		},
+		{
+			err: "Migration is irreversible: alter table users: execute raw command",
+			schema: func(schema *Schema) {
+				schema.AlterTable("users", func(t *AlterTable) { t.Fragment("SQL") })
+			},
+		},
+		{
+			err:    "Migration is irreversible: drop index name_idx on users",
+			schema: func(schema *Schema) { schema.DropIndex("users", "name_idx") },
+		},
+		{
+			err:    "Migration is irreversible: execute raw command",
+			schema: func(schema *Schema) { schema.Exec("SQL") },
+		},
+		{
+			err:    "Migration is irreversible: run go code",
+			schema: func(schema *Schema) { schema.Do(func(Repository) error { return nil }) },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.err, func(t *testing.T) {
+			var schema Schema
+			schema.CreateTable("users", func(t *Table) {})
+			test.schema(&schema)
+
+			_, err := schema.Reverse()
+			assert.Equal(t, test.err, err.Error())
+			assert.IsType(t, IrreversibleError{}, err)
+		})
+	}
+}
+
 func TestRaw(t *testing.T) {
 	var schema Schema
 
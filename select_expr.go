@@ -0,0 +1,127 @@
+package rel
+
+// Expr defines function expression in select clause, such as aggregate and window function.
+// Use As to set the alias, it's used to scan the result to the matching struct field.
+type Expr struct {
+	Func     string
+	Distinct bool
+	Args     []interface{}
+	Window   *Window
+	Alias    string
+}
+
+// As set alias of the expression.
+func (e Expr) As(alias string) Expr {
+	e.Alias = alias
+	return e
+}
+
+// Over turns the expression into window function using given window.
+func (e Expr) Over(window Window) Expr {
+	e.Window = &window
+	return e
+}
+
+// NewExpr defines function expression with arguments.
+// String argument is treated as field, while other value is used as the argument value.
+func NewExpr(fn string, args ...interface{}) Expr {
+	return Expr{
+		Func: fn,
+		Args: args,
+	}
+}
+
+// Count aggregates number of non null value of field, use "*" to count all rows.
+func Count(field string) Expr {
+	return NewExpr("COUNT", field)
+}
+
+// CountDistinct aggregates number of distinct value of field.
+func CountDistinct(field string) Expr {
+	return Expr{
+		Func:     "COUNT",
+		Distinct: true,
+		Args:     []interface{}{field},
+	}
+}
+
+// Sum aggregates sum of field.
+func Sum(field string) Expr {
+	return NewExpr("SUM", field)
+}
+
+// Avg aggregates average value of field.
+func Avg(field string) Expr {
+	return NewExpr("AVG", field)
+}
+
+// Max aggregates maximum value of field.
+func Max(field string) Expr {
+	return NewExpr("MAX", field)
+}
+
+// Min aggregates minimum value of field.
+func Min(field string) Expr {
+	return NewExpr("MIN", field)
+}
+
+// RowNumber window function returns sequential number of the row within its partition.
+func RowNumber() Expr {
+	return NewExpr("ROW_NUMBER")
+}
+
+// Rank window function returns rank of the row within its partition, with gaps.
+func Rank() Expr {
+	return NewExpr("RANK")
+}
+
+// DenseRank window function returns rank of the row within its partition, without gaps.
+func DenseRank() Expr {
+	return NewExpr("DENSE_RANK")
+}
+
+// Lag window function returns value of field from the row that is offset rows before the current row.
+func Lag(field string, offset int) Expr {
+	return NewExpr("LAG", field, offset)
+}
+
+// Lead window function returns value of field from the row that is offset rows after the current row.
+func Lead(field string, offset int) Expr {
+	return NewExpr("LEAD", field, offset)
+}
+
+// Window defines partition and sort of window function.
+type Window struct {
+	Partition []string
+	Sort      []SortQuery
+}
+
+// SortAsc sorts rows within the partition in ascending order.
+func (w Window) SortAsc(fields ...string) Window {
+	for i := range fields {
+		w.Sort = append(w.Sort, NewSortAsc(fields[i]))
+	}
+
+	return w
+}
+
+// SortDesc sorts rows within the partition in descending order.
+func (w Window) SortDesc(fields ...string) Window {
+	for i := range fields {
+		w.Sort = append(w.Sort, NewSortDesc(fields[i]))
+	}
+
+	return w
+}
+
+// PartitionBy creates window that divides rows into partitions using fields.
+func PartitionBy(fields ...string) Window {
+	return Window{
+		Partition: fields,
+	}
+}
+
+// OrderBy creates window without partition that sorts rows in ascending order.
+func OrderBy(fields ...string) Window {
+	return Window{}.SortAsc(fields...)
+}
@@ -0,0 +1,53 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpr(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected rel.Expr
+		expr     rel.Expr
+	}{
+		{"Count", rel.Expr{Func: "COUNT", Args: []interface{}{"*"}, Alias: "count"}, rel.Count("*").As("count")},
+		{"CountDistinct", rel.Expr{Func: "COUNT", Distinct: true, Args: []interface{}{"user_id"}}, rel.CountDistinct("user_id")},
+		{"Sum", rel.Expr{Func: "SUM", Args: []interface{}{"amount"}}, rel.Sum("amount")},
+		{"Avg", rel.Expr{Func: "AVG", Args: []interface{}{"amount"}}, rel.Avg("amount")},
+		{"Max", rel.Expr{Func: "MAX", Args: []interface{}{"amount"}}, rel.Max("amount")},
+		{"Min", rel.Expr{Func: "MIN", Args: []interface{}{"amount"}}, rel.Min("amount")},
+		{"RowNumber", rel.Expr{Func: "ROW_NUMBER", Window: &rel.Window{Partition: []string{"user_id"}}}, rel.RowNumber().Over(rel.PartitionBy("user_id"))},
+		{"Rank", rel.Expr{Func: "RANK", Window: &rel.Window{Sort: []rel.SortQuery{rel.NewSortDesc("score")}}}, rel.Rank().Over(rel.Window{}.SortDesc("score"))},
+		{"DenseRank", rel.Expr{Func: "DENSE_RANK", Window: &rel.Window{Sort: []rel.SortQuery{rel.NewSortAsc("score")}}}, rel.DenseRank().Over(rel.OrderBy("score"))},
+		{"Lag", rel.Expr{Func: "LAG", Args: []interface{}{"amount", 1}}, rel.Lag("amount", 1)},
+		{"Lead", rel.Expr{Func: "LEAD", Args: []interface{}{"amount", 1}}, rel.Lead("amount", 1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.expr)
+		})
+	}
+}
+
+func TestQuery_SelectExpr(t *testing.T) {
+	var (
+		result = rel.Query{
+			Table: "transactions",
+			SelectQuery: rel.SelectQuery{
+				Fields: []string{"user_id"},
+				Exprs:  []rel.Expr{rel.Sum("amount").As("total"), rel.Count("*").As("count")},
+			},
+			CascadeQuery: true,
+		}
+	)
+
+	assert.Equal(t, result, rel.Build("transactions", rel.Select("user_id").SelectExpr(rel.Sum("amount").As("total")).SelectExpr(rel.Count("*").As("count"))))
+	assert.Equal(t, result, rel.Build("", rel.From("transactions"), rel.Select("user_id").SelectExpr(rel.Sum("amount").As("total"), rel.Count("*").As("count"))))
+
+	result.SelectQuery.Fields = nil
+	assert.Equal(t, result, rel.Build("transactions", rel.SelectExpr(rel.Sum("amount").As("total"), rel.Count("*").As("count"))))
+}
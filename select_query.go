@@ -4,6 +4,7 @@ package rel
 type SelectQuery struct {
 	OnlyDistinct bool
 	Fields       []string
+	Exprs        []Expr
 }
 
 // Distinct select query.
@@ -12,6 +13,12 @@ func (sq SelectQuery) Distinct() SelectQuery {
 	return sq
 }
 
+// Expr adds expressions to select query, expressions are selected after fields.
+func (sq SelectQuery) Expr(exprs ...Expr) SelectQuery {
+	sq.Exprs = append(sq.Exprs, exprs...)
+	return sq
+}
+
 // NewSelect query.
 func NewSelect(fields ...string) SelectQuery {
 	return SelectQuery{
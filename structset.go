@@ -20,24 +20,22 @@ type Structset struct {
 // Apply mutation.
 func (s Structset) Apply(doc *Document, mut *Mutation) {
 	var (
-		pFields = s.doc.PrimaryFields()
-		t       = now().Truncate(time.Second)
+		pFields   = s.doc.PrimaryFields()
+		createdAt = doc.data.flagFieldName(HasCreatedAt)
+		updatedAt = doc.data.flagFieldName(HasUpdatedAt)
+		t         = now().Truncate(time.Second)
 	)
 
 	for _, field := range s.doc.Fields() {
 		switch field {
-		case "created_at", "inserted_at":
-			if doc.Flag(HasCreatedAt) {
-				if value, ok := doc.Value(field); ok && value.(time.Time).IsZero() {
-					s.set(doc, mut, field, t, true)
-					continue
-				}
-			}
-		case "updated_at":
-			if doc.Flag(HasUpdatedAt) {
+		case createdAt:
+			if value, ok := doc.Value(field); ok && value.(time.Time).IsZero() {
 				s.set(doc, mut, field, t, true)
 				continue
 			}
+		case updatedAt:
+			s.set(doc, mut, field, t, true)
+			continue
 		}
 
 		if len(pFields) == 1 && pFields[0] == field {
@@ -58,7 +56,7 @@ func (s Structset) set(doc *Document, mut *Mutation, field string, value interfa
 		panic(fmt.Sprint("rel: cannot assign ", value, " as ", field, " into ", doc.Table()))
 	}
 
-	mut.Add(Set(field, value))
+	mut.Add(Set(field, doc.mutateValue(field, value)))
 }
 
 func (s Structset) applyValue(doc *Document, mut *Mutation, field string, skipZero bool) {
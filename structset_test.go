@@ -170,6 +170,29 @@ func TestStructset_invalidCreatedAtType(t *testing.T) {
 	assert.Equal(t, mutation, Apply(doc, NewStructset(&user, false)))
 }
 
+func TestStructset_json(t *testing.T) {
+	var (
+		setting = Setting{
+			ID:   1,
+			Data: map[string]interface{}{"theme": "dark"},
+		}
+		doc      = NewDocument(&setting)
+		mutation = Mutation{
+			Cascade: true,
+			Mutates: map[string]Mutate{
+				"id":      Set("id", 1),
+				"name":    Set("name", ""),
+				"data":    Set("data", jsonValue{v: map[string]interface{}{"theme": "dark"}}),
+				"labels":  Set("labels", jsonValue{v: []string(nil)}),
+				"owner":   Set("owner", jsonValue{v: nil}),
+				"details": Set("details", jsonValue{v: setting.Details}),
+			},
+		}
+	)
+
+	assert.Equal(t, mutation, Apply(doc, NewStructset(&setting, false)))
+}
+
 func TestStructset_differentStruct(t *testing.T) {
 	type UserTmp struct {
 		ID   int
@@ -26,11 +26,28 @@ func (t *Table) ID(name string, options ...ColumnOption) {
 	t.Column(name, ID, options...)
 }
 
+// BigID defines a column with name and BigID type.
+// the resulting database type will depends on database.
+func (t *Table) BigID(name string, options ...ColumnOption) {
+	t.Column(name, BigID, options...)
+}
+
+// Serial defines a column with name and Serial type.
+// sqlite only supports auto increment on primary key, so it's created as primary key.
+func (t *Table) Serial(name string, options ...ColumnOption) {
+	t.Column(name, Serial, options...)
+}
+
 // Bool defines a column with name and Bool type.
 func (t *Table) Bool(name string, options ...ColumnOption) {
 	t.Column(name, Bool, options...)
 }
 
+// SmallInt defines a column with name and SmallInt type.
+func (t *Table) SmallInt(name string, options ...ColumnOption) {
+	t.Column(name, SmallInt, options...)
+}
+
 // Int defines a column with name and Int type.
 func (t *Table) Int(name string, options ...ColumnOption) {
 	t.Column(name, Int, options...)
@@ -81,6 +98,43 @@ func (t *Table) Timestamp(name string, options ...ColumnOption) {
 	t.Column(name, Timestamp, options...)
 }
 
+// JSON defines a column with name and JSON type.
+func (t *Table) JSON(name string, options ...ColumnOption) {
+	t.Column(name, JSON, options...)
+}
+
+// UUID defines a column with name and UUID type.
+func (t *Table) UUID(name string, options ...ColumnOption) {
+	t.Column(name, UUID, options...)
+}
+
+// Binary defines a column with name and Binary type.
+func (t *Table) Binary(name string, options ...ColumnOption) {
+	t.Column(name, Binary, options...)
+}
+
+// Enum defines a column with name and Enum type that only accepts given values.
+func (t *Table) Enum(name string, values []string, options ...ColumnOption) {
+	column := createColumn(name, Enum, options)
+	column.Values = values
+
+	t.Definitions = append(t.Definitions, column)
+}
+
+// Array defines a column with name and Array type of given element type.
+// Column options such as limit is applied to the element, database without array support stores it as json.
+func (t *Table) Array(name string, of ColumnType, options ...ColumnOption) {
+	column := createColumn(name, Array, options)
+	column.Element = of
+
+	t.Definitions = append(t.Definitions, column)
+}
+
+// Inet defines a column with name and Inet type to store ip address.
+func (t *Table) Inet(name string, options ...ColumnOption) {
+	t.Column(name, Inet, options...)
+}
+
 // PrimaryKey defines a primary key for table.
 func (t *Table) PrimaryKey(column string, options ...KeyOption) {
 	t.PrimaryKeys([]string{column}, options...)
@@ -101,6 +155,11 @@ func (t *Table) Unique(columns []string, options ...KeyOption) {
 	t.Definitions = append(t.Definitions, createKeys(columns, UniqueKey, options))
 }
 
+// Check defines a named check constraint using sql expression.
+func (t *Table) Check(name string, expr string, options ...KeyOption) {
+	t.Definitions = append(t.Definitions, createCheck(name, expr, options))
+}
+
 // Fragment defines anything using sql fragment.
 func (t *Table) Fragment(fragment string) {
 	t.Definitions = append(t.Definitions, Raw(fragment))
@@ -123,10 +182,57 @@ func (at *AlterTable) RenameColumn(name string, newName string, options ...Colum
 }
 
 // DropColumn from this table.
+// The column type can be passed as option to make it reversible, for example: DropColumn("name", String, Limit(50)).
 func (at *AlterTable) DropColumn(name string, options ...ColumnOption) {
 	at.Definitions = append(at.Definitions, dropColumn(name, options))
 }
 
+// ChangeColumn type along with its limit, precision and scale.
+// The column is redefined on mysql and sqlite, options such as Required and Default need to be specified again to be kept.
+func (at *AlterTable) ChangeColumn(name string, typ ColumnType, options ...ColumnOption) {
+	at.Definitions = append(at.Definitions, changeColumn(name, typ, options))
+}
+
+// SetNull allows nil values in the column.
+func (at *AlterTable) SetNull(name string) {
+	at.Definitions = append(at.Definitions, alterColumn(name, AlterColumnNull, nil))
+}
+
+// SetNotNull disallows nil values in the column.
+func (at *AlterTable) SetNotNull(name string) {
+	at.Definitions = append(at.Definitions, alterColumn(name, AlterColumnNotNull, nil))
+}
+
+// SetDefault value of the column.
+func (at *AlterTable) SetDefault(name string, value interface{}) {
+	at.Definitions = append(at.Definitions, alterColumn(name, AlterColumnDefault, value))
+}
+
+// DropDefault value of the column.
+func (at *AlterTable) DropDefault(name string) {
+	at.Definitions = append(at.Definitions, alterColumn(name, AlterColumnDropDefault, nil))
+}
+
+// AddForeignKey to this table.
+func (at *AlterTable) AddForeignKey(column string, refTable string, refColumn string, options ...KeyOption) {
+	at.ForeignKey(column, refTable, refColumn, options...)
+}
+
+// DropForeignKey by name.
+func (at *AlterTable) DropForeignKey(name string, options ...KeyOption) {
+	at.Definitions = append(at.Definitions, dropKey(name, ForeignKey, options))
+}
+
+// AddUnique key for columns.
+func (at *AlterTable) AddUnique(columns []string, options ...KeyOption) {
+	at.Unique(columns, options...)
+}
+
+// DropConstraint by name, it can be used to drop unique key, foreign key and check constraint.
+func (at *AlterTable) DropConstraint(name string, options ...KeyOption) {
+	at.Definitions = append(at.Definitions, dropKey(name, "", options))
+}
+
 func createTable(name string, options []TableOption) Table {
 	table := Table{
 		Op:   SchemaCreate,
@@ -105,6 +105,80 @@ func TestTable(t *testing.T) {
 		}, table.Definitions[len(table.Definitions)-1])
 	})
 
+	t.Run("JSON", func(t *testing.T) {
+		table.JSON("json")
+		assert.Equal(t, Column{
+			Name: "json",
+			Type: JSON,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("BigID", func(t *testing.T) {
+		table.BigID("big_id")
+		assert.Equal(t, Column{
+			Name: "big_id",
+			Type: BigID,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("Serial", func(t *testing.T) {
+		table.Serial("serial")
+		assert.Equal(t, Column{
+			Name: "serial",
+			Type: Serial,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("SmallInt", func(t *testing.T) {
+		table.SmallInt("smallint")
+		assert.Equal(t, Column{
+			Name: "smallint",
+			Type: SmallInt,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("UUID", func(t *testing.T) {
+		table.UUID("uuid")
+		assert.Equal(t, Column{
+			Name: "uuid",
+			Type: UUID,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		table.Binary("binary")
+		assert.Equal(t, Column{
+			Name: "binary",
+			Type: Binary,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("Enum", func(t *testing.T) {
+		table.Enum("enum", []string{"draft", "published"})
+		assert.Equal(t, Column{
+			Name:   "enum",
+			Type:   Enum,
+			Values: []string{"draft", "published"},
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		table.Array("array", String)
+		assert.Equal(t, Column{
+			Name:    "array",
+			Type:    Array,
+			Element: String,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("Inet", func(t *testing.T) {
+		table.Inet("inet")
+		assert.Equal(t, Column{
+			Name: "inet",
+			Type: Inet,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
 	t.Run("PrimaryKey", func(t *testing.T) {
 		table.PrimaryKey("id")
 		assert.Equal(t, Key{
@@ -158,6 +232,102 @@ func TestAlterTable(t *testing.T) {
 			Name: "column",
 		}, table.Definitions[len(table.Definitions)-1])
 	})
+
+	t.Run("ChangeColumn", func(t *testing.T) {
+		table.ChangeColumn("column", String, Limit(50))
+		assert.Equal(t, Column{
+			Op:    SchemaAlter,
+			Alter: AlterColumnType,
+			Name:  "column",
+			Type:  String,
+			Limit: 50,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("SetNull", func(t *testing.T) {
+		table.SetNull("column")
+		assert.Equal(t, Column{
+			Op:    SchemaAlter,
+			Alter: AlterColumnNull,
+			Name:  "column",
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("SetNotNull", func(t *testing.T) {
+		table.SetNotNull("column")
+		assert.Equal(t, Column{
+			Op:    SchemaAlter,
+			Alter: AlterColumnNotNull,
+			Name:  "column",
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("SetDefault", func(t *testing.T) {
+		table.SetDefault("column", "value")
+		assert.Equal(t, Column{
+			Op:      SchemaAlter,
+			Alter:   AlterColumnDefault,
+			Name:    "column",
+			Default: "value",
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("DropDefault", func(t *testing.T) {
+		table.DropDefault("column")
+		assert.Equal(t, Column{
+			Op:    SchemaAlter,
+			Alter: AlterColumnDropDefault,
+			Name:  "column",
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("AddForeignKey", func(t *testing.T) {
+		table.AddForeignKey("user_id", "users", "id", Name("user_id_fk"))
+		assert.Equal(t, Key{
+			Name:    "user_id_fk",
+			Columns: []string{"user_id"},
+			Type:    ForeignKey,
+			Reference: ForeignKeyReference{
+				Table:   "users",
+				Columns: []string{"id"},
+			},
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("DropForeignKey", func(t *testing.T) {
+		table.DropForeignKey("user_id_fk")
+		assert.Equal(t, Key{
+			Op:   SchemaDrop,
+			Name: "user_id_fk",
+			Type: ForeignKey,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("AddUnique", func(t *testing.T) {
+		table.AddUnique([]string{"code"}, Name("code_unique"))
+		assert.Equal(t, Key{
+			Name:    "code_unique",
+			Columns: []string{"code"},
+			Type:    UniqueKey,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		table.Check("score_check", "score >= 0")
+		assert.Equal(t, Key{
+			Name: "score_check",
+			Type: CheckKey,
+			Expr: "score >= 0",
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
+	t.Run("DropConstraint", func(t *testing.T) {
+		table.DropConstraint("code_unique")
+		assert.Equal(t, Key{
+			Op:   SchemaDrop,
+			Name: "code_unique",
+		}, table.Definitions[len(table.Definitions)-1])
+	})
 }
 
 func TestCreateTable(t *testing.T) {
@@ -6,6 +6,10 @@ import (
 )
 
 func indirect(rv reflect.Value) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+
 	if rv.Kind() == reflect.Ptr {
 		if rv.IsNil() {
 			return nil
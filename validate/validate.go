@@ -0,0 +1,174 @@
+// Package validate provides built-in validators to be used with rel.Validate.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/go-rel/rel"
+)
+
+// Required validates that field is not zero.
+func Required() rel.Validator {
+	return rel.ValidatorFunc(func(ctx context.Context, repo rel.Repository, doc *rel.Document, field string) (string, error) {
+		value, _ := doc.Value(field)
+		if value == nil {
+			return "is required", nil
+		}
+
+		var (
+			rv = reflect.ValueOf(value)
+		)
+
+		switch rv.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			if rv.Len() == 0 {
+				return "is required", nil
+			}
+		default:
+			if rv.IsZero() {
+				return "is required", nil
+			}
+		}
+
+		return "", nil
+	})
+}
+
+// Length validates that length of string or slice field is between min and max.
+// Negative max means the length is unlimited.
+func Length(min int, max int) rel.Validator {
+	return rel.ValidatorFunc(func(ctx context.Context, repo rel.Repository, doc *rel.Document, field string) (string, error) {
+		value, _ := doc.Value(field)
+		if value == nil {
+			return "", nil
+		}
+
+		var (
+			rv     = reflect.ValueOf(value)
+			length int
+		)
+
+		switch rv.Kind() {
+		case reflect.String:
+			length = len([]rune(rv.String()))
+		case reflect.Slice, reflect.Array, reflect.Map:
+			length = rv.Len()
+		default:
+			return "", nil
+		}
+
+		if length < min || (max >= 0 && length > max) {
+			if max < 0 {
+				return fmt.Sprintf("length must be at least %d", min), nil
+			}
+
+			return fmt.Sprintf("length must be between %d and %d", min, max), nil
+		}
+
+		return "", nil
+	})
+}
+
+// Between validates that numeric field is between min and max (inclusive).
+func Between(min float64, max float64) rel.Validator {
+	return rel.ValidatorFunc(func(ctx context.Context, repo rel.Repository, doc *rel.Document, field string) (string, error) {
+		value, _ := doc.Value(field)
+		if value == nil {
+			return "", nil
+		}
+
+		var (
+			rv = reflect.ValueOf(value)
+			n  float64
+		)
+
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = float64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = float64(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			n = rv.Float()
+		default:
+			return "", nil
+		}
+
+		if n < min || n > max {
+			return fmt.Sprintf("must be between %v and %v", min, max), nil
+		}
+
+		return "", nil
+	})
+}
+
+// Format validates that string field matches the regular expression.
+func Format(re *regexp.Regexp) rel.Validator {
+	return rel.ValidatorFunc(func(ctx context.Context, repo rel.Repository, doc *rel.Document, field string) (string, error) {
+		if value, ok := doc.Value(field); ok {
+			if str, ok := value.(string); ok && !re.MatchString(str) {
+				return "has invalid format", nil
+			}
+		}
+
+		return "", nil
+	})
+}
+
+// Inclusion validates that field value is one of the given values.
+func Inclusion(values ...interface{}) rel.Validator {
+	return rel.ValidatorFunc(func(ctx context.Context, repo rel.Repository, doc *rel.Document, field string) (string, error) {
+		value, _ := doc.Value(field)
+		if value == nil {
+			return "", nil
+		}
+
+		for i := range values {
+			if values[i] == value {
+				return "", nil
+			}
+		}
+
+		return "is not included in the list", nil
+	})
+}
+
+// Unique validates that no other record in the table has the same field value.
+// The check is executed using repository, within the same transaction if any, and always reads from primary database.
+func Unique() rel.Validator {
+	return rel.ValidatorFunc(func(ctx context.Context, repo rel.Repository, doc *rel.Document, field string) (string, error) {
+		value, _ := doc.Value(field)
+		if value == nil {
+			return "", nil
+		}
+
+		var (
+			filter  = rel.Eq(field, value)
+			pFields = doc.PrimaryFields()
+			pValues = doc.PrimaryValues()
+			primary = make([]rel.FilterQuery, len(pFields))
+		)
+
+		// exclude the record itself when it's already persisted.
+		if doc.Persisted() {
+			for i := range pFields {
+				primary[i] = rel.Eq(pFields[i], pValues[i])
+			}
+
+			filter = filter.And(rel.Not(primary...))
+		}
+
+		count, err := repo.Count(rel.WithPrimary(ctx), doc.Table(), filter)
+		if err != nil {
+			return "", err
+		}
+
+		if count > 0 {
+			return "has already been taken", nil
+		}
+
+		return "", nil
+	})
+}
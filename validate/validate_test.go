@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+type User struct {
+	ID     int
+	Name   string
+	Email  *string
+	Age    int
+	Score  float64
+	Tags   []string
+	Gender string
+}
+
+func validate(t *testing.T, validator rel.Validator, record interface{}, field string) string {
+	message, err := validator.Validate(context.TODO(), nil, rel.NewDocument(record), field)
+	assert.Nil(t, err)
+	return message
+}
+
+func TestRequired(t *testing.T) {
+	var (
+		email = "user@example.com"
+	)
+
+	assert.Equal(t, "is required", validate(t, Required(), &User{}, "name"))
+	assert.Equal(t, "is required", validate(t, Required(), &User{}, "email"))
+	assert.Equal(t, "is required", validate(t, Required(), &User{}, "age"))
+	assert.Equal(t, "is required", validate(t, Required(), &User{Tags: []string{}}, "tags"))
+	assert.Equal(t, "", validate(t, Required(), &User{Name: "name"}, "name"))
+	assert.Equal(t, "", validate(t, Required(), &User{Email: &email}, "email"))
+	assert.Equal(t, "", validate(t, Required(), &User{Age: 1}, "age"))
+}
+
+func TestLength(t *testing.T) {
+	assert.Equal(t, "length must be between 2 and 4", validate(t, Length(2, 4), &User{Name: "a"}, "name"))
+	assert.Equal(t, "length must be between 2 and 4", validate(t, Length(2, 4), &User{Name: "abcde"}, "name"))
+	assert.Equal(t, "", validate(t, Length(2, 4), &User{Name: "ab"}, "name"))
+	assert.Equal(t, "", validate(t, Length(2, 4), &User{Name: "ąęść"}, "name"))
+	assert.Equal(t, "length must be at least 1", validate(t, Length(1, -1), &User{}, "tags"))
+	assert.Equal(t, "", validate(t, Length(1, -1), &User{}, "email"))
+	assert.Equal(t, "", validate(t, Length(1, -1), &User{}, "age"))
+}
+
+func TestBetween(t *testing.T) {
+	assert.Equal(t, "must be between 0 and 150", validate(t, Between(0, 150), &User{Age: -1}, "age"))
+	assert.Equal(t, "must be between 0 and 150", validate(t, Between(0, 150), &User{Age: 151}, "age"))
+	assert.Equal(t, "", validate(t, Between(0, 150), &User{Age: 150}, "age"))
+	assert.Equal(t, "must be between 0 and 1", validate(t, Between(0, 1), &User{Score: 1.5}, "score"))
+	assert.Equal(t, "", validate(t, Between(0, 1), &User{}, "name"))
+}
+
+func TestFormat(t *testing.T) {
+	var (
+		re      = regexp.MustCompile(`^[^@]+@[^@]+$`)
+		valid   = "user@example.com"
+		invalid = "user"
+	)
+
+	assert.Equal(t, "has invalid format", validate(t, Format(re), &User{Email: &invalid}, "email"))
+	assert.Equal(t, "", validate(t, Format(re), &User{Email: &valid}, "email"))
+	assert.Equal(t, "", validate(t, Format(re), &User{}, "email"))
+}
+
+func TestInclusion(t *testing.T) {
+	assert.Equal(t, "is not included in the list", validate(t, Inclusion("male", "female"), &User{Gender: "other"}, "gender"))
+	assert.Equal(t, "", validate(t, Inclusion("male", "female"), &User{Gender: "male"}, "gender"))
+	assert.Equal(t, "", validate(t, Inclusion("male", "female"), &User{}, "email"))
+}
+
+func TestUnique(t *testing.T) {
+	var (
+		repo  = reltest.New()
+		email = "user@example.com"
+		user  = User{Email: &email}
+	)
+
+	repo.ExpectCount("users", rel.Eq("email", email)).Result(1)
+	message, err := Unique().Validate(context.TODO(), repo, rel.NewDocument(&user), "email")
+	assert.Nil(t, err)
+	assert.Equal(t, "has already been taken", message)
+	repo.AssertExpectations(t)
+}
+
+func TestUnique_persisted(t *testing.T) {
+	var (
+		repo  = reltest.New()
+		email = "user@example.com"
+		user  = User{ID: 1, Email: &email}
+	)
+
+	repo.ExpectCount("users", rel.Eq("email", email).And(rel.Ne("id", 1))).Result(0)
+	message, err := Unique().Validate(context.TODO(), repo, rel.NewDocument(&user), "email")
+	assert.Nil(t, err)
+	assert.Equal(t, "", message)
+	repo.AssertExpectations(t)
+}
+
+func TestUnique_error(t *testing.T) {
+	var (
+		repo  = reltest.New()
+		email = "user@example.com"
+		user  = User{Email: &email}
+	)
+
+	repo.ExpectCount("users", rel.Eq("email", email)).ConnectionClosed()
+	_, err := Unique().Validate(context.TODO(), repo, rel.NewDocument(&user), "email")
+	assert.Equal(t, sql.ErrConnDone, err)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package rel
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// ValidationError returned when record fails validation, it contains list of messages for each invalid field.
+type ValidationError map[string][]string
+
+// Add a message to field.
+func (ve ValidationError) Add(field string, message string) {
+	ve[field] = append(ve[field], message)
+}
+
+// Error message.
+func (ve ValidationError) Error() string {
+	var (
+		fields   = make([]string, 0, len(ve))
+		messages = make([]string, 0, len(ve))
+	)
+
+	for field := range ve {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		for _, message := range ve[field] {
+			messages = append(messages, field+" "+message)
+		}
+	}
+
+	return "rel: validation failed: " + strings.Join(messages, ", ")
+}
+
+// Validator validates a field of document.
+// It returns non empty message when field is invalid, error is only returned when validation can't be performed.
+type Validator interface {
+	Validate(ctx context.Context, repo Repository, doc *Document, field string) (string, error)
+}
+
+// ValidatorFunc is an adapter to allow the use of ordinary function as validator.
+type ValidatorFunc func(ctx context.Context, repo Repository, doc *Document, field string) (string, error)
+
+// Validate field using the function.
+func (vf ValidatorFunc) Validate(ctx context.Context, repo Repository, doc *Document, field string) (string, error) {
+	return vf(ctx, repo, doc, field)
+}
+
+// Validation mutator, it declares validators of a field that are run before the record is inserted or updated.
+type Validation struct {
+	Field      string
+	Validators []Validator
+	changed    func(field string) bool
+}
+
+// Apply mutation.
+func (v Validation) Apply(doc *Document, mutation *Mutation) {
+	mutation.Validations = append(mutation.Validations, v)
+}
+
+func (v Validation) validate(ctx context.Context, repo Repository, doc *Document, errs ValidationError) error {
+	if v.changed != nil && !v.changed(v.Field) {
+		return nil
+	}
+
+	for _, validator := range v.Validators {
+		message, err := validator.Validate(ctx, repo, doc, v.Field)
+		if err != nil {
+			return err
+		}
+
+		if message != "" {
+			errs.Add(v.Field, message)
+		}
+	}
+
+	return nil
+}
+
+// Validate field using validators.
+func Validate(field string, validators ...Validator) Validation {
+	return Validation{
+		Field:      field,
+		Validators: validators,
+	}
+}
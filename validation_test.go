@@ -0,0 +1,56 @@
+package rel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	var (
+		err = ValidationError{}
+	)
+
+	err.Add("name", "is required")
+	err.Add("age", "must be between 0 and 150")
+	err.Add("name", "has invalid format")
+
+	assert.Equal(t, ValidationError{
+		"name": {"is required", "has invalid format"},
+		"age":  {"must be between 0 and 150"},
+	}, err)
+	assert.Equal(t, "rel: validation failed: age must be between 0 and 150, name is required, name has invalid format", err.Error())
+}
+
+func TestValidation_Apply(t *testing.T) {
+	var (
+		user       = User{Name: "name"}
+		doc        = NewDocument(&user)
+		validation = Validate("name")
+		mutation   = Apply(doc, validation)
+	)
+
+	assert.Equal(t, []Validation{validation}, mutation.Validations)
+	assert.Equal(t, Set("name", "name"), mutation.Mutates["name"])
+}
+
+func TestValidation_changeset(t *testing.T) {
+	var (
+		called    []string
+		user      = User{Name: "name", Age: 10}
+		changeset = NewChangeset(&user)
+		validator = ValidatorFunc(func(ctx context.Context, repo Repository, doc *Document, field string) (string, error) {
+			called = append(called, field)
+			return "", nil
+		})
+		errs = ValidationError{}
+	)
+
+	user.Age = 20
+
+	assert.Nil(t, changeset.Validate("name", validator).validate(context.TODO(), nil, NewDocument(&user), errs))
+	assert.Nil(t, changeset.Validate("age", validator).validate(context.TODO(), nil, NewDocument(&user), errs))
+	assert.Equal(t, []string{"age"}, called)
+	assert.Len(t, errs, 0)
+}
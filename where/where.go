@@ -70,6 +70,18 @@ var (
 	// NotLike compares value of field to not match string pattern.
 	NotLike = rel.NotLike
 
+	// Exists check whethers subquery returns any rows.
+	Exists = rel.Exists
+
+	// NotExists check whethers subquery returns no rows.
+	NotExists = rel.NotExists
+
+	// JSONContains checks whether json field contains given value.
+	JSONContains = rel.JSONContains
+
+	// JSONExtract refers to a value inside json field using dot separated path.
+	JSONExtract = rel.JSONExtract
+
 	// Fragment add custom filter.
 	Fragment = rel.FilterFragment
 )
@@ -0,0 +1,30 @@
+package rel
+
+// WithQuery defines common table expression (WITH clause) in query.
+type WithQuery struct {
+	Name      string
+	Query     Query
+	Recursive bool
+}
+
+// Build query.
+func (wq WithQuery) Build(query *Query) {
+	query.WithQuery = append(query.WithQuery, wq)
+}
+
+// NewWith defines a common table expression using given name and query.
+func NewWith(name string, query Query) WithQuery {
+	return WithQuery{
+		Name:  name,
+		Query: query,
+	}
+}
+
+// NewWithRecursive defines a recursive common table expression using given name and query.
+func NewWithRecursive(name string, query Query) WithQuery {
+	return WithQuery{
+		Name:      name,
+		Query:     query,
+		Recursive: true,
+	}
+}
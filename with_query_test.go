@@ -0,0 +1,51 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithQuery(t *testing.T) {
+	var (
+		sub    = rel.From("users").Where(where.Eq("active", true))
+		result = rel.Query{
+			Table: "active_users",
+			WithQuery: []rel.WithQuery{
+				{
+					Name:  "active_users",
+					Query: sub,
+				},
+			},
+			CascadeQuery: true,
+		}
+	)
+
+	assert.Equal(t, result, rel.Build("", rel.From("active_users").With("active_users", sub)))
+	assert.Equal(t, result, rel.Build("", rel.With("active_users", sub).From("active_users")))
+	assert.Equal(t, result, rel.Build("active_users", rel.NewWith("active_users", sub)))
+	assert.Equal(t, result, rel.Build("active_users", rel.Where(), rel.With("active_users", sub)))
+}
+
+func TestWithQuery_recursive(t *testing.T) {
+	var (
+		sub    = rel.From("categories").Where(where.Nil("parent_id"))
+		result = rel.Query{
+			Table: "tree",
+			WithQuery: []rel.WithQuery{
+				{
+					Name:      "tree",
+					Query:     sub,
+					Recursive: true,
+				},
+			},
+			CascadeQuery: true,
+		}
+	)
+
+	assert.Equal(t, result, rel.Build("", rel.From("tree").WithRecursive("tree", sub)))
+	assert.Equal(t, result, rel.Build("", rel.WithRecursive("tree", sub).From("tree")))
+	assert.Equal(t, result, rel.Build("tree", rel.NewWithRecursive("tree", sub)))
+}